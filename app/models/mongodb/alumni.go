@@ -4,11 +4,14 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/utils/importer"
 )
 
 // Struktur utama untuk data Alumni
 type Alumni struct {
 	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"` // gunakan ObjectID untuk MongoDB
+	AlumniID   int                `json:"alumni_id" bson:"alumni_id"` // kunci angka yang dirujuk pekerjaan.alumni_id
 	NIM        string             `json:"nim" bson:"nim"`
 	Nama       string             `json:"nama" bson:"nama"`
 	Jurusan    string             `json:"jurusan" bson:"jurusan"`
@@ -19,28 +22,62 @@ type Alumni struct {
 	Alamat     string             `json:"alamat" bson:"alamat"`
 	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+	CreatedBy  int                `json:"created_by" bson:"created_by"`
 	DeletedAt  *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+	Version    int                `json:"version" bson:"version"` // optimistic locking, dikirim sebagai ETag
+	// ID batch saat alumni di-soft delete; pekerjaan & file yang ikut terhapus memakai ID yang sama
+	DeletionBatch string `json:"deletion_batch,omitempty" bson:"deletion_batch,omitempty"`
+}
+
+// DeletionBatch -> data yang ikut di-soft delete / restore bersama satu alumni
+type DeletionBatch struct {
+	ID        string `json:"batch_id,omitempty" bson:"batch_id,omitempty"`
+	Pekerjaan int64  `json:"pekerjaan" bson:"pekerjaan"`
+	Files     int64  `json:"files" bson:"files"`
+}
+
+// AlumniVersion -> snapshot data alumni pada satu versi.
+// Berlaku sejak ValidFrom sampai ValidTo; ValidTo nil berarti versi saat ini.
+type AlumniVersion struct {
+	AlumniID   primitive.ObjectID `json:"alumni_id" bson:"alumni_id"`
+	Version    int                `json:"version" bson:"version"`
+	NIM        string             `json:"nim" bson:"nim"`
+	Nama       string             `json:"nama" bson:"nama"`
+	Jurusan    string             `json:"jurusan" bson:"jurusan"`
+	Angkatan   int                `json:"angkatan" bson:"angkatan"`
+	TahunLulus int                `json:"tahun_lulus" bson:"tahun_lulus"`
+	Email      string             `json:"email" bson:"email"`
+	NoTelepon  string             `json:"no_telepon" bson:"no_telepon"`
+	Alamat     string             `json:"alamat" bson:"alamat"`
+	ValidFrom  time.Time          `json:"valid_from" bson:"valid_from"`
+	ValidTo    *time.Time         `json:"valid_to" bson:"valid_to,omitempty"`
+	ChangedBy  *int               `json:"changed_by,omitempty" bson:"changed_by,omitempty"` // user yang menggantikan versi ini
+}
+
+// Struktur untuk request revert data Alumni ke versi sebelumnya
+type RevertAlumniRequest struct {
+	Version int `json:"version" validate:"required,min=1"`
 }
 
 // Struktur untuk request pembuatan data Alumni (tanpa ID & timestamp)
 type CreateAlumniRequest struct {
-	NIM        string `json:"nim" bson:"nim"`
-	Nama       string `json:"nama" bson:"nama"`
-	Jurusan    string `json:"jurusan" bson:"jurusan"`
-	Angkatan   int    `json:"angkatan" bson:"angkatan"`
-	TahunLulus int    `json:"tahun_lulus" bson:"tahun_lulus"`
-	Email      string `json:"email" bson:"email"`
+	NIM        string `json:"nim" bson:"nim" validate:"required,nim"`
+	Nama       string `json:"nama" bson:"nama" validate:"required"`
+	Jurusan    string `json:"jurusan" bson:"jurusan" validate:"required"`
+	Angkatan   int    `json:"angkatan" bson:"angkatan" validate:"year"`
+	TahunLulus int    `json:"tahun_lulus" bson:"tahun_lulus" validate:"year,gtefield=Angkatan"`
+	Email      string `json:"email" bson:"email" validate:"required,email"`
 	NoTelepon  string `json:"no_telepon" bson:"no_telepon"`
 	Alamat     string `json:"alamat" bson:"alamat"`
 }
 
 // Struktur untuk update data Alumni
 type UpdateAlumniRequest struct {
-	Nama       string `json:"nama" bson:"nama"`
-	Jurusan    string `json:"jurusan" bson:"jurusan"`
-	Angkatan   int    `json:"angkatan" bson:"angkatan"`
-	TahunLulus int    `json:"tahun_lulus" bson:"tahun_lulus"`
-	Email      string `json:"email" bson:"email"`
+	Nama       string `json:"nama" bson:"nama" validate:"required"`
+	Jurusan    string `json:"jurusan" bson:"jurusan" validate:"required"`
+	Angkatan   int    `json:"angkatan" bson:"angkatan" validate:"year"`
+	TahunLulus int    `json:"tahun_lulus" bson:"tahun_lulus" validate:"year,gtefield=Angkatan"`
+	Email      string `json:"email" bson:"email" validate:"required,email"`
 	NoTelepon  string `json:"no_telepon" bson:"no_telepon"`
 	Alamat     string `json:"alamat" bson:"alamat"`
 }
@@ -48,6 +85,7 @@ type UpdateAlumniRequest struct {
 // Struktur gabungan alumni + pekerjaan
 type AlumniWithPekerjaan struct {
 	ID         primitive.ObjectID `json:"id" bson:"_id"`
+	AlumniID   int                `json:"alumni_id" bson:"alumni_id"`
 	NIM        string             `json:"nim" bson:"nim"`
 	Nama       string             `json:"nama" bson:"nama"`
 	Jurusan    string             `json:"jurusan" bson:"jurusan"`
@@ -60,3 +98,14 @@ type AlumniWithPekerjaan struct {
 	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
 	Pekerjaan  []Pekerjaan        `json:"pekerjaan" bson:"pekerjaan"`
 }
+
+// Struktur ringkasan hasil import alumni dari CSV / XLSX
+type ImportAlumniReport struct {
+	DryRun  bool                `json:"dry_run"`
+	Mode    string              `json:"mode"` // insert | upsert
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Failed  int                 `json:"failed"`
+	Errors  []importer.RowError `json:"errors"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/utils/audit"
+)
+
+// AuditLog -> satu catatan perubahan data
+type AuditLog struct {
+	ID        primitive.ObjectID      `json:"id" bson:"_id,omitempty"`
+	ActorID   *int                    `json:"actor_id" bson:"actor_id"`
+	Action    string                  `json:"action" bson:"action"`
+	Entity    string                  `json:"entity" bson:"entity"`
+	EntityID  string                  `json:"entity_id" bson:"entity_id"`
+	IP        string                  `json:"ip" bson:"ip"`
+	Diff      map[string]audit.Change `json:"diff" bson:"diff"`
+	CreatedAt time.Time               `json:"created_at" bson:"created_at"`
+}
+
+// AuditFilter -> filter untuk GET /api/audit
+type AuditFilter struct {
+	ActorID  *int
+	Action   string
+	Entity   string
+	EntityID string
+	From     *time.Time
+	To       *time.Time
+}
+
+// AuditResponse -> response untuk endpoint /audit
+type AuditResponse struct {
+	Data []*AuditLog `json:"data" bson:"data"`
+	Meta *MetaInfo   `json:"meta" bson:"meta"`
+}
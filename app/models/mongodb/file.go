@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type File struct {
     ID           int64     `json:"id" bson:"id"`
@@ -10,6 +14,11 @@ type File struct {
     FileSize     int64     `json:"file_size" bson:"file_size"`
     FileType     string    `json:"file_type" bson:"file_type"`
     UploadedAt   time.Time `json:"uploaded_at" bson:"uploaded_at"`
+    Version      int       `json:"version" bson:"version"` // optimistic locking, dikirim sebagai ETag
+    // Pemilik file (opsional); file ikut di-soft delete saat alumninya dihapus
+    AlumniID      *primitive.ObjectID `json:"alumni_id,omitempty" bson:"alumni_id,omitempty"`
+    DeletedAt     *time.Time          `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+    DeletionBatch string              `json:"deletion_batch,omitempty" bson:"deletion_batch,omitempty"`
 }
 
 type FileResponse struct {
@@ -20,4 +29,6 @@ type FileResponse struct {
     FileSize     int64     `json:"file_size"`
     FileType     string    `json:"file_type"`
     UploadedAt   time.Time `json:"uploaded_at"`
+    Version      int       `json:"version"`
+    AlumniID     string    `json:"alumni_id,omitempty"`
 }
@@ -9,6 +9,9 @@ import (
 type Pekerjaan struct {
     ID                  primitive.ObjectID `json:"_id" bson:"_id,omitempty"`
     AlumniID            int                `json:"alumni_id" bson:"alumni_id"`
+    // AlumniLinked -> alumni_id diisi saat alumni sudah punya alumni_id (dibuat setelah relasi ada),
+    // hanya pekerjaan ini yang ikut terhapus bersama alumninya. Pekerjaan lama tidak pernah di-set.
+    AlumniLinked        bool               `json:"-" bson:"alumni_linked,omitempty"`
     NamaPerusahaan      string             `json:"nama_perusahaan" bson:"nama_perusahaan"`
     PosisiJabatan       string             `json:"posisi_jabatan" bson:"posisi_jabatan"`
     BidangIndustri      string             `json:"bidang_industri" bson:"bidang_industri"`
@@ -22,30 +25,32 @@ type Pekerjaan struct {
     UpdatedAt           time.Time          `json:"updated_at" bson:"updated_at"`
     CreatedBy           int                `json:"created_by" bson:"created_by"`
     DeletedAt           *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
+    Version             int                `json:"version" bson:"version"` // optimistic locking, dikirim sebagai ETag
+    DeletionBatch       string             `json:"deletion_batch,omitempty" bson:"deletion_batch,omitempty"`
 }
 
 type CreatePekerjaanRequest struct {
-    AlumniID           int    `json:"alumni_id"`
-    NamaPerusahaan     string `json:"nama_perusahaan"`
-    PosisiJabatan      string `json:"posisi_jabatan"`
+    AlumniID           int    `json:"alumni_id" validate:"required,min=1"`
+    NamaPerusahaan     string `json:"nama_perusahaan" validate:"required"`
+    PosisiJabatan      string `json:"posisi_jabatan" validate:"required"`
     BidangIndustri     string `json:"bidang_industri"`
     LokasiKerja        string `json:"lokasi_kerja"`
     GajiRange          string `json:"gaji_range"`
-    TanggalMulaiKerja  string `json:"tanggal_mulai_kerja"`
-    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty"`
-    StatusPekerjaan    string `json:"status_pekerjaan"`
+    TanggalMulaiKerja  string `json:"tanggal_mulai_kerja" validate:"required,date"`
+    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty" validate:"date,gtefield=TanggalMulaiKerja"`
+    StatusPekerjaan    string `json:"status_pekerjaan" validate:"oneof=aktif selesai resigned"`
     DeskripsiPekerjaan string `json:"deskripsi_pekerjaan"`
 }
 
 type UpdatePekerjaanRequest struct {
-    NamaPerusahaan      string `json:"nama_perusahaan"`
-    PosisiJabatan       string `json:"posisi_jabatan"`
-    BidangIndustri      string `json:"bidang_industri"`
-    LokasiKerja         string `json:"lokasi_kerja"`
+    NamaPerusahaan      string `json:"nama_perusahaan" validate:"required"`
+    PosisiJabatan       string `json:"posisi_jabatan" validate:"required"`
+    BidangIndustri      string `json:"bidang_industri" validate:"required"`
+    LokasiKerja         string `json:"lokasi_kerja" validate:"required"`
     GajiRange           string `json:"gaji_range"`
-    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja"`
-    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty"`
-    StatusPekerjaan     string `json:"status_pekerjaan"`
+    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,date"`
+    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty" validate:"date,gtefield=TanggalMulaiKerja"`
+    StatusPekerjaan     string `json:"status_pekerjaan" validate:"oneof=aktif selesai resigned"`
     DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
 }
 
@@ -59,6 +64,32 @@ type GetTrashPekerjaan struct {
     StatusPekerjaan string             `json:"status_pekerjaan" bson:"status_pekerjaan"`
     DeletedAt       *time.Time         `json:"deleted_at,omitempty" bson:"deleted_at,omitempty"`
     CreatedBy       int                `json:"created_by" bson:"created_by"`
+    DeletedBy       *int               `json:"deleted_by,omitempty" bson:"deleted_by,omitempty"`
+}
+
+// TrashBatchRequest -> pilih data trash berdasarkan daftar ID (hex ObjectID) atau filter.
+// Minimal satu kriteria wajib diisi; gunakan all=true untuk mengosongkan seluruh trash.
+type TrashBatchRequest struct {
+    IDs           []string `json:"ids"`
+    All           bool     `json:"all"`
+    AlumniID      *int     `json:"alumni_id"`
+    DeletedBy     *int     `json:"deleted_by"`
+    DeletedBefore string   `json:"deleted_before"` // YYYY-MM-DD atau RFC3339
+}
+
+// TrashFilter -> kriteria hasil parsing TrashBatchRequest
+type TrashFilter struct {
+    IDs           []primitive.ObjectID
+    AlumniID      *int
+    DeletedBy     *int
+    DeletedBefore *time.Time
+}
+
+// TrashBatchResponse -> hasil restore / purge banyak data trash
+type TrashBatchResponse struct {
+    Action   string   `json:"action"`
+    Affected int      `json:"affected"`
+    IDs      []string `json:"ids"`
 }
 
 type ResponsePekerjaan struct {
@@ -73,4 +104,13 @@ type ResponsePekerjaan struct {
 	TanggalSelesaiKerja *time.Time `json:"tanggal_selesai_kerja,omitempty"`
 	StatusPekerjaan     string     `json:"status_pekerjaan"`
 	DeskripsiPekerjaan  string     `json:"deskripsi_pekerjaan"`
+	Version             int        `json:"version"`
+}
+
+// BulkUpdatePekerjaanItem -> satu item pada bulk update, ID (hex ObjectID) wajib diisi.
+// Version (opsional) berfungsi seperti If-Match: item ditolak jika versi di database sudah berubah.
+type BulkUpdatePekerjaanItem struct {
+    ID      string `json:"id" validate:"required"`
+    Version int    `json:"version,omitempty"`
+    UpdatePekerjaanRequest
 }
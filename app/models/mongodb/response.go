@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"alumniproject/utils/filter"
+)
+
 // MetaInfo -> informasi pagination, sorting, dan search
 type MetaInfo struct {
 	Page   int    `json:"page" bson:"page"`
@@ -9,6 +15,35 @@ type MetaInfo struct {
 	SortBy string `json:"sortBy" bson:"sortBy"`
 	Order  string `json:"order" bson:"order"`
 	Search string `json:"search" bson:"search"`
+	Filter string `json:"filter,omitempty" bson:"filter,omitempty"`
+	// Mode cursor (?cursor=): total & pages tidak dihitung
+	NextCursor string `json:"next_cursor,omitempty" bson:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty" bson:"prev_cursor,omitempty"`
+}
+
+// ExportFilter -> parameter search, ?filter= & sort yang sama dengan endpoint list, dipakai untuk export
+type ExportFilter struct {
+	Search string
+	Filter filter.Filter
+	SortBy string
+	Order  string
+}
+
+// SearchHit -> satu hasil pencarian gabungan
+type SearchHit struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Snippet  string  `json:"snippet"` // kata yang cocok dibungkus <mark>
+	Score    float64 `json:"score"`
+	Text     string  `json:"-"` // teks sumber cuplikan
+}
+
+// SearchGroup -> hasil pencarian untuk satu tipe entity (alumni / pekerjaan)
+type SearchGroup struct {
+	Type  string      `json:"type"`
+	Total int64       `json:"total"`
+	Items []SearchHit `json:"items"`
 }
 
 // AlumniResponse -> response untuk endpoint /alumni
@@ -22,3 +57,44 @@ type PekerjaanResponse struct {
 	Data []*Pekerjaan `json:"data" bson:"data"` // gunakan pointer slice
 	Meta *MetaInfo    `json:"meta" bson:"meta"`
 }
+
+// UserResponse -> response untuk endpoint /users
+type UserResponse struct {
+	Data []User     `json:"data" bson:"data"`
+	Meta *MetaInfo     `json:"meta" bson:"meta"`
+}
+
+// BulkItemResult -> hasil per item pada operasi bulk
+type BulkItemResult struct {
+	Index   int    `json:"index" bson:"index"`
+	ID      string `json:"id,omitempty" bson:"id,omitempty"`
+	Success bool   `json:"success" bson:"success"`
+	Error   string `json:"error,omitempty" bson:"error,omitempty"`
+	Status  int    `json:"status,omitempty" bson:"status,omitempty"` // status HTTP error item, mis. 412 jika version tidak cocok
+	Code    string `json:"code,omitempty" bson:"code,omitempty"`     // kode error stabil, mis. version_conflict
+}
+
+// BulkResponse -> response untuk endpoint bulk create/update
+type BulkResponse struct {
+	Mode         string           `json:"mode" bson:"mode"`
+	Total        int              `json:"total" bson:"total"`
+	SuccessCount int              `json:"success_count" bson:"success_count"`
+	FailedCount  int              `json:"failed_count" bson:"failed_count"`
+	Results      []BulkItemResult `json:"results" bson:"results"`
+}
+
+// PurgeCollectionReport -> jumlah dokumen yang dihapus permanen per collection
+type PurgeCollectionReport struct {
+	Collection string `json:"collection" bson:"collection"`
+	Purged     int64  `json:"purged" bson:"purged"`                         // melewati masa retensi
+	Cascaded   int64  `json:"cascaded,omitempty" bson:"cascaded,omitempty"` // ikut terhapus karena alumninya di-purge
+	Skipped    int64  `json:"skipped,omitempty" bson:"skipped,omitempty"`   // melewati retensi tapi masih punya data aktif
+}
+
+// PurgeReport -> hasil satu kali purge trash
+type PurgeReport struct {
+	DryRun        bool                    `json:"dry_run" bson:"dry_run"`
+	RetentionDays int                     `json:"retention_days" bson:"retention_days"`
+	Cutoff        time.Time               `json:"cutoff" bson:"cutoff"`
+	Collections   []PurgeCollectionReport `json:"collections" bson:"collections"`
+}
@@ -16,8 +16,8 @@ type User struct {
 
 // Request body yang dikirim dari client saat login
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
 }
 
 // Response saat login berhasil
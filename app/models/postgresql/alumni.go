@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"alumniproject/utils/importer"
+)
 
 type Alumni struct {
 	ID          int       `json:"id"`
@@ -16,25 +20,55 @@ type Alumni struct {
 	UpdatedAt   time.Time `json:"updated_at"`
 	CreatedBy           int        `json:"created_by"`  // siapa yang input
     DeletedAt           *time.Time `json:"deleted_at,omitempty"` // soft dele
+	Version     int       `json:"version"` // optimistic locking, dikirim sebagai ETag
+}
+
+// DeletionBatch -> data yang ikut di-soft delete / restore bersama satu alumni
+type DeletionBatch struct {
+	ID        string `json:"batch_id,omitempty"`
+	Pekerjaan int64  `json:"pekerjaan"`
+}
+
+// AlumniVersion -> snapshot data alumni pada satu versi.
+// Berlaku sejak ValidFrom sampai ValidTo; ValidTo nil berarti versi saat ini.
+type AlumniVersion struct {
+	AlumniID   int        `json:"alumni_id"`
+	Version    int        `json:"version"`
+	NIM        string     `json:"nim"`
+	Nama       string     `json:"nama"`
+	Jurusan    string     `json:"jurusan"`
+	Angkatan   int        `json:"angkatan"`
+	TahunLulus int        `json:"tahun_lulus"`
+	Email      string     `json:"email"`
+	NoTelepon  string     `json:"no_telepon"`
+	Alamat     string     `json:"alamat"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidTo    *time.Time `json:"valid_to"`
+	ChangedBy  *int       `json:"changed_by,omitempty"` // user yang menggantikan versi ini
+}
+
+// RevertAlumniRequest -> versi tujuan saat mengembalikan data alumni
+type RevertAlumniRequest struct {
+	Version int `json:"version" validate:"required,min=1"`
 }
 
 type CreateAlumniRequest struct {
-	NIM         string `json:"nim"`
-	Nama        string `json:"nama"`
-	Jurusan     string `json:"jurusan"`
-	Angkatan    int    `json:"angkatan"`
-	TahunLulus  int    `json:"tahun_lulus"`
-	Email       string `json:"email"`
+	NIM         string `json:"nim" validate:"required,nim"`
+	Nama        string `json:"nama" validate:"required"`
+	Jurusan     string `json:"jurusan" validate:"required"`
+	Angkatan    int    `json:"angkatan" validate:"year"`
+	TahunLulus  int    `json:"tahun_lulus" validate:"year,gtefield=Angkatan"`
+	Email       string `json:"email" validate:"required,email"`
 	NoTelepon   string `json:"no_telepon"`
 	Alamat      string `json:"alamat"`
 }
 
 type UpdateAlumniRequest struct {
-	Nama        string `json:"nama"`
-	Jurusan     string `json:"jurusan"`
-	Angkatan    int    `json:"angkatan"`
-	TahunLulus  int    `json:"tahun_lulus"`
-	Email       string `json:"email"`
+	Nama        string `json:"nama" validate:"required"`
+	Jurusan     string `json:"jurusan" validate:"required"`
+	Angkatan    int    `json:"angkatan" validate:"year"`
+	TahunLulus  int    `json:"tahun_lulus" validate:"year,gtefield=Angkatan"`
+	Email       string `json:"email" validate:"required,email"`
 	NoTelepon   string `json:"no_telepon"`
 	Alamat      string `json:"alamat"`
 }
@@ -53,3 +87,14 @@ type AlumniWithPekerjaan struct {
     Pekerjaan   []Pekerjaan  `json:"pekerjaan"`
 }
 
+
+// ImportAlumniReport -> ringkasan hasil import alumni dari CSV / XLSX
+type ImportAlumniReport struct {
+	DryRun  bool                `json:"dry_run"`
+	Mode    string              `json:"mode"` // insert | upsert
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Failed  int                 `json:"failed"`
+	Errors  []importer.RowError `json:"errors"`
+}
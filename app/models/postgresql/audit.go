@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"alumniproject/utils/audit"
+)
+
+// AuditLog -> satu catatan perubahan data
+type AuditLog struct {
+	ID        int64                   `json:"id"`
+	ActorID   *int                    `json:"actor_id"`
+	Action    string                  `json:"action"`
+	Entity    string                  `json:"entity"`
+	EntityID  string                  `json:"entity_id"`
+	IP        string                  `json:"ip"`
+	Diff      map[string]audit.Change `json:"diff"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+// AuditFilter -> filter untuk GET /api/audit
+type AuditFilter struct {
+	ActorID  *int
+	Action   string
+	Entity   string
+	EntityID string
+	From     *time.Time
+	To       *time.Time
+}
+
+// AuditResponse -> response untuk endpoint /audit
+type AuditResponse struct {
+	Data []AuditLog `json:"data"`
+	Meta *MetaInfo  `json:"meta"`
+}
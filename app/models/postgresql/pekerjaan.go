@@ -18,30 +18,31 @@ type Pekerjaan struct {
     UpdatedAt           time.Time  `json:"updated_at"`
     CreatedBy           int        `json:"created_by"`  // siapa yang input
     DeletedAt           *time.Time `json:"deleted_at,omitempty"` // soft delete
+    Version             int        `json:"version"` // optimistic locking, dikirim sebagai ETag
 }
 
 type CreatePekerjaanRequest struct {
-    AlumniID            int    `json:"alumni_id"`
-    NamaPerusahaan      string `json:"nama_perusahaan"`
-    PosisiJabatan       string `json:"posisi_jabatan"`
+    AlumniID            int    `json:"alumni_id" validate:"required,min=1"`
+    NamaPerusahaan      string `json:"nama_perusahaan" validate:"required"`
+    PosisiJabatan       string `json:"posisi_jabatan" validate:"required"`
     BidangIndustri      string `json:"bidang_industri"`
     LokasiKerja         string `json:"lokasi_kerja"`
     GajiRange           string `json:"gaji_range"`
-    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja"` // YYYY-MM-DD
-    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty"`
-    StatusPekerjaan     string `json:"status_pekerjaan"`
+    TanggalMulaiKerja   string `json:"tanggal_mulai_kerja" validate:"required,date"` // YYYY-MM-DD
+    TanggalSelesaiKerja string `json:"tanggal_selesai_kerja,omitempty" validate:"date,gtefield=TanggalMulaiKerja"`
+    StatusPekerjaan     string `json:"status_pekerjaan" validate:"oneof=aktif selesai resigned"`
     DeskripsiPekerjaan  string `json:"deskripsi_pekerjaan"`
 }
 
 type UpdatePekerjaanRequest struct {
-	NamaPerusahaan        string `json:"nama_perusahaan"`
-	PosisiJabatan         string `json:"posisi_jabatan"`
-	BidangIndustri        string `json:"bidang_industri"`
-	LokasiKerja           string `json:"lokasi_kerja"`
+	NamaPerusahaan        string `json:"nama_perusahaan" validate:"required"`
+	PosisiJabatan         string `json:"posisi_jabatan" validate:"required"`
+	BidangIndustri        string `json:"bidang_industri" validate:"required"`
+	LokasiKerja           string `json:"lokasi_kerja" validate:"required"`
 	GajiRange             string `json:"gaji_range"`
-	TanggalMulaiKerja     string `json:"tanggal_mulai_kerja"`
-	TanggalSelesaiKerja   string `json:"tanggal_selesai_kerja,omitempty"`
-	StatusPekerjaan       string `json:"status_pekerjaan"`
+	TanggalMulaiKerja     string `json:"tanggal_mulai_kerja" validate:"required,date"`
+	TanggalSelesaiKerja   string `json:"tanggal_selesai_kerja,omitempty" validate:"date,gtefield=TanggalMulaiKerja"`
+	StatusPekerjaan       string `json:"status_pekerjaan" validate:"oneof=aktif selesai resigned"`
 	DeskripsiPekerjaan    string `json:"deskripsi_pekerjaan"`
 }
 
@@ -84,4 +85,37 @@ type GetTrashPekerjaan struct {
 	StatusPekerjaan string     `json:"status_pekerjaan"`
 	DeletedAt       *time.Time `json:"deleted_at"`
 	CreatedBy       int        `json:"created_by"`
-}
\ No newline at end of file
+	DeletedBy       *int       `json:"deleted_by"`
+}
+
+// TrashBatchRequest -> pilih data trash berdasarkan daftar ID atau filter.
+// Minimal satu kriteria wajib diisi; gunakan all=true untuk mengosongkan seluruh trash.
+type TrashBatchRequest struct {
+	IDs           []int64 `json:"ids"`
+	All           bool    `json:"all"`
+	AlumniID      *int    `json:"alumni_id"`
+	DeletedBy     *int    `json:"deleted_by"`
+	DeletedBefore string  `json:"deleted_before"` // YYYY-MM-DD atau RFC3339
+}
+
+// TrashFilter -> kriteria hasil parsing TrashBatchRequest
+type TrashFilter struct {
+	IDs           []int64
+	AlumniID      *int
+	DeletedBy     *int
+	DeletedBefore *time.Time
+}
+
+// TrashBatchResponse -> hasil restore / purge banyak data trash
+type TrashBatchResponse struct {
+	Action   string  `json:"action"`
+	Affected int     `json:"affected"`
+	IDs      []int64 `json:"ids"`
+}
+// BulkUpdatePekerjaanItem -> satu item pada bulk update, ID wajib diisi.
+// Version (opsional) berfungsi seperti If-Match: item ditolak jika versi di database sudah berubah.
+type BulkUpdatePekerjaanItem struct {
+	ID      int64 `json:"id" validate:"required"`
+	Version int   `json:"version,omitempty"`
+	UpdatePekerjaanRequest
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"time"
+
+	"alumniproject/utils/filter"
+)
+
 // MetaInfo -> informasi pagination, sorting, dan search
 type MetaInfo struct {
     Page   int    `json:"page"`
@@ -9,6 +15,35 @@ type MetaInfo struct {
     SortBy string `json:"sortBy"`
     Order  string `json:"order"`
     Search string `json:"search"`
+    Filter string `json:"filter,omitempty"`
+    // Mode cursor (?cursor=): total & pages tidak dihitung
+    NextCursor string `json:"next_cursor,omitempty"`
+    PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// ExportFilter -> parameter search, ?filter= & sort yang sama dengan endpoint list, dipakai untuk export
+type ExportFilter struct {
+	Search string
+	Filter filter.Filter
+	SortBy string
+	Order  string
+}
+
+// SearchHit -> satu hasil pencarian gabungan
+type SearchHit struct {
+	ID       int64   `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle"`
+	Snippet  string  `json:"snippet"` // kata yang cocok dibungkus <mark>
+	Score    float64 `json:"score"`
+	Text     string  `json:"-"` // teks sumber cuplikan
+}
+
+// SearchGroup -> hasil pencarian untuk satu tipe entity (alumni / pekerjaan)
+type SearchGroup struct {
+	Type  string      `json:"type"`
+	Total int         `json:"total"`
+	Items []SearchHit `json:"items"`
 }
 
 // AlumniResponse -> response untuk endpoint /alumni
@@ -21,4 +56,44 @@ type AlumniResponse struct {
 type PekerjaanResponse struct {
     Data []Pekerjaan       `json:"data"`
     Meta *MetaInfo         `json:"meta"`
-}
\ No newline at end of file
+}
+
+// UserResponse -> response untuk endpoint /users
+type UserResponse struct {
+    Data []User    `json:"data"`
+    Meta *MetaInfo `json:"meta"`
+}
+// BulkItemResult -> hasil per item pada operasi bulk
+type BulkItemResult struct {
+    Index   int    `json:"index"`
+    ID      int64  `json:"id,omitempty"`
+    Success bool   `json:"success"`
+    Error   string `json:"error,omitempty"`
+    Status  int    `json:"status,omitempty"` // status HTTP error item, mis. 412 jika version tidak cocok
+    Code    string `json:"code,omitempty"`   // kode error stabil, mis. version_conflict
+}
+
+// BulkResponse -> response untuk endpoint bulk create/update
+type BulkResponse struct {
+    Mode         string           `json:"mode"`
+    Total        int              `json:"total"`
+    SuccessCount int              `json:"success_count"`
+    FailedCount  int              `json:"failed_count"`
+    Results      []BulkItemResult `json:"results"`
+}
+
+// PurgeTableReport -> jumlah baris yang dihapus permanen per tabel
+type PurgeTableReport struct {
+    Table    string `json:"table"`
+    Purged   int64  `json:"purged"`             // melewati masa retensi
+    Cascaded int64  `json:"cascaded,omitempty"` // ikut terhapus karena alumninya di-purge
+    Skipped  int64  `json:"skipped,omitempty"`  // melewati retensi tapi masih punya data aktif
+}
+
+// PurgeReport -> hasil satu kali purge trash
+type PurgeReport struct {
+    DryRun        bool               `json:"dry_run"`
+    RetentionDays int                `json:"retention_days"`
+    Cutoff        time.Time          `json:"cutoff"`
+    Tables        []PurgeTableReport `json:"tables"`
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+    "context"
+    "fmt"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/app/models/mongodb"
+    "alumniproject/database/mongodb"
+)
+
+// GetHistory -> semua snapshot alumni, versi terbaru di depan (tanpa versi saat ini)
+func (r *AlumniMongoRepo) GetHistory(ctx context.Context, id string) ([]models.AlumniVersion, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, fmt.Errorf("invalid ObjectID format: %v", err)
+    }
+
+    opts := options.Find().SetSort(bson.D{{Key: "version", Value: -1}})
+    cursor, err := database.AlumniHistoryCollection.Find(ctx, bson.M{"alumni_id": objID}, opts)
+    if err != nil {
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    list := []models.AlumniVersion{}
+    if err := cursor.All(ctx, &list); err != nil {
+        return nil, err
+    }
+    return list, nil
+}
+
+// GetVersion -> snapshot alumni pada versi tertentu, nil jika tidak ada
+func (r *AlumniMongoRepo) GetVersion(ctx context.Context, id string, version int) (*models.AlumniVersion, error) {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, fmt.Errorf("invalid ObjectID format: %v", err)
+    }
+    return findAlumniVersion(ctx, bson.M{"alumni_id": objID, "version": version}, nil)
+}
+
+// GetAsOf -> snapshot alumni yang berlaku pada waktu asOf, nil jika tidak ada.
+// Versi saat ini tidak ada di alumni_history, jadi dicek terpisah oleh pemanggil.
+func (r *AlumniMongoRepo) GetAsOf(ctx context.Context, id string, asOf time.Time) (*models.AlumniVersion, error) {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, fmt.Errorf("invalid ObjectID format: %v", err)
+    }
+    filter := bson.M{
+        "alumni_id":  objID,
+        "valid_from": bson.M{"$lte": asOf},
+        "valid_to":   bson.M{"$gt": asOf},
+    }
+    return findAlumniVersion(ctx, filter, options.FindOne().SetSort(bson.D{{Key: "version", Value: -1}}))
+}
+
+func findAlumniVersion(ctx context.Context, filter bson.M, opts *options.FindOneOptions) (*models.AlumniVersion, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    if opts == nil {
+        opts = options.FindOne()
+    }
+
+    var v models.AlumniVersion
+    err := database.AlumniHistoryCollection.FindOne(ctx, filter, opts).Decode(&v)
+    if err == mongo.ErrNoDocuments {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &v, nil
+}
@@ -0,0 +1,383 @@
+package repository
+
+import (
+    "context"
+    "fmt"
+    "log/slog"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+    "github.com/google/uuid"
+
+    "alumniproject/app/models/mongodb"
+    "alumniproject/database/mongodb"
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/filter"
+)
+
+// ErrIDTidakValid -> id bukan ObjectID yang valid
+var ErrIDTidakValid = apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+
+type AlumniMongoRepo struct{}
+
+func NewAlumniRepo() *AlumniMongoRepo {
+    return &AlumniMongoRepo{}
+}
+
+// GetByID: ambil alumni yang belum dihapus, nil jika tidak ada
+func (r *AlumniMongoRepo) GetByID(ctx context.Context, id string) (*models.Alumni, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, ErrIDTidakValid
+    }
+
+    var alumni models.Alumni
+    err = database.AlumniCollection.FindOne(ctx, bson.M{"_id": objID, "deleted_at": nil}).Decode(&alumni)
+    if err != nil {
+        if err == mongo.ErrNoDocuments {
+            return nil, nil
+        }
+        return nil, err
+    }
+    return &alumni, nil
+}
+
+// GetAlumniPaginated: alumni aktif sesuai search & filter, non-admin hanya data miliknya
+func (r *AlumniMongoRepo) GetAlumniPaginated(ctx context.Context, search, sortBy, order string, limit, offset int, role string, userID int, f filter.Filter) ([]*models.Alumni, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    query := f.Apply(alumniExportFilter(models.ExportFilter{Search: search}, role, userID))
+    opts := options.Find().
+        SetSort(exportSort(models.ExportFilter{SortBy: sortBy, Order: order})).
+        SetLimit(int64(limit)).
+        SetSkip(int64(offset))
+
+    cursor, err := database.AlumniCollection.Find(ctx, query, opts)
+    if err != nil {
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    var results []*models.Alumni
+    if err = cursor.All(ctx, &results); err != nil {
+        return nil, err
+    }
+    return results, nil
+}
+
+// CountAlumni: jumlah alumni untuk pagination GetAlumniPaginated
+func (r *AlumniMongoRepo) CountAlumni(ctx context.Context, search, role string, userID int, f filter.Filter) (int64, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    query := f.Apply(alumniExportFilter(models.ExportFilter{Search: search}, role, userID))
+    return database.AlumniCollection.CountDocuments(ctx, query)
+}
+
+// UpdateAlumni: non-admin hanya bisa update data miliknya sendiri.
+// ifMatch > 0 -> update hanya jika versi di database masih sama (optimistic locking).
+// Dokumen lama disimpan ke alumni_history dalam transaksi yang sama.
+func (r *AlumniMongoRepo) UpdateAlumni(ctx context.Context, id string, a *models.Alumni, role string, userID int, ifMatch int) error {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return ErrIDTidakValid
+    }
+
+    a.UpdatedAt = time.Now()
+
+    filter := bson.M{"_id": objID, "deleted_at": nil}
+    if role != "admin" {
+        filter["created_by"] = userID
+    }
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
+
+    update := bson.M{"$inc": bson.M{"version": 1}, "$set": bson.M{
+        "nama":        a.Nama,
+        "jurusan":     a.Jurusan,
+        "angkatan":    a.Angkatan,
+        "tahun_lulus": a.TahunLulus,
+        "email":       a.Email,
+        "no_telepon":  a.NoTelepon,
+        "alamat":      a.Alamat,
+        "updated_at":  a.UpdatedAt,
+    }}
+
+    session, err := database.MongoClient.StartSession()
+    if err != nil {
+        return err
+    }
+    defer session.EndSession(ctx)
+
+    _, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+        var old models.Alumni
+        err := database.AlumniCollection.FindOneAndUpdate(sc, filter, update).Decode(&old) // dokumen sebelum update
+        if err == mongo.ErrNoDocuments {
+            if ifMatch > 0 {
+                return nil, ErrVersionConflict
+            }
+            return nil, ErrAlumniTidakDitemukan
+        }
+        if err != nil {
+            return nil, fmt.Errorf("gagal update data: %v", err)
+        }
+
+        _, err = database.AlumniHistoryCollection.InsertOne(sc, models.AlumniVersion{
+            AlumniID:   old.ID,
+            Version:    old.Version,
+            NIM:        old.NIM,
+            Nama:       old.Nama,
+            Jurusan:    old.Jurusan,
+            Angkatan:   old.Angkatan,
+            TahunLulus: old.TahunLulus,
+            Email:      old.Email,
+            NoTelepon:  old.NoTelepon,
+            Alamat:     old.Alamat,
+            ValidFrom:  old.UpdatedAt,
+            ValidTo:    &a.UpdatedAt,
+            ChangedBy:  &userID,
+        })
+        if err != nil {
+            return nil, fmt.Errorf("gagal menyimpan riwayat alumni: %v", err)
+        }
+
+        a.Version = old.Version + 1
+        return nil, nil
+    })
+    if err != nil {
+        return err
+    }
+
+    slog.DebugContext(ctx, "update alumni berhasil", "id", id)
+    return nil
+}
+
+// ErrAlumniTidakDitemukan -> alumni tidak ada, sudah dihapus, atau bukan milik user ini
+var ErrAlumniTidakDitemukan = apperror.NotFound("alumni.not_found_or_not_owned")
+
+// cascadeFilter -> data aktif milik alumni. Pekerjaan merujuk alumni lewat alumni_id (angka),
+// file lewat alumni_id berisi _id alumni.
+func cascadeFilter(alumniID interface{}) bson.M {
+    return bson.M{"alumni_id": alumniID, "deleted_at": nil}
+}
+
+// pekerjaanCascadeFilter -> pekerjaan aktif yang ikut terhapus bersama alumni. Hanya pekerjaan yang
+// dibuat setelah alumni punya alumni_id (alumni_linked); alumni_id pada pekerjaan lama tidak pernah
+// merujuk alumni mana pun, jadi angka yang kebetulan sama belum tentu milik alumni ini.
+func pekerjaanCascadeFilter(alumniID int) bson.M {
+    f := cascadeFilter(alumniID)
+    f["alumni_linked"] = true
+    return f
+}
+
+// SoftDeleteAlumni: soft delete alumni beserta pekerjaan dan file (foto & sertifikat) miliknya.
+// Semua ditandai dengan deletion_batch yang sama dalam satu transaksi,
+// sehingga RestoreAlumni bisa mengembalikan persis batch tersebut.
+func (r *AlumniMongoRepo) SoftDeleteAlumni(ctx context.Context, id string, userID int, role string, ifMatch int) (*models.DeletionBatch, error) {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, ErrIDTidakValid
+    }
+
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    session, err := database.MongoClient.StartSession()
+    if err != nil {
+        return nil, err
+    }
+    defer session.EndSession(ctx)
+
+    var batch *models.DeletionBatch
+    _, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+        now := time.Now()
+        batch = &models.DeletionBatch{ID: uuid.NewString()}
+
+        filter := bson.M{"_id": objID, "deleted_at": nil}
+        if role != "admin" {
+            filter["created_by"] = userID
+        }
+        if ifMatch > 0 {
+            filter["version"] = ifMatch
+        }
+
+        var deleted models.Alumni
+        err := database.AlumniCollection.FindOneAndUpdate(sc, filter, bson.M{
+            "$set": bson.M{"deleted_at": now, "deletion_batch": batch.ID},
+            "$inc": bson.M{"version": 1},
+        }).Decode(&deleted)
+        if err == mongo.ErrNoDocuments {
+            if ifMatch > 0 {
+                return nil, ErrVersionConflict
+            }
+            return nil, ErrAlumniTidakDitemukan
+        }
+        if err != nil {
+            return nil, err
+        }
+
+        // Data yang sudah di trash sebelumnya tidak disentuh, jadi tidak ikut ter-restore nanti.
+        // Alumni tanpa alumni_id (backfill belum selesai) tidak punya pekerjaan yang bisa dipastikan miliknya.
+        if deleted.AlumniID > 0 {
+            res, err := database.PekerjaanCollection.UpdateMany(sc, pekerjaanCascadeFilter(deleted.AlumniID), bson.M{
+                "$set": bson.M{"deleted_at": now, "deleted_by": userID, "deletion_batch": batch.ID},
+                "$inc": bson.M{"version": 1},
+            })
+            if err != nil {
+                return nil, err
+            }
+            batch.Pekerjaan = res.ModifiedCount
+        } else {
+            slog.WarnContext(sc, "alumni tanpa alumni_id, pekerjaan tidak ikut dihapus", "id", id)
+        }
+
+        for _, name := range []string{"files", "fotos"} {
+            res, err := database.DB.Collection(name).UpdateMany(sc, cascadeFilter(objID), bson.M{
+                "$set": bson.M{"deleted_at": now, "deletion_batch": batch.ID},
+                "$inc": bson.M{"version": 1},
+            })
+            if err != nil {
+                return nil, err
+            }
+            batch.Files += res.ModifiedCount
+        }
+        return nil, nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    slog.InfoContext(ctx, "soft delete alumni", "id", id, "batch_id", batch.ID, "pekerjaan", batch.Pekerjaan, "files", batch.Files)
+    return batch, nil
+}
+
+// ErrAlumniTidakDiTrash -> alumni tidak ada di trash atau bukan milik user ini
+var ErrAlumniTidakDiTrash = apperror.NotFound("alumni.not_in_trash")
+
+// RestoreAlumni mengembalikan alumni beserta pekerjaan & file dari batch penghapusan yang sama.
+// Non-admin hanya bisa restore alumni miliknya sendiri, sama seperti SoftDeleteAlumni.
+func (r *AlumniMongoRepo) RestoreAlumni(ctx context.Context, id string, userID int, role string) (*models.DeletionBatch, error) {
+    objID, err := primitive.ObjectIDFromHex(id)
+    if err != nil {
+        return nil, ErrIDTidakValid
+    }
+
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    session, err := database.MongoClient.StartSession()
+    if err != nil {
+        return nil, err
+    }
+    defer session.EndSession(ctx)
+
+    var batch *models.DeletionBatch
+    _, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+        filter := bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}
+        if role != "admin" {
+            filter["created_by"] = userID
+        }
+
+        var deleted models.Alumni
+        err := database.AlumniCollection.FindOneAndUpdate(sc, filter,
+            bson.M{"$unset": bson.M{"deleted_at": "", "deletion_batch": ""}, "$inc": bson.M{"version": 1}},
+        ).Decode(&deleted) // dokumen sebelum update, berisi deletion_batch lama
+        if err == mongo.ErrNoDocuments {
+            return nil, ErrAlumniTidakDiTrash
+        }
+        if err != nil {
+            return nil, err
+        }
+
+        batch = &models.DeletionBatch{ID: deleted.DeletionBatch}
+        if batch.ID == "" {
+            return nil, nil
+        }
+
+        inBatch := bson.M{"deletion_batch": batch.ID}
+        res, err := database.PekerjaanCollection.UpdateMany(sc, inBatch, bson.M{
+            "$unset": bson.M{"deleted_at": "", "deleted_by": "", "deletion_batch": ""},
+            "$inc":   bson.M{"version": 1},
+        })
+        if err != nil {
+            return nil, err
+        }
+        batch.Pekerjaan = res.ModifiedCount
+
+        for _, name := range []string{"files", "fotos"} {
+            res, err = database.DB.Collection(name).UpdateMany(sc, inBatch, bson.M{
+                "$unset": bson.M{"deleted_at": "", "deletion_batch": ""},
+                "$inc":   bson.M{"version": 1},
+            })
+            if err != nil {
+                return nil, err
+            }
+            batch.Files += res.ModifiedCount
+        }
+        return nil, nil
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    slog.InfoContext(ctx, "restore alumni", "id", id, "batch_id", batch.ID, "pekerjaan", batch.Pekerjaan, "files", batch.Files)
+    return batch, nil
+}
+
+// CreateAlumni: simpan alumni baru dengan versi awal 1 dan alumni_id berikutnya dari counter
+func (r *AlumniMongoRepo) CreateAlumni(ctx context.Context, a *models.Alumni) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    seq, err := database.NextSequence(ctx, database.AlumniSequence)
+    if err != nil {
+        return err
+    }
+
+    now := time.Now()
+    a.ID = primitive.NewObjectID()
+    a.AlumniID = seq
+    a.CreatedAt = now
+    a.UpdatedAt = now
+    a.Version = 1
+
+    _, err = database.AlumniCollection.InsertOne(ctx, a)
+    return err
+}
+
+// FindByNIMs -> alumni (termasuk yang sudah dihapus) dengan NIM di daftar, dikelompokkan per NIM
+func (r *AlumniMongoRepo) FindByNIMs(ctx context.Context, nims []string) (map[string]models.Alumni, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    result := make(map[string]models.Alumni)
+    if len(nims) == 0 {
+        return result, nil
+    }
+
+    cursor, err := database.AlumniCollection.Find(ctx, bson.M{"nim": bson.M{"$in": nims}})
+    if err != nil {
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    var list []models.Alumni
+    if err := cursor.All(ctx, &list); err != nil {
+        return nil, err
+    }
+    for _, a := range list {
+        result[a.NIM] = a
+    }
+    return result, nil
+}
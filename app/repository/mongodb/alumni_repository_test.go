@@ -0,0 +1,171 @@
+package repository
+
+import (
+    "context"
+    "fmt"
+    "os"
+    "testing"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    models "alumniproject/app/models/mongodb"
+    database "alumniproject/database/mongodb"
+)
+
+// setupTestDB mengarahkan collection global ke database sementara di MONGO_TEST_URI.
+// Transaksi butuh replica set, mis. mongodb://localhost:27017/?replicaSet=rs0.
+func setupTestDB(t *testing.T) context.Context {
+    t.Helper()
+    uri := os.Getenv("MONGO_TEST_URI")
+    if uri == "" {
+        t.Skip("MONGO_TEST_URI tidak di-set, test integrasi MongoDB dilewati")
+    }
+
+    ctx := context.Background()
+    client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+    if err != nil {
+        t.Fatalf("connect: %v", err)
+    }
+
+    db := client.Database(fmt.Sprintf("alumni_test_%d", time.Now().UnixNano()))
+    database.MongoClient = client
+    database.DB = db
+    database.AlumniCollection = db.Collection("alumni")
+    database.PekerjaanCollection = db.Collection("pekerjaan")
+    database.CountersCollection = db.Collection("counters")
+
+    // Collection dibuat lebih dulu karena tidak bisa dibuat implisit di dalam transaksi (MongoDB < 4.4)
+    for _, name := range []string{"alumni", "pekerjaan", "files", "fotos", "counters"} {
+        _ = db.CreateCollection(ctx, name)
+    }
+
+    t.Cleanup(func() {
+        _ = db.Drop(ctx)
+        _ = client.Disconnect(ctx)
+    })
+    return ctx
+}
+
+func TestSoftDeleteAndRestoreAlumniCascadesPekerjaan(t *testing.T) {
+    ctx := setupTestDB(t)
+    repo := NewAlumniRepo()
+
+    a := &models.Alumni{NIM: "123456789", Nama: "Budi", Jurusan: "TI", CreatedBy: 1}
+    if err := repo.CreateAlumni(ctx, a); err != nil {
+        t.Fatalf("CreateAlumni: %v", err)
+    }
+    if a.AlumniID == 0 {
+        t.Fatal("alumni_id tidak diisi saat create")
+    }
+
+    earlier := time.Now().Add(-time.Hour)
+    _, err := database.PekerjaanCollection.InsertMany(ctx, []interface{}{
+        models.Pekerjaan{AlumniID: a.AlumniID, AlumniLinked: true, NamaPerusahaan: "A", CreatedBy: 1, Version: 1},
+        models.Pekerjaan{AlumniID: a.AlumniID, AlumniLinked: true, NamaPerusahaan: "B", CreatedBy: 1, Version: 1},
+        // Sudah di trash sebelum alumni dihapus -> tidak ikut batch, tidak ikut restore
+        models.Pekerjaan{AlumniID: a.AlumniID, AlumniLinked: true, NamaPerusahaan: "C", CreatedBy: 1, Version: 1, DeletedAt: &earlier},
+        // Milik alumni lain
+        models.Pekerjaan{AlumniID: a.AlumniID + 1, AlumniLinked: true, NamaPerusahaan: "D", CreatedBy: 1, Version: 1},
+        // Pekerjaan lama: alumni_id sama hanya kebetulan, pemiliknya tidak diketahui
+        models.Pekerjaan{AlumniID: a.AlumniID, NamaPerusahaan: "E", CreatedBy: 1, Version: 1},
+    })
+    if err != nil {
+        t.Fatalf("insert pekerjaan: %v", err)
+    }
+
+    batch, err := repo.SoftDeleteAlumni(ctx, a.ID.Hex(), 1, "user", 0)
+    if err != nil {
+        t.Fatalf("SoftDeleteAlumni: %v", err)
+    }
+    if batch.Pekerjaan != 2 {
+        t.Fatalf("pekerjaan ikut terhapus = %d, want 2", batch.Pekerjaan)
+    }
+
+    byName := func() map[string]models.Pekerjaan {
+        cursor, err := database.PekerjaanCollection.Find(ctx, bson.M{})
+        if err != nil {
+            t.Fatalf("find pekerjaan: %v", err)
+        }
+        var list []models.Pekerjaan
+        if err := cursor.All(ctx, &list); err != nil {
+            t.Fatalf("decode pekerjaan: %v", err)
+        }
+        out := make(map[string]models.Pekerjaan, len(list))
+        for _, p := range list {
+            out[p.NamaPerusahaan] = p
+        }
+        return out
+    }
+
+    got := byName()
+    for _, name := range []string{"A", "B"} {
+        if got[name].DeletedAt == nil || got[name].DeletionBatch != batch.ID {
+            t.Errorf("pekerjaan %s: deleted_at=%v batch=%q, want di trash dengan batch %q", name, got[name].DeletedAt, got[name].DeletionBatch, batch.ID)
+        }
+    }
+    if got["C"].DeletionBatch != "" {
+        t.Errorf("pekerjaan C ikut batch %q", got["C"].DeletionBatch)
+    }
+    if got["D"].DeletedAt != nil {
+        t.Error("pekerjaan alumni lain ikut terhapus")
+    }
+    if got["E"].DeletedAt != nil {
+        t.Error("pekerjaan lama tanpa alumni_linked ikut terhapus")
+    }
+
+    // User lain tidak boleh restore alumni ini
+    if _, err := repo.RestoreAlumni(ctx, a.ID.Hex(), 2, "user"); err != ErrAlumniTidakDiTrash {
+        t.Fatalf("RestoreAlumni oleh user lain: err = %v, want ErrAlumniTidakDiTrash", err)
+    }
+
+    restored, err := repo.RestoreAlumni(ctx, a.ID.Hex(), 1, "user")
+    if err != nil {
+        t.Fatalf("RestoreAlumni: %v", err)
+    }
+    if restored.ID != batch.ID || restored.Pekerjaan != 2 {
+        t.Fatalf("restore batch = %+v, want batch %q dengan 2 pekerjaan", restored, batch.ID)
+    }
+
+    got = byName()
+    for _, name := range []string{"A", "B"} {
+        if got[name].DeletedAt != nil || got[name].DeletionBatch != "" {
+            t.Errorf("pekerjaan %s belum ter-restore: %+v", name, got[name])
+        }
+    }
+    if got["C"].DeletedAt == nil {
+        t.Error("pekerjaan C yang dihapus terpisah ikut ter-restore")
+    }
+}
+
+func TestSoftDeleteAlumniWithoutAlumniIDSkipsPekerjaan(t *testing.T) {
+    ctx := setupTestDB(t)
+    repo := NewAlumniRepo()
+
+    // Alumni lama yang belum sempat mendapat alumni_id dari backfill
+    a := models.Alumni{ID: primitive.NewObjectID(), NIM: "987654321", Nama: "Lama", CreatedBy: 1, Version: 1}
+    if _, err := database.AlumniCollection.InsertOne(ctx, a); err != nil {
+        t.Fatalf("insert alumni: %v", err)
+    }
+    _, err := database.PekerjaanCollection.InsertMany(ctx, []interface{}{
+        models.Pekerjaan{NamaPerusahaan: "Yatim", CreatedBy: 1, Version: 1},
+        models.Pekerjaan{NamaPerusahaan: "Yatim baru", AlumniLinked: true, CreatedBy: 1, Version: 1},
+    })
+    if err != nil {
+        t.Fatalf("insert pekerjaan: %v", err)
+    }
+
+    batch, err := repo.SoftDeleteAlumni(ctx, a.ID.Hex(), 1, "user", 0)
+    if err != nil {
+        t.Fatalf("SoftDeleteAlumni: %v", err)
+    }
+    if batch.Pekerjaan != 0 {
+        t.Errorf("pekerjaan ikut terhapus = %d, want 0", batch.Pekerjaan)
+    }
+    if n, _ := database.PekerjaanCollection.CountDocuments(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}}); n != 0 {
+        t.Errorf("%d pekerjaan dengan alumni_id 0 masuk trash", n)
+    }
+}
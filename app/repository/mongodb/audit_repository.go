@@ -0,0 +1,84 @@
+package repository
+
+import (
+    "context"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    models "alumniproject/app/models/mongodb"
+    database "alumniproject/database/mongodb"
+)
+
+type AuditMongoRepo struct{}
+
+func NewAuditRepo() *AuditMongoRepo {
+    return &AuditMongoRepo{}
+}
+
+// Insert menyimpan satu catatan audit log
+func (r *AuditMongoRepo) Insert(ctx context.Context, e *models.AuditLog) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    _, err := database.AuditCollection.InsertOne(ctx, e)
+    return err
+}
+
+func auditFilter(f models.AuditFilter) bson.M {
+    filter := bson.M{}
+    if f.ActorID != nil {
+        filter["actor_id"] = *f.ActorID
+    }
+    if f.Action != "" {
+        filter["action"] = f.Action
+    }
+    if f.Entity != "" {
+        filter["entity"] = f.Entity
+    }
+    if f.EntityID != "" {
+        filter["entity_id"] = f.EntityID
+    }
+    if f.From != nil || f.To != nil {
+        createdAt := bson.M{}
+        if f.From != nil {
+            createdAt["$gte"] = *f.From
+        }
+        if f.To != nil {
+            createdAt["$lt"] = *f.To
+        }
+        filter["created_at"] = createdAt
+    }
+    return filter
+}
+
+// Find -> audit log terbaru lebih dulu, dengan filter dan pagination
+func (r *AuditMongoRepo) Find(ctx context.Context, f models.AuditFilter, limit, offset int) ([]*models.AuditLog, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    opts := options.Find().
+        SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).
+        SetLimit(int64(limit)).
+        SetSkip(int64(offset))
+
+    cursor, err := database.AuditCollection.Find(ctx, auditFilter(f), opts)
+    if err != nil {
+        return nil, err
+    }
+    defer cursor.Close(ctx)
+
+    logs := []*models.AuditLog{}
+    if err = cursor.All(ctx, &logs); err != nil {
+        return nil, err
+    }
+    return logs, nil
+}
+
+// Count -> total audit log yang cocok dengan filter
+func (r *AuditMongoRepo) Count(ctx context.Context, f models.AuditFilter) (int64, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    return database.AuditCollection.CountDocuments(ctx, auditFilter(f))
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+    "context"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/app/models/mongodb"
+    "alumniproject/database/mongodb"
+)
+
+// exportTimeout -> batas waktu satu query export (lebih panjang dari query biasa)
+const exportTimeout = 10 * time.Minute
+
+// Cursor membaca hasil query satu dokumen per panggilan Next, tanpa memuat semua data ke memori
+type Cursor[T any] struct {
+    cur    *mongo.Cursor
+    ctx    context.Context
+    cancel context.CancelFunc
+}
+
+// Next mengisi dst dengan dokumen berikutnya, false jika data sudah habis
+func (c *Cursor[T]) Next(dst *T) (bool, error) {
+    if !c.cur.Next(c.ctx) {
+        return false, c.cur.Err()
+    }
+    var zero T
+    *dst = zero // field opsional dari dokumen sebelumnya tidak boleh terbawa
+    return true, c.cur.Decode(dst)
+}
+
+// Close menutup cursor dan membatalkan context query
+func (c *Cursor[T]) Close() error {
+    defer c.cancel()
+    return c.cur.Close(c.ctx)
+}
+
+func openCursor[T any](ctx context.Context, coll *mongo.Collection, filter bson.M, opts *options.FindOptions) (*Cursor[T], error) {
+    ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+    cur, err := coll.Find(ctx, filter, opts)
+    if err != nil {
+        cancel()
+        return nil, err
+    }
+    return &Cursor[T]{cur: cur, ctx: ctx, cancel: cancel}, nil
+}
+
+// exportSort -> urutan sesuai filter, ditambah _id agar urutan stabil
+func exportSort(f models.ExportFilter) bson.D {
+    sort := bson.D{{Key: f.SortBy, Value: getMongoOrder(f.Order)}}
+    if f.SortBy != "_id" {
+        sort = append(sort, bson.E{Key: "_id", Value: 1})
+    }
+    return sort
+}
+
+// alumniExportFilter -> alumni aktif sesuai search & ?filter=, non-admin hanya data miliknya
+func alumniExportFilter(f models.ExportFilter, role string, userID int) bson.M {
+    filter := bson.M{
+        "deleted_at": nil,
+        "$or": []bson.M{
+            {"nama": bson.M{"$regex": f.Search, "$options": "i"}},
+            {"nim": bson.M{"$regex": f.Search, "$options": "i"}},
+            {"jurusan": bson.M{"$regex": f.Search, "$options": "i"}},
+        },
+    }
+    if role != "admin" {
+        filter["created_by"] = userID
+    }
+    return f.Filter.Apply(filter)
+}
+
+// pekerjaanExportFilter -> pekerjaan aktif sesuai search & ?filter=, non-admin hanya data miliknya
+func pekerjaanExportFilter(f models.ExportFilter, role string, userID int) bson.M {
+    filter := bson.M{
+        "deleted_at": nil,
+        "$or": []bson.M{
+            {"nama_perusahaan": bson.M{"$regex": f.Search, "$options": "i"}},
+            {"posisi_jabatan": bson.M{"$regex": f.Search, "$options": "i"}},
+        },
+    }
+    if role != "admin" {
+        filter["created_by"] = userID
+    }
+    return f.Filter.Apply(filter)
+}
+
+// StreamAlumni -> alumni aktif sesuai search & sort, non-admin hanya data miliknya
+func (r *AlumniMongoRepo) StreamAlumni(ctx context.Context, f models.ExportFilter, role string, userID int) (*Cursor[models.Alumni], error) {
+    opts := options.Find().SetSort(exportSort(f)).SetBatchSize(500)
+    return openCursor[models.Alumni](ctx, database.AlumniCollection, alumniExportFilter(f, role, userID), opts)
+}
+
+// CountExport -> jumlah dokumen yang akan ditulis StreamAlumni (untuk progres job)
+func (r *AlumniMongoRepo) CountExport(ctx context.Context, f models.ExportFilter, role string, userID int) (int64, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+    return database.AlumniCollection.CountDocuments(ctx, alumniExportFilter(f, role, userID))
+}
+
+// StreamPekerjaan -> pekerjaan aktif sesuai search & sort, non-admin hanya data miliknya
+func (r *PekerjaanMongoRepo) StreamPekerjaan(ctx context.Context, f models.ExportFilter, role string, userID int) (*Cursor[models.Pekerjaan], error) {
+    opts := options.Find().SetSort(exportSort(f)).SetBatchSize(500)
+    return openCursor[models.Pekerjaan](ctx, database.PekerjaanCollection, pekerjaanExportFilter(f, role, userID), opts)
+}
+
+// CountExport -> jumlah dokumen yang akan ditulis StreamPekerjaan (untuk progres job)
+func (r *PekerjaanMongoRepo) CountExport(ctx context.Context, f models.ExportFilter, role string, userID int) (int64, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+    return database.PekerjaanCollection.CountDocuments(ctx, pekerjaanExportFilter(f, role, userID))
+}
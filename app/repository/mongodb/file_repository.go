@@ -3,6 +3,8 @@ package repository
 import (
 	models "alumniproject/app/models/mongodb"
 	// "alumniproject/models/mongodb"
+	"alumniproject/utils/filter"
+	database "alumniproject/database/mongodb"
 	"context"
 	"time"
 
@@ -12,11 +14,11 @@ import (
 )
 
 type FileRepository interface {
-    Create(file *models.File) error
-    FindAll() ([]models.File, error)
-    FindByID(id int64) (*models.File, error)
-    Delete(id int64) error
-    GetNextID() (int64, error)
+    Create(ctx context.Context, file *models.File) error
+    FindAll(ctx context.Context, f filter.Filter) ([]models.File, error)
+    FindByID(ctx context.Context, id int64) (*models.File, error)
+    Delete(ctx context.Context, id int64, ifMatch int) error
+    GetNextID(ctx context.Context) (int64, error)
 }
 
 type fileRepository struct {
@@ -30,8 +32,8 @@ func NewFileRepository(db *mongo.Database) FileRepository {
 }
 
 // Mendapatkan ID terakhir (auto increment)
-func (r *fileRepository) GetNextID() (int64, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) GetNextID(ctx context.Context) (int64, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
      opts := options.FindOne().SetSort(bson.D{{Key: "id", Value: -1}})
@@ -49,28 +51,29 @@ func (r *fileRepository) GetNextID() (int64, error) {
     return lastFile.ID + 1, nil
 }
 
-func (r *fileRepository) Create(file *models.File) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) Create(ctx context.Context, file *models.File) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
-    nextID, err := r.GetNextID()
+    nextID, err := r.GetNextID(ctx)
     if err != nil {
         return err
     }
 
     file.ID = nextID
     file.UploadedAt = time.Now()
+    file.Version = 1
 
     _, err = r.collection.InsertOne(ctx, file)
     return err
 }
 
-func (r *fileRepository) FindAll() ([]models.File, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) FindAll(ctx context.Context, f filter.Filter) ([]models.File, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     var files []models.File
-    cursor, err := r.collection.Find(ctx, bson.M{})
+    cursor, err := r.collection.Find(ctx, f.Apply(bson.M{"deleted_at": nil}))
     if err != nil {
         return nil, err
     }
@@ -83,12 +86,12 @@ func (r *fileRepository) FindAll() ([]models.File, error) {
     return files, nil
 }
 
-func (r *fileRepository) FindByID(id int64) (*models.File, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fileRepository) FindByID(ctx context.Context, id int64) (*models.File, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     var file models.File
-    err := r.collection.FindOne(ctx, bson.M{"id": id}).Decode(&file)
+    err := r.collection.FindOne(ctx, bson.M{"id": id, "deleted_at": nil}).Decode(&file)
     if err != nil {
         return nil, err
     }
@@ -96,10 +99,22 @@ func (r *fileRepository) FindByID(id int64) (*models.File, error) {
     return &file, nil
 }
 
-func (r *fileRepository) Delete(id int64) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Delete: ifMatch > 0 -> hanya hapus jika versi metadata masih sama
+func (r *fileRepository) Delete(ctx context.Context, id int64, ifMatch int) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
-    _, err := r.collection.DeleteOne(ctx, bson.M{"id": id})
-    return err
+    filter := bson.M{"id": id}
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
+
+    result, err := r.collection.DeleteOne(ctx, filter)
+    if err != nil {
+        return err
+    }
+    if result.DeletedCount == 0 && ifMatch > 0 {
+        return ErrVersionConflict
+    }
+    return nil
 }
@@ -3,6 +3,8 @@ package repository
 import (
     "context"
     "alumniproject/app/models/mongodb"
+    "alumniproject/utils/filter"
+    database "alumniproject/database/mongodb"
     "time"
 
     "go.mongodb.org/mongo-driver/bson"
@@ -12,11 +14,11 @@ import (
 )
 
 type FotoRepository interface {
-    Create(file *models.File) error
-    FindAll() ([]models.File, error)
-    FindByID(id int64) (*models.File, error)
-    Delete(id int64) error
-    GetNextID() (int64, error)
+    Create(ctx context.Context, file *models.File) error
+    FindAll(ctx context.Context, f filter.Filter) ([]models.File, error)
+    FindByID(ctx context.Context, id int64) (*models.File, error)
+    Delete(ctx context.Context, id int64, ifMatch int) error
+    GetNextID(ctx context.Context) (int64, error)
 }
 
 type fotoRepository struct {
@@ -29,8 +31,8 @@ func NewFotoRepository(db *mongo.Database) FotoRepository {
     }
 }
 
-func (r *fotoRepository) GetNextID() (int64, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fotoRepository) GetNextID(ctx context.Context) (int64, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
      opts := options.FindOne().SetSort(bson.D{{Key: "id", Value: -1}})
@@ -48,28 +50,29 @@ func (r *fotoRepository) GetNextID() (int64, error) {
     return lastFoto.ID + 1, nil
 }
 
-func (r *fotoRepository) Create(file *models.File) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fotoRepository) Create(ctx context.Context, file *models.File) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
-    nextID, err := r.GetNextID()
+    nextID, err := r.GetNextID(ctx)
     if err != nil {
         return err
     }
 
     file.ID = nextID
     file.UploadedAt = time.Now()
+    file.Version = 1
 
     _, err = r.collection.InsertOne(ctx, file)
     return err
 }
 
-func (r *fotoRepository) FindAll() ([]models.File, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fotoRepository) FindAll(ctx context.Context, f filter.Filter) ([]models.File, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     var fotos []models.File
-    cursor, err := r.collection.Find(ctx, bson.M{})
+    cursor, err := r.collection.Find(ctx, f.Apply(bson.M{"deleted_at": nil}))
     if err != nil {
         return nil, err
     }
@@ -82,12 +85,12 @@ func (r *fotoRepository) FindAll() ([]models.File, error) {
     return fotos, nil
 }
 
-func (r *fotoRepository) FindByID(id int64) (*models.File, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+func (r *fotoRepository) FindByID(ctx context.Context, id int64) (*models.File, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     var foto models.File
-    err := r.collection.FindOne(ctx, bson.M{"id": id}).Decode(&foto)
+    err := r.collection.FindOne(ctx, bson.M{"id": id, "deleted_at": nil}).Decode(&foto)
     if err != nil {
         return nil, err
     }
@@ -95,10 +98,22 @@ func (r *fotoRepository) FindByID(id int64) (*models.File, error) {
     return &foto, nil
 }
 
-func (r *fotoRepository) Delete(id int64) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+// Delete: ifMatch > 0 -> hanya hapus jika versi metadata masih sama
+func (r *fotoRepository) Delete(ctx context.Context, id int64, ifMatch int) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
-    _, err := r.collection.DeleteOne(ctx, bson.M{"id": id})
-    return err
+    filter := bson.M{"id": id}
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
+
+    result, err := r.collection.DeleteOne(ctx, filter)
+    if err != nil {
+        return err
+    }
+    if result.DeletedCount == 0 && ifMatch > 0 {
+        return ErrVersionConflict
+    }
+    return nil
 }
@@ -0,0 +1,103 @@
+package repository
+
+import (
+    "context"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/database/mongodb"
+    "alumniproject/utils/jobs"
+)
+
+// JobMongoRepo -> penyimpanan antrian job di koleksi jobs (implementasi jobs.Store)
+type JobMongoRepo struct{}
+
+func NewJobRepo() *JobMongoRepo {
+    return &JobMongoRepo{}
+}
+
+func (r *JobMongoRepo) Create(ctx context.Context, j *jobs.Job) error {
+    _, err := database.JobsCollection.InsertOne(ctx, j)
+    return err
+}
+
+func (r *JobMongoRepo) Get(ctx context.Context, id string) (*jobs.Job, error) {
+    var j jobs.Job
+    err := database.JobsCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&j)
+    if err == mongo.ErrNoDocuments {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &j, nil
+}
+
+// ClaimNext memakai FindOneAndUpdate agar satu job tidak diambil dua worker
+func (r *JobMongoRepo) ClaimNext(ctx context.Context, types []string, now time.Time) (*jobs.Job, error) {
+    if len(types) == 0 {
+        return nil, nil
+    }
+
+    filter := bson.M{
+        "status":    jobs.StatusQueued,
+        "run_after": bson.M{"$lte": now},
+        "type":      bson.M{"$in": types},
+    }
+    update := bson.M{
+        "$set": bson.M{"status": jobs.StatusRunning, "started_at": now, "updated_at": now},
+        "$inc": bson.M{"attempts": 1},
+        "$unset": bson.M{"error": ""},
+    }
+    opts := options.FindOneAndUpdate().
+        SetSort(bson.D{{Key: "created_at", Value: 1}}).
+        SetReturnDocument(options.After)
+
+    var j jobs.Job
+    err := database.JobsCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&j)
+    if err == mongo.ErrNoDocuments {
+        return nil, nil
+    }
+    if err != nil {
+        return nil, err
+    }
+    return &j, nil
+}
+
+func (r *JobMongoRepo) UpdateProgress(ctx context.Context, id string, progress int, message string) error {
+    _, err := database.JobsCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{
+        "$set": bson.M{"progress": progress, "message": message, "updated_at": time.Now()},
+    })
+    return err
+}
+
+func (r *JobMongoRepo) Finish(ctx context.Context, j *jobs.Job) error {
+    _, err := database.JobsCollection.UpdateOne(ctx, bson.M{"_id": j.ID}, bson.M{"$set": bson.M{
+        "status":      j.Status,
+        "progress":    j.Progress,
+        "message":     j.Message,
+        "error":       j.Error,
+        "result_path": j.ResultPath,
+        "result_name": j.ResultName,
+        "result_type": j.ResultType,
+        "run_after":   j.RunAfter,
+        "updated_at":  j.UpdatedAt,
+        "finished_at": j.FinishedAt,
+    }})
+    return err
+}
+
+func (r *JobMongoRepo) Recover(ctx context.Context) (int64, error) {
+    now := time.Now()
+    res, err := database.JobsCollection.UpdateMany(ctx,
+        bson.M{"status": jobs.StatusRunning},
+        bson.M{"$set": bson.M{"status": jobs.StatusQueued, "run_after": now, "updated_at": now}},
+    )
+    if err != nil {
+        return 0, err
+    }
+    return res.ModifiedCount, nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+    "context"
+    "strconv"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/app/models/mongodb"
+    "alumniproject/database/mongodb"
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/cursor"
+    "alumniproject/utils/filter"
+)
+
+// ErrInvalidCursor -> nilai cursor tidak cocok dengan tipe kolom sort
+var ErrInvalidCursor = apperror.Validation("invalid_cursor").WithCode(apperror.CodeInvalidCursor)
+
+// Tipe kolom sort selain _id, untuk mengubah nilai cursor kembali ke tipe aslinya
+var (
+    alumniKeysetKinds = map[string]filter.Kind{
+        "nim": filter.String, "nama": filter.String, "jurusan": filter.String,
+        "angkatan": filter.Int, "tahun_lulus": filter.Int,
+    }
+    pekerjaanKeysetKinds = map[string]filter.Kind{
+        "nama_perusahaan": filter.String, "posisi_jabatan": filter.String,
+        "tanggal_mulai_kerja": filter.Time, "created_at": filter.Time,
+    }
+)
+
+// keysetCondition -> dokumen setelah (atau sebelum, jika desc) baris batas cur berdasarkan (sortBy, _id)
+func keysetCondition(sortBy string, desc bool, cur *cursor.Cursor, kinds map[string]filter.Kind) (bson.M, error) {
+    op := "$gt"
+    if desc {
+        op = "$lt"
+    }
+    id, err := primitive.ObjectIDFromHex(cur.ID)
+    if err != nil {
+        return nil, ErrInvalidCursor
+    }
+    if sortBy == "_id" {
+        return bson.M{"_id": bson.M{op: id}}, nil
+    }
+
+    var value interface{} = cur.Value
+    switch kinds[sortBy] {
+    case filter.Int:
+        if value, err = strconv.Atoi(cur.Value); err != nil {
+            return nil, ErrInvalidCursor
+        }
+    case filter.Time:
+        if value, err = time.Parse(time.RFC3339Nano, cur.Value); err != nil {
+            return nil, ErrInvalidCursor
+        }
+    }
+    return bson.M{"$or": []bson.M{
+        {sortBy: bson.M{op: value}},
+        {sortBy: value, "_id": bson.M{op: id}},
+    }}, nil
+}
+
+// findKeyset menjalankan query keyset: filter + kondisi cursor, urut (sortBy, _id), maksimal limit dokumen
+func findKeyset[T any](ctx context.Context, coll *mongo.Collection, query bson.M, sortBy, order string, limit int, cur *cursor.Cursor, kinds map[string]filter.Kind) ([]*T, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    desc := cursor.Descending(order, cur)
+    if cur != nil {
+        cond, err := keysetCondition(sortBy, desc, cur, kinds)
+        if err != nil {
+            return nil, err
+        }
+        and, _ := query["$and"].([]bson.M)
+        query["$and"] = append(and, cond)
+    }
+
+    dir := 1
+    if desc {
+        dir = -1
+    }
+    sort := bson.D{{Key: sortBy, Value: dir}}
+    if sortBy != "_id" {
+        sort = append(sort, bson.E{Key: "_id", Value: dir})
+    }
+
+    rows, err := coll.Find(ctx, query, options.Find().SetSort(sort).SetLimit(int64(limit)))
+    if err != nil {
+        return nil, err
+    }
+    defer rows.Close(ctx)
+
+    var results []*T
+    if err = rows.All(ctx, &results); err != nil {
+        return nil, err
+    }
+    return results, nil
+}
+
+// GetAlumniKeyset: seperti GetAlumniPaginated tapi keyset pagination setelah/sebelum cur
+func (r *AlumniMongoRepo) GetAlumniKeyset(ctx context.Context, search, sortBy, order string, limit int, cur *cursor.Cursor, role string, userID int, f filter.Filter) ([]*models.Alumni, error) {
+    query := f.Apply(alumniExportFilter(models.ExportFilter{Search: search}, role, userID))
+    return findKeyset[models.Alumni](ctx, database.AlumniCollection, query, sortBy, order, limit, cur, alumniKeysetKinds)
+}
+
+// GetPekerjaanKeyset: seperti GetPekerjaanPaginated tapi keyset pagination setelah/sebelum cur
+func (r *PekerjaanMongoRepo) GetPekerjaanKeyset(ctx context.Context, search, sortBy, order string, limit int, cur *cursor.Cursor, role string, userID int, f filter.Filter) ([]*models.Pekerjaan, error) {
+    query := f.Apply(pekerjaanExportFilter(models.ExportFilter{Search: search}, role, userID))
+    return findKeyset[models.Pekerjaan](ctx, database.PekerjaanCollection, query, sortBy, order, limit, cur, pekerjaanKeysetKinds)
+}
@@ -3,7 +3,7 @@ package repository
 import (
     "context"
     "fmt"
-    "log"
+    "log/slog"
     "strings"
     "time"
 
@@ -14,21 +14,25 @@ import (
 
     "alumniproject/app/models/mongodb"      // perbaiki sesuai path kamu
     "alumniproject/database/mongodb" // pastikan path benar
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/bulk"
+    "alumniproject/utils/filter"
 )
 
 type PekerjaanMongoRepo struct{}
 
 // GetAllPekerjaan: Sudah OK, tapi tambah log
-func (r *PekerjaanMongoRepo) GetAllPekerjaan(role string, userID int) ([]*models.Pekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetAllPekerjaan(ctx context.Context, role string, userID int, f filter.Filter) ([]*models.Pekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     filter := bson.M{"deleted_at": nil}
     if role != "admin" {
         filter["created_by"] = userID
     }
+    filter = f.Apply(filter)
 
-    log.Printf("🔍 GetAllPekerjaan filter: %+v (role: %s, userID: %d)", filter, role, userID)
+    slog.DebugContext(ctx, "get all pekerjaan", "filter", filter, "role", role, "user_id", userID)
 
     projection := bson.M{
         "_id":                   1,
@@ -42,30 +46,31 @@ func (r *PekerjaanMongoRepo) GetAllPekerjaan(role string, userID int) ([]*models
         "tanggal_selesai_kerja": 1,
         "status_pekerjaan":      1,
         "deskripsi_pekerjaan":   1,
+        "version":               1,
     }
 
-    opts := options.Find().SetProjection(projection).SetSort(bson.D{{"created_at", -1}})
+    opts := options.Find().SetProjection(projection).SetSort(bson.D{{Key: "created_at", Value: -1}})
 
     cursor, err := database.PekerjaanCollection.Find(ctx, filter, opts)
     if err != nil {
-        log.Printf("❌ Find error: %v", err)
+        slog.ErrorContext(ctx, "find pekerjaan gagal", "error", err)
         return nil, err
     }
     defer cursor.Close(ctx)
 
     var results []*models.Pekerjaan
     if err = cursor.All(ctx, &results); err != nil {
-        log.Printf("❌ Decode error: %v", err)
+        slog.ErrorContext(ctx, "decode pekerjaan gagal", "error", err)
         return nil, err
     }
 
-    log.Printf("📊 Found %d pekerjaan records", len(results))
+    slog.DebugContext(ctx, "pekerjaan ditemukan", "count", len(results))
     return results, nil
 }
 
 // GetPekerjaanByID: Tambah role/userID untuk filter owner
-func (r *PekerjaanMongoRepo) GetByID(role string, userID int, id string) (*models.Pekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetByID(ctx context.Context, role string, userID int, id string) (*models.Pekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     objID, err := primitive.ObjectIDFromHex(id)
@@ -90,6 +95,8 @@ func (r *PekerjaanMongoRepo) GetByID(role string, userID int, id string) (*model
         "tanggal_selesai_kerja": 1,
         "status_pekerjaan":      1,
         "deskripsi_pekerjaan":   1,
+        "created_by":            1,
+        "version":               1,
     })
 
     var pekerjaan models.Pekerjaan
@@ -101,13 +108,13 @@ func (r *PekerjaanMongoRepo) GetByID(role string, userID int, id string) (*model
         return nil, err
     }
 
-    log.Printf("✅ Data ditemukan: %+v", pekerjaan)
+    slog.DebugContext(ctx, "pekerjaan ditemukan", "id", pekerjaan.ID.Hex())
     return &pekerjaan, nil
 }
 
 // GetPekerjaanByAlumniID: Tambah filter role/userID (asumsi admin only, tapi selaraskan)
-func (r *PekerjaanMongoRepo) GetPekerjaanByAlumniID(alumniID int, role string, userID int) ([]*models.Pekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetPekerjaanByAlumniID(ctx context.Context, alumniID int, role string, userID int) ([]*models.Pekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     filter := bson.M{"alumni_id": alumniID, "deleted_at": nil}
@@ -116,7 +123,7 @@ func (r *PekerjaanMongoRepo) GetPekerjaanByAlumniID(alumniID int, role string, u
         filter["created_by"] = userID
     }
 
-    opts := options.Find().SetSort(bson.D{{"created_at", -1}})
+    opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
     cursor, err := database.PekerjaanCollection.Find(ctx, filter, opts)
     if err != nil {
         return nil, err
@@ -165,8 +172,8 @@ func (r *PekerjaanMongoRepo) GetPekerjaanByAlumniID(alumniID int, role string, u
 // }
 
 // CreatePekerjaan: Pakai sequential ID dari GetNextSequence (sudah include init)
-func (r *PekerjaanMongoRepo) CreatePekerjaan(p *models.Pekerjaan) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) CreatePekerjaan(ctx context.Context, p *models.Pekerjaan) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     p.ID = primitive.NewObjectID()
@@ -174,20 +181,23 @@ func (r *PekerjaanMongoRepo) CreatePekerjaan(p *models.Pekerjaan) error {
     p.CreatedAt = now
     p.UpdatedAt = now
     p.DeletedAt = nil
+    p.Version = 1
+    p.AlumniLinked = true
 
     _, err := database.PekerjaanCollection.InsertOne(ctx, p)
     if err != nil {
-        log.Printf("❌ Insert error: %v", err)
+        slog.ErrorContext(ctx, "insert pekerjaan gagal", "error", err)
         return fmt.Errorf("gagal membuat pekerjaan: %v", err)
     }
 
-    log.Printf("✅ Insert berhasil dengan ID: %s", p.ID.Hex())
+    slog.DebugContext(ctx, "insert pekerjaan berhasil", "id", p.ID.Hex())
     return nil
 }
 
-// UpdatePekerjaan: Tambah role/userID untuk filter owner
-func (r *PekerjaanMongoRepo) UpdatePekerjaan(id string, p *models.Pekerjaan, role string, userID int) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// UpdatePekerjaan: Tambah role/userID untuk filter owner.
+// ifMatch > 0 -> update hanya jika versi di database masih sama (optimistic locking)
+func (r *PekerjaanMongoRepo) UpdatePekerjaan(ctx context.Context, id string, p *models.Pekerjaan, role string, userID int, ifMatch int) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     objID, err := primitive.ObjectIDFromHex(id)
@@ -201,8 +211,11 @@ func (r *PekerjaanMongoRepo) UpdatePekerjaan(id string, p *models.Pekerjaan, rol
     if role != "admin" {
         filter["created_by"] = userID
     }
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
 
-    update := bson.M{"$set": bson.M{
+    update := bson.M{"$inc": bson.M{"version": 1}, "$set": bson.M{
         "nama_perusahaan":      p.NamaPerusahaan,
         "posisi_jabatan":       p.PosisiJabatan,
         "bidang_industri":      p.BidangIndustri,
@@ -215,18 +228,32 @@ func (r *PekerjaanMongoRepo) UpdatePekerjaan(id string, p *models.Pekerjaan, rol
         "updated_at":           p.UpdatedAt,
     }}
 
-    _, err = database.PekerjaanCollection.UpdateOne(ctx, filter, update)
+    opts := options.FindOneAndUpdate().
+        SetReturnDocument(options.After).
+        SetProjection(bson.M{"version": 1})
+
+    var updated struct {
+        Version int `bson:"version"`
+    }
+    err = database.PekerjaanCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+    if err == mongo.ErrNoDocuments {
+        if ifMatch > 0 {
+            return ErrVersionConflict
+        }
+        return fmt.Errorf("data tidak ditemukan")
+    }
     if err != nil {
         return fmt.Errorf("gagal update data: %v", err)
     }
+    p.Version = updated.Version
 
-    log.Printf("✅ Update berhasil untuk ID %s", id)
+    slog.DebugContext(ctx, "update pekerjaan berhasil", "id", id)
     return nil
 }
 
 // RestorePekerjaan: Fix bson.M dengan $exists untuk hindari nil type issue
-func (r *PekerjaanMongoRepo) RestorePekerjaan(id string) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) RestorePekerjaan(ctx context.Context, id string) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     objID, err := primitive.ObjectIDFromHex(id)
@@ -235,7 +262,7 @@ func (r *PekerjaanMongoRepo) RestorePekerjaan(id string) error {
     }
 
     filter := bson.M{"_id": objID, "deleted_at": bson.M{"$exists": true}}
-    update := bson.M{"$unset": bson.M{"deleted_at": ""}}
+    update := bson.M{"$unset": bson.M{"deleted_at": "", "deleted_by": "", "deletion_batch": ""}, "$inc": bson.M{"version": 1}}
 
     result, err := database.PekerjaanCollection.UpdateOne(ctx, filter, update)
     if err != nil {
@@ -246,13 +273,13 @@ func (r *PekerjaanMongoRepo) RestorePekerjaan(id string) error {
         return fmt.Errorf("data tidak ditemukan")
     }
 
-    log.Printf("✅ Restore berhasil untuk ID %s", id)
+    slog.DebugContext(ctx, "restore pekerjaan berhasil", "id", id)
     return nil
 }
 
 // SoftDeletePekerjaan: Fix sama, gunakan $exists: false untuk deleted_at: nil
-func (r *PekerjaanMongoRepo) SoftDeletePekerjaan(id string, userID int, role string) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) SoftDeletePekerjaan(ctx context.Context, id string, userID int, role string, ifMatch int) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     objID, err := primitive.ObjectIDFromHex(id)
@@ -264,24 +291,30 @@ func (r *PekerjaanMongoRepo) SoftDeletePekerjaan(id string, userID int, role str
     if role != "admin" {
         filter["created_by"] = userID
     }
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
 
-    update := bson.M{"$set": bson.M{"deleted_at": time.Now()}}
+    update := bson.M{"$set": bson.M{"deleted_at": time.Now(), "deleted_by": userID}, "$inc": bson.M{"version": 1}}
     result, err := database.PekerjaanCollection.UpdateOne(ctx, filter, update)
     if err != nil {
         return fmt.Errorf("gagal soft delete: %v", err)
     }
 
     if result.MatchedCount == 0 {
+        if ifMatch > 0 {
+            return ErrVersionConflict
+        }
         return fmt.Errorf("data tidak ditemukan atau sudah dihapus")
     }
 
-    log.Printf("✅ Soft delete berhasil untuk ID %s", id)
+    slog.DebugContext(ctx, "soft delete pekerjaan berhasil", "id", id)
     return nil
 }
 
 // HardDeletePekerjaanByID: OK, _id
-func (r *PekerjaanMongoRepo) HardDeletePekerjaanByID(id string) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) HardDeletePekerjaanByID(ctx context.Context, id string, ifMatch int) error {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     objID, err := primitive.ObjectIDFromHex(id)
@@ -290,22 +323,28 @@ func (r *PekerjaanMongoRepo) HardDeletePekerjaanByID(id string) error {
     }
 
     filter := bson.M{"_id": objID, "deleted_at": bson.M{"$ne": nil}}
+    if ifMatch > 0 {
+        filter["version"] = ifMatch
+    }
     result, err := database.PekerjaanCollection.DeleteOne(ctx, filter)
     if err != nil {
         return fmt.Errorf("gagal hard delete: %v", err)
     }
     if result.DeletedCount == 0 {
+        if ifMatch > 0 {
+            return ErrVersionConflict
+        }
         return fmt.Errorf("data tidak ditemukan")
     }
 
-    log.Printf("✅ Hard delete berhasil untuk ID %s", id)
+    slog.DebugContext(ctx, "hard delete pekerjaan berhasil", "id", id)
     return nil
 }
 
 
 // GetTrashPekerjaan: OK
-func (r *PekerjaanMongoRepo) GetTrashPekerjaan(userID int, role string) ([]*models.GetTrashPekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetTrashPekerjaan(ctx context.Context, userID int, role string) ([]*models.GetTrashPekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     filter := bson.M{"deleted_at": bson.M{"$ne": nil}}
@@ -313,7 +352,7 @@ func (r *PekerjaanMongoRepo) GetTrashPekerjaan(userID int, role string) ([]*mode
         filter["created_by"] = userID
     }
 
-    opts := options.Find().SetSort(bson.D{{"deleted_at", -1}})
+    opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
     cursor, err := database.PekerjaanCollection.Find(ctx, filter, opts)
     if err != nil {
         return nil, err
@@ -328,8 +367,8 @@ func (r *PekerjaanMongoRepo) GetTrashPekerjaan(userID int, role string) ([]*mode
 }
 
 // GetPekerjaanPaginated: Tambah role/userID
-func (r *PekerjaanMongoRepo) GetPekerjaanPaginated(search, sortBy, order string, limit, offset int, role string, userID int) ([]*models.Pekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetPekerjaanPaginated(ctx context.Context, search, sortBy, order string, limit, offset int, role string, userID int, f filter.Filter) ([]*models.Pekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     filter := bson.M{
@@ -342,8 +381,9 @@ func (r *PekerjaanMongoRepo) GetPekerjaanPaginated(search, sortBy, order string,
     if role != "admin" {
         filter["created_by"] = userID
     }
+    filter = f.Apply(filter)
 
-    sort := bson.D{{sortBy, getMongoOrder(order)}}
+    sort := bson.D{{Key: sortBy, Value: getMongoOrder(order)}}
     opts := options.Find().
         SetSort(sort).
         SetLimit(int64(limit)).
@@ -363,8 +403,8 @@ func (r *PekerjaanMongoRepo) GetPekerjaanPaginated(search, sortBy, order string,
 }
 
 // CountPekerjaan: Tambah role/userID
-func (r *PekerjaanMongoRepo) CountPekerjaan(search string, role string, userID int) (int64, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) CountPekerjaan(ctx context.Context, search string, role string, userID int, f filter.Filter) (int64, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     filter := bson.M{
@@ -377,34 +417,43 @@ func (r *PekerjaanMongoRepo) CountPekerjaan(search string, role string, userID i
     if role != "admin" {
         filter["created_by"] = userID
     }
+    filter = f.Apply(filter)
 
     return database.PekerjaanCollection.CountDocuments(ctx, filter)
 }
 
 // GetAlumniWithPekerjaan: OK (sudah pass isAdmin)
-func (r *PekerjaanMongoRepo) GetAlumniWithPekerjaan(userID int, isAdmin bool) ([]*models.AlumniWithPekerjaan, error) {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func (r *PekerjaanMongoRepo) GetAlumniWithPekerjaan(ctx context.Context, userID int, isAdmin bool) ([]*models.AlumniWithPekerjaan, error) {
+    ctx, cancel := database.WithQueryTimeout(ctx)
     defer cancel()
 
     pipeline := mongo.Pipeline{
-        bson.D{{"$match", bson.D{{"deleted_at", nil}}}},
-        bson.D{{"$lookup", bson.D{
-            {"from", "alumni"},
-            {"localField", "alumni_id"},
-            {"foreignField", "_id"},
-            {"as", "alumni_data"},
+        bson.D{{Key: "$match", Value: bson.D{{Key: "deleted_at", Value: nil}}}},
+        // pekerjaan.alumni_id merujuk alumni_id (angka) alumni, bukan _id
+        bson.D{{Key: "$lookup", Value: bson.D{
+            {Key: "from", Value: "alumni"},
+            {Key: "localField", Value: "alumni_id"},
+            {Key: "foreignField", Value: "alumni_id"},
+            {Key: "as", Value: "alumni_data"},
         }}},
+        bson.D{{Key: "$unwind", Value: "$alumni_data"}},
     }
 
     if !isAdmin {
-        pipeline = append(pipeline, bson.D{{"$match", bson.D{{"created_by", userID}}}})
+        pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.D{{Key: "created_by", Value: userID}}}})
     }
 
-    pipeline = append(pipeline, bson.D{{"$group", bson.D{
-        {"_id", "$alumni_data._id"},
-        {"alumni", bson.D{{"$first", "$alumni_data"}}},
-        {"pekerjaan", bson.D{{"$push", "$$ROOT"}}},
-    }}})
+    pipeline = append(pipeline,
+        bson.D{{Key: "$group", Value: bson.D{
+            {Key: "_id", Value: "$alumni_data._id"},
+            {Key: "alumni", Value: bson.D{{Key: "$first", Value: "$alumni_data"}}},
+            {Key: "pekerjaan", Value: bson.D{{Key: "$push", Value: "$$ROOT"}}},
+        }}},
+        // Field alumni dinaikkan ke level atas sesuai bentuk AlumniWithPekerjaan
+        bson.D{{Key: "$replaceRoot", Value: bson.D{{Key: "newRoot", Value: bson.D{
+            {Key: "$mergeObjects", Value: bson.A{"$alumni", bson.D{{Key: "pekerjaan", Value: "$pekerjaan"}}}},
+        }}}}},
+    )
 
     cursor, err := database.PekerjaanCollection.Aggregate(ctx, pipeline)
     if err != nil {
@@ -419,6 +468,143 @@ func (r *PekerjaanMongoRepo) GetAlumniWithPekerjaan(userID int, isAdmin bool) ([
     return results, nil
 }
 
+// ErrVersionConflict -> versi di If-Match tidak sama dengan versi di database
+var ErrVersionConflict = apperror.PreconditionFailed("version_conflict").WithCode(apperror.CodeVersionConflict)
+
+// ErrBulkDibatalkan -> item tidak disimpan karena item lain gagal pada mode all-or-nothing
+var ErrBulkDibatalkan = bulk.ErrCancelled
+
+// runBulk menjalankan fn untuk setiap item.
+// Mode atomic memakai multi-document transaction (butuh replica set), satu gagal -> semua dibatalkan.
+// Mode best-effort menjalankan item satu per satu tanpa transaksi.
+func runBulk(ctx context.Context, n int, atomic bool, fn func(ctx context.Context, i int) error) ([]error, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    errs := make([]error, n)
+    if !atomic {
+        for i := 0; i < n; i++ {
+            errs[i] = fn(ctx, i)
+        }
+        return errs, nil
+    }
+
+    session, err := database.MongoClient.StartSession()
+    if err != nil {
+        return nil, err
+    }
+    defer session.EndSession(ctx)
+
+    failedAt := -1
+    _, err = session.WithTransaction(ctx, func(sc mongo.SessionContext) (interface{}, error) {
+        // WithTransaction bisa mengulang callback, jadi reset state setiap percobaan
+        failedAt = -1
+        for i := range errs {
+            errs[i] = nil
+        }
+        for i := 0; i < n; i++ {
+            if err := fn(sc, i); err != nil {
+                errs[i] = err
+                failedAt = i
+                return nil, err
+            }
+        }
+        return nil, nil
+    })
+    if err != nil && failedAt < 0 {
+        return nil, err
+    }
+
+    if failedAt >= 0 {
+        for i := range errs {
+            if errs[i] == nil {
+                errs[i] = ErrBulkDibatalkan
+            }
+        }
+    }
+    return errs, nil
+}
+
+// BulkCreatePekerjaan menyimpan banyak pekerjaan sekaligus
+func (r *PekerjaanMongoRepo) BulkCreatePekerjaan(ctx context.Context, list []*models.Pekerjaan, atomic bool) ([]error, error) {
+    now := time.Now()
+    errs, err := runBulk(ctx, len(list), atomic, func(ctx context.Context, i int) error {
+        p := list[i]
+        p.ID = primitive.NewObjectID()
+        p.CreatedAt = now
+        p.UpdatedAt = now
+        p.DeletedAt = nil
+        p.Version = 1
+        p.AlumniLinked = true
+        _, err := database.PekerjaanCollection.InsertOne(ctx, p)
+        return err
+    })
+    if err != nil {
+        return nil, err
+    }
+
+    for i, e := range errs {
+        if e != nil {
+            list[i].ID = primitive.NilObjectID
+        }
+    }
+    slog.DebugContext(ctx, "bulk insert pekerjaan selesai", "count", len(list))
+    return errs, nil
+}
+
+// BulkUpdatePekerjaan mengubah banyak pekerjaan sekaligus; non-admin hanya bisa ubah miliknya sendiri.
+// p.Version > 0 -> item hanya diubah jika versi di database masih sama, selain itu ErrVersionConflict.
+// Dokumen lama dikembalikan di before untuk audit; field yang tidak ikut diubah (alumni_id, created_by,
+// created_at) disalin ke list sehingga list berisi data baru yang lengkap.
+func (r *PekerjaanMongoRepo) BulkUpdatePekerjaan(ctx context.Context, list []*models.Pekerjaan, atomic bool, role string, userID int) ([]*models.Pekerjaan, []error, error) {
+    now := time.Now()
+    before := make([]*models.Pekerjaan, len(list))
+    errs, err := runBulk(ctx, len(list), atomic, func(ctx context.Context, i int) error {
+        p := list[i]
+        filter := bson.M{"_id": p.ID, "deleted_at": nil}
+        if role != "admin" {
+            filter["created_by"] = userID
+        }
+        if p.Version > 0 {
+            filter["version"] = p.Version
+        }
+
+        update := bson.M{"$inc": bson.M{"version": 1}, "$set": bson.M{
+            "nama_perusahaan":       p.NamaPerusahaan,
+            "posisi_jabatan":        p.PosisiJabatan,
+            "bidang_industri":       p.BidangIndustri,
+            "lokasi_kerja":          p.LokasiKerja,
+            "gaji_range":            p.GajiRange,
+            "tanggal_mulai_kerja":   p.TanggalMulaiKerja,
+            "tanggal_selesai_kerja": p.TanggalSelesaiKerja,
+            "status_pekerjaan":      p.StatusPekerjaan,
+            "deskripsi_pekerjaan":   p.DeskripsiPekerjaan,
+            "updated_at":            now,
+        }}
+
+        var old models.Pekerjaan
+        opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+        err := database.PekerjaanCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&old)
+        if err == mongo.ErrNoDocuments {
+            if p.Version > 0 {
+                return ErrVersionConflict
+            }
+            return apperror.NotFound("pekerjaan.not_found_or_not_owned")
+        }
+        if err != nil {
+            return fmt.Errorf("gagal update data: %v", err)
+        }
+        p.AlumniID, p.CreatedBy, p.CreatedAt, p.UpdatedAt = old.AlumniID, old.CreatedBy, old.CreatedAt, now
+        p.Version = old.Version + 1
+        before[i] = &old
+        return nil
+    })
+    if err != nil {
+        return nil, nil, err
+    }
+    return before, errs, nil
+}
+
 // Helper
 func getMongoOrder(order string) int {
     if strings.ToLower(order) == "desc" {
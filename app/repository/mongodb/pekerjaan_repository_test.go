@@ -0,0 +1,78 @@
+package repository
+
+import (
+    "testing"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson/primitive"
+
+    models "alumniproject/app/models/mongodb"
+    database "alumniproject/database/mongodb"
+)
+
+func TestBulkUpdatePekerjaanReturnsOldDocuments(t *testing.T) {
+    ctx := setupTestDB(t)
+    repo := New()
+
+    created := time.Now().Add(-time.Hour).Truncate(time.Millisecond).UTC()
+    own := models.Pekerjaan{ID: primitive.NewObjectID(), AlumniID: 7, NamaPerusahaan: "Lama", CreatedBy: 1, CreatedAt: created, Version: 1}
+    other := models.Pekerjaan{ID: primitive.NewObjectID(), AlumniID: 8, NamaPerusahaan: "Orang lain", CreatedBy: 2, CreatedAt: created, Version: 1}
+    if _, err := database.PekerjaanCollection.InsertMany(ctx, []interface{}{own, other}); err != nil {
+        t.Fatalf("insert pekerjaan: %v", err)
+    }
+
+    list := []*models.Pekerjaan{
+        {ID: own.ID, NamaPerusahaan: "Baru"},
+        {ID: other.ID, NamaPerusahaan: "Diambil alih"},
+    }
+    before, errs, err := repo.BulkUpdatePekerjaan(ctx, list, false, "user", 1)
+    if err != nil {
+        t.Fatalf("BulkUpdatePekerjaan: %v", err)
+    }
+
+    if errs[0] != nil {
+        t.Fatalf("item milik sendiri gagal: %v", errs[0])
+    }
+    if before[0] == nil || before[0].NamaPerusahaan != "Lama" || before[0].Version != 1 {
+        t.Errorf("before[0] = %+v, want dokumen lama versi 1", before[0])
+    }
+    if p := list[0]; p.Version != 2 || p.AlumniID != 7 || p.CreatedBy != 1 || !p.CreatedAt.Equal(created) {
+        t.Errorf("list[0] = %+v, want versi 2 dengan alumni_id, created_by, created_at dari data lama", p)
+    }
+
+    if errs[1] == nil {
+        t.Error("item milik user lain ikut terupdate")
+    }
+    if before[1] != nil {
+        t.Errorf("before[1] = %+v, want nil untuk item yang gagal", before[1])
+    }
+}
+
+func TestBulkUpdatePekerjaanRejectsStaleVersion(t *testing.T) {
+    ctx := setupTestDB(t)
+    repo := New()
+
+    p := models.Pekerjaan{ID: primitive.NewObjectID(), AlumniID: 7, NamaPerusahaan: "Lama", CreatedBy: 1, Version: 3}
+    if _, err := database.PekerjaanCollection.InsertOne(ctx, p); err != nil {
+        t.Fatalf("insert pekerjaan: %v", err)
+    }
+
+    _, errs, err := repo.BulkUpdatePekerjaan(ctx, []*models.Pekerjaan{{ID: p.ID, NamaPerusahaan: "Basi", Version: 2}}, false, "admin", 0)
+    if err != nil {
+        t.Fatalf("BulkUpdatePekerjaan: %v", err)
+    }
+    if errs[0] != ErrVersionConflict {
+        t.Fatalf("versi lama: err = %v, want ErrVersionConflict", errs[0])
+    }
+
+    list := []*models.Pekerjaan{{ID: p.ID, NamaPerusahaan: "Baru", Version: 3}}
+    if _, errs, err = repo.BulkUpdatePekerjaan(ctx, list, false, "admin", 0); err != nil {
+        t.Fatalf("BulkUpdatePekerjaan: %v", err)
+    }
+    if errs[0] != nil {
+        t.Fatalf("versi sesuai: err = %v", errs[0])
+    }
+    if list[0].Version != 4 {
+        t.Errorf("version = %d, want 4", list[0].Version)
+    }
+}
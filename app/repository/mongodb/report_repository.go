@@ -0,0 +1,128 @@
+package repository
+
+import (
+    "context"
+    "regexp"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+
+    "alumniproject/database/mongodb"
+    "alumniproject/utils/report"
+)
+
+type ReportMongoRepo struct{}
+
+func NewReportRepo() *ReportMongoRepo {
+    return &ReportMongoRepo{}
+}
+
+// tracerAlumniMatch -> alumni aktif sesuai filter laporan. prefix dipakai saat alumni hasil $lookup.
+func tracerAlumniMatch(f report.TracerFilter, prefix string) bson.M {
+    match := bson.M{prefix + "deleted_at": nil}
+    if f.Angkatan != 0 {
+        match[prefix+"angkatan"] = f.Angkatan
+    }
+    if f.Jurusan != "" {
+        match[prefix+"jurusan"] = bson.M{"$regex": "^" + regexp.QuoteMeta(f.Jurusan) + "$", "$options": "i"}
+    }
+    return match
+}
+
+// GetTracerStats mengambil data laporan tracer study: keterserapan per angkatan serta
+// breakdown bidang industri, perusahaan teratas, dan rentang gaji dari pekerjaan aktif.
+// Pekerjaan dihubungkan ke alumni lewat alumni_id (kunci angka di kedua collection).
+func (r *ReportMongoRepo) GetTracerStats(ctx context.Context, f report.TracerFilter) (*report.TracerStats, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    stats := &report.TracerStats{}
+
+    activeJob := bson.M{"$and": []interface{}{
+        bson.M{"$eq": []interface{}{"$$this.status_pekerjaan", "aktif"}},
+        bson.M{"$eq": []interface{}{bson.M{"$ifNull": []interface{}{"$$this.deleted_at", nil}}, nil}},
+    }}
+    pipeline := mongo.Pipeline{
+        {{Key: "$match", Value: tracerAlumniMatch(f, "")}},
+        {{Key: "$lookup", Value: bson.M{
+            "from":         "pekerjaan",
+            "localField":   "alumni_id",
+            "foreignField": "alumni_id",
+            "as":           "pekerjaan",
+        }}},
+        {{Key: "$group", Value: bson.M{
+            "_id":   "$angkatan",
+            "total": bson.M{"$sum": 1},
+            "employed": bson.M{"$sum": bson.M{"$cond": []interface{}{
+                bson.M{"$gt": []interface{}{bson.M{"$size": bson.M{"$filter": bson.M{"input": "$pekerjaan", "cond": activeJob}}}, 0}},
+                1, 0,
+            }}},
+        }}},
+        {{Key: "$sort", Value: bson.M{"_id": 1}}},
+    }
+
+    cursor, err := database.AlumniCollection.Aggregate(ctx, pipeline)
+    if err != nil {
+        return nil, err
+    }
+    var cohorts []struct {
+        Angkatan int `bson:"_id"`
+        Total    int `bson:"total"`
+        Employed int `bson:"employed"`
+    }
+    if err := cursor.All(ctx, &cohorts); err != nil {
+        return nil, err
+    }
+    for _, c := range cohorts {
+        stats.Cohorts = append(stats.Cohorts, report.CohortStat{Angkatan: c.Angkatan, Total: c.Total, Employed: c.Employed})
+    }
+
+    if stats.Industries, err = countActivePekerjaan(ctx, "bidang_industri", f, 0); err != nil {
+        return nil, err
+    }
+    if stats.Employers, err = countActivePekerjaan(ctx, "nama_perusahaan", f, report.TopEmployers); err != nil {
+        return nil, err
+    }
+    if stats.SalaryBands, err = countActivePekerjaan(ctx, "gaji_range", f, 0); err != nil {
+        return nil, err
+    }
+    return stats, nil
+}
+
+// countActivePekerjaan menghitung pekerjaan aktif per nilai field, terbanyak lebih dulu. limit 0 = semua.
+func countActivePekerjaan(ctx context.Context, field string, f report.TracerFilter, limit int) ([]report.CountStat, error) {
+    pipeline := mongo.Pipeline{
+        {{Key: "$match", Value: bson.M{"deleted_at": nil, "status_pekerjaan": "aktif"}}},
+        {{Key: "$lookup", Value: bson.M{
+            "from":         "alumni",
+            "localField":   "alumni_id",
+            "foreignField": "alumni_id",
+            "as":           "alumni",
+        }}},
+        {{Key: "$unwind", Value: "$alumni"}},
+        {{Key: "$match", Value: tracerAlumniMatch(f, "alumni.")}},
+        {{Key: "$group", Value: bson.M{"_id": bson.M{"$ifNull": []interface{}{"$" + field, ""}}, "count": bson.M{"$sum": 1}}}},
+        {{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+    }
+    if limit > 0 {
+        pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+    }
+
+    cursor, err := database.PekerjaanCollection.Aggregate(ctx, pipeline)
+    if err != nil {
+        return nil, err
+    }
+    var rows []struct {
+        Label string `bson:"_id"`
+        Count int    `bson:"count"`
+    }
+    if err := cursor.All(ctx, &rows); err != nil {
+        return nil, err
+    }
+
+    list := make([]report.CountStat, len(rows))
+    for i, row := range rows {
+        list[i] = report.CountStat{Label: row.Label, Count: row.Count}
+    }
+    return list, nil
+}
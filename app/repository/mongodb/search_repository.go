@@ -0,0 +1,152 @@
+package repository
+
+import (
+    "context"
+    "regexp"
+    "strconv"
+    "strings"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/app/models/mongodb"
+    "alumniproject/database/mongodb"
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/search"
+)
+
+// ErrSearchTidakTersedia -> text index pencarian belum terpasang (gagal dibuat saat start)
+var ErrSearchTidakTersedia = apperror.Unavailable("search.unavailable")
+
+// searchFilter -> filter $text (text index alumni_search / pekerjaan_search).
+// Jika $text tidak menemukan apa pun, dipakai regex per kata (cocok sebagian, mis. potongan NIM).
+func searchFilter(q string, fields []string, text bool, role string, userID int) bson.M {
+    filter := bson.M{"deleted_at": nil}
+    if text {
+        filter["$text"] = bson.M{"$search": q}
+    } else {
+        var and []bson.M
+        for _, t := range search.Terms(q) {
+            var or []bson.M
+            for _, f := range fields {
+                or = append(or, bson.M{f: bson.M{"$regex": regexp.QuoteMeta(t), "$options": "i"}})
+            }
+            and = append(and, bson.M{"$or": or})
+        }
+        if len(and) == 0 {
+            and = append(and, bson.M{"_id": nil})
+        }
+        filter["$and"] = and
+    }
+    if role != "admin" {
+        filter["created_by"] = userID
+    }
+    return filter
+}
+
+// runSearch menjalankan pencarian $text (urut berdasarkan textScore), lalu fallback regex jika kosong
+func runSearch[T any](ctx context.Context, coll *mongo.Collection, q string, fields []string, limit int, role string, userID int) ([]T, []float64, int64, error) {
+    if !database.SearchEnabled() {
+        return nil, nil, 0, ErrSearchTidakTersedia
+    }
+    ctx, cancel := database.WithQueryTimeout(ctx)
+    defer cancel()
+
+    for _, text := range []bool{true, false} {
+        filter := searchFilter(q, fields, text, role, userID)
+        total, err := coll.CountDocuments(ctx, filter)
+        if err != nil {
+            return nil, nil, 0, err
+        }
+        if total == 0 {
+            continue
+        }
+
+        opts := options.Find().SetLimit(int64(limit))
+        if text {
+            opts.SetProjection(bson.M{"score": bson.M{"$meta": "textScore"}}).
+                SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}})
+        } else {
+            opts.SetSort(bson.D{{Key: "_id", Value: 1}})
+        }
+
+        cursor, err := coll.Find(ctx, filter, opts)
+        if err != nil {
+            return nil, nil, 0, err
+        }
+        var docs []bson.Raw
+        if err := cursor.All(ctx, &docs); err != nil {
+            return nil, nil, 0, err
+        }
+
+        items := make([]T, len(docs))
+        scores := make([]float64, len(docs))
+        for i, raw := range docs {
+            if err := bson.Unmarshal(raw, &items[i]); err != nil {
+                return nil, nil, 0, err
+            }
+            if v, ok := raw.Lookup("score").DoubleOK(); ok {
+                scores[i] = v
+            }
+        }
+        return items, scores, total, nil
+    }
+    return nil, nil, 0, nil
+}
+
+// Search mencari alumni aktif berdasarkan nama, NIM, dan jurusan. Non-admin hanya mencari data miliknya.
+func (r *AlumniMongoRepo) Search(ctx context.Context, q string, limit int, role string, userID int) (*models.SearchGroup, error) {
+    list, scores, total, err := runSearch[models.Alumni](ctx, database.AlumniCollection, q, []string{"nama", "nim", "jurusan"}, limit, role, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    group := &models.SearchGroup{Type: search.TypeAlumni, Total: total, Items: []models.SearchHit{}}
+    for i, a := range list {
+        subtitle := []string{a.NIM, a.Jurusan}
+        if a.Angkatan != 0 {
+            subtitle = append(subtitle, "Angkatan "+strconv.Itoa(a.Angkatan))
+        }
+        group.Items = append(group.Items, models.SearchHit{
+            ID:       a.ID.Hex(),
+            Title:    a.Nama,
+            Subtitle: joinNonEmpty(subtitle...),
+            Score:    scores[i],
+            Text:     strings.Join([]string{a.Nama, a.NIM, a.Jurusan}, " "),
+        })
+    }
+    return group, nil
+}
+
+// Search mencari pekerjaan aktif berdasarkan perusahaan, posisi, bidang industri, dan deskripsi.
+// Non-admin hanya mencari data miliknya.
+func (r *PekerjaanMongoRepo) Search(ctx context.Context, q string, limit int, role string, userID int) (*models.SearchGroup, error) {
+    fields := []string{"nama_perusahaan", "posisi_jabatan", "bidang_industri", "deskripsi_pekerjaan"}
+    list, scores, total, err := runSearch[models.Pekerjaan](ctx, database.PekerjaanCollection, q, fields, limit, role, userID)
+    if err != nil {
+        return nil, err
+    }
+
+    group := &models.SearchGroup{Type: search.TypePekerjaan, Total: total, Items: []models.SearchHit{}}
+    for i, p := range list {
+        group.Items = append(group.Items, models.SearchHit{
+            ID:       p.ID.Hex(),
+            Title:    p.PosisiJabatan + " - " + p.NamaPerusahaan,
+            Subtitle: joinNonEmpty(p.BidangIndustri, p.LokasiKerja),
+            Score:    scores[i],
+            Text:     strings.Join([]string{p.PosisiJabatan, p.NamaPerusahaan, p.BidangIndustri, p.DeskripsiPekerjaan}, " "),
+        })
+    }
+    return group, nil
+}
+
+func joinNonEmpty(parts ...string) string {
+    var out []string
+    for _, p := range parts {
+        if p != "" {
+            out = append(out, p)
+        }
+    }
+    return strings.Join(out, " · ")
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+    "context"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    models "alumniproject/app/models/mongodb"
+    database "alumniproject/database/mongodb"
+)
+
+type TrashMongoRepo struct{}
+
+func NewTrashRepo() *TrashMongoRepo {
+    return &TrashMongoRepo{}
+}
+
+// PurgeTrash menghapus permanen dokumen di trash yang deleted_at-nya sebelum cutoff.
+// Pekerjaan yang masih di trash ikut terhapus jika alumninya di-purge.
+// Alumni yang masih punya pekerjaan aktif dilewati (dilaporkan sebagai skipped).
+// dryRun -> hanya menghitung dokumen yang akan dihapus.
+func (r *TrashMongoRepo) PurgeTrash(ctx context.Context, cutoff time.Time, dryRun bool) ([]models.PurgeCollectionReport, error) {
+    ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+    defer cancel()
+
+    pekerjaan := models.PurgeCollectionReport{Collection: "pekerjaan"}
+    alumni := models.PurgeCollectionReport{Collection: "alumni"}
+
+    // Alumni yang melewati retensi. Pekerjaan merujuk alumni lewat alumni_id (angka), bukan _id
+    cursor, err := database.AlumniCollection.Find(ctx,
+        bson.M{"deleted_at": bson.M{"$lt": cutoff}},
+        options.Find().SetProjection(bson.M{"_id": 1, "alumni_id": 1}),
+    )
+    if err != nil {
+        return nil, err
+    }
+    var expired []models.Alumni
+    if err := cursor.All(ctx, &expired); err != nil {
+        return nil, err
+    }
+
+    // Alumni yang masih punya pekerjaan aktif tidak boleh di-purge
+    var purgeIDs []primitive.ObjectID
+    var purgeKeys []int
+    if len(expired) > 0 {
+        keys := make([]int, len(expired))
+        for i, a := range expired {
+            keys[i] = a.AlumniID
+        }
+        active, err := database.PekerjaanCollection.Distinct(ctx, "alumni_id", bson.M{
+            "alumni_id":  bson.M{"$in": keys},
+            "deleted_at": nil,
+        })
+        if err != nil {
+            return nil, err
+        }
+        blocked := make(map[int]bool, len(active))
+        for _, v := range active {
+            if key, ok := intValue(v); ok {
+                blocked[key] = true
+            }
+        }
+        for _, a := range expired {
+            if blocked[a.AlumniID] {
+                alumni.Skipped++
+                continue
+            }
+            purgeIDs = append(purgeIDs, a.ID)
+            if a.AlumniID > 0 {
+                purgeKeys = append(purgeKeys, a.AlumniID)
+            }
+        }
+    }
+
+    expiredPekerjaan := bson.M{"deleted_at": bson.M{"$lt": cutoff}}
+    // Cascade: pekerjaan di trash milik alumni yang di-purge, walau belum lewat retensi.
+    // Hanya pekerjaan alumni_linked, lihat pekerjaanCascadeFilter.
+    cascadePekerjaan := bson.M{
+        "deleted_at":    bson.M{"$gte": cutoff},
+        "alumni_id":     bson.M{"$in": purgeKeys},
+        "alumni_linked": true,
+    }
+    purgeAlumni := bson.M{"_id": bson.M{"$in": purgeIDs}}
+
+    run := func(coll *mongo.Collection, filter bson.M) (int64, error) {
+        if dryRun {
+            return coll.CountDocuments(ctx, filter, options.Count())
+        }
+        res, err := coll.DeleteMany(ctx, filter)
+        if err != nil {
+            return 0, err
+        }
+        return res.DeletedCount, nil
+    }
+
+    if pekerjaan.Purged, err = run(database.PekerjaanCollection, expiredPekerjaan); err != nil {
+        return nil, err
+    }
+    if len(purgeKeys) > 0 {
+        if pekerjaan.Cascaded, err = run(database.PekerjaanCollection, cascadePekerjaan); err != nil {
+            return nil, err
+        }
+    }
+    if len(purgeIDs) > 0 {
+        if alumni.Purged, err = run(database.AlumniCollection, purgeAlumni); err != nil {
+            return nil, err
+        }
+    }
+
+    return []models.PurgeCollectionReport{pekerjaan, alumni}, nil
+}
+
+// intValue -> angka hasil Distinct (int32 / int64 tergantung besar nilai yang tersimpan)
+func intValue(v interface{}) (int, bool) {
+    switch n := v.(type) {
+    case int32:
+        return int(n), true
+    case int64:
+        return int(n), true
+    }
+    return 0, false
+}
+
+// trashFilter membangun filter untuk pekerjaan di trash.
+// Non-admin hanya bisa menyentuh data miliknya sendiri, sama seperti GetTrashPekerjaan.
+func trashFilter(f models.TrashFilter, userID int, role string) bson.M {
+    deletedAt := bson.M{"$ne": nil}
+    if f.DeletedBefore != nil {
+        deletedAt["$lt"] = *f.DeletedBefore
+    }
+
+    filter := bson.M{"deleted_at": deletedAt}
+    if len(f.IDs) > 0 {
+        filter["_id"] = bson.M{"$in": f.IDs}
+    }
+    if f.AlumniID != nil {
+        filter["alumni_id"] = *f.AlumniID
+    }
+    if f.DeletedBy != nil {
+        filter["deleted_by"] = *f.DeletedBy
+    }
+    if role != "admin" {
+        filter["created_by"] = userID
+    }
+    return filter
+}
+
+// matchTrash mengambil ID pekerjaan di trash yang cocok dengan filter
+func matchTrash(ctx context.Context, filter bson.M) ([]primitive.ObjectID, error) {
+    raw, err := database.PekerjaanCollection.Distinct(ctx, "_id", filter)
+    if err != nil {
+        return nil, err
+    }
+    ids := make([]primitive.ObjectID, 0, len(raw))
+    for _, v := range raw {
+        if id, ok := v.(primitive.ObjectID); ok {
+            ids = append(ids, id)
+        }
+    }
+    return ids, nil
+}
+
+func hexIDs(ids []primitive.ObjectID) []string {
+    out := make([]string, len(ids))
+    for i, id := range ids {
+        out[i] = id.Hex()
+    }
+    return out
+}
+
+// RestorePekerjaanBatch mengembalikan semua pekerjaan di trash yang cocok dengan filter
+func (r *TrashMongoRepo) RestorePekerjaanBatch(ctx context.Context, f models.TrashFilter, userID int, role string) ([]string, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    filter := trashFilter(f, userID, role)
+    ids, err := matchTrash(ctx, filter)
+    if err != nil || len(ids) == 0 {
+        return []string{}, err
+    }
+
+    // Filter awal tetap dipakai agar data yang berubah di antaranya tidak ikut ter-restore
+    filter["_id"] = bson.M{"$in": ids}
+    update := bson.M{"$unset": bson.M{"deleted_at": "", "deleted_by": "", "deletion_batch": ""}, "$inc": bson.M{"version": 1}}
+    if _, err := database.PekerjaanCollection.UpdateMany(ctx, filter, update); err != nil {
+        return nil, err
+    }
+    return hexIDs(ids), nil
+}
+
+// PurgePekerjaanBatch menghapus permanen semua pekerjaan di trash yang cocok dengan filter
+func (r *TrashMongoRepo) PurgePekerjaanBatch(ctx context.Context, f models.TrashFilter, userID int, role string) ([]string, error) {
+    ctx, cancel := database.WithTxTimeout(ctx)
+    defer cancel()
+
+    filter := trashFilter(f, userID, role)
+    ids, err := matchTrash(ctx, filter)
+    if err != nil || len(ids) == 0 {
+        return []string{}, err
+    }
+
+    filter["_id"] = bson.M{"$in": ids}
+    if _, err := database.PekerjaanCollection.DeleteMany(ctx, filter); err != nil {
+        return nil, err
+    }
+    return hexIDs(ids), nil
+}
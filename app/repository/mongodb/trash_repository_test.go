@@ -0,0 +1,64 @@
+package repository
+
+import (
+    "testing"
+    "time"
+
+    "go.mongodb.org/mongo-driver/bson"
+
+    models "alumniproject/app/models/mongodb"
+    database "alumniproject/database/mongodb"
+)
+
+func TestPurgeTrashSkipsAlumniWithActivePekerjaan(t *testing.T) {
+    ctx := setupTestDB(t)
+    repo := NewAlumniRepo()
+
+    old := time.Now().AddDate(0, 0, -60)
+    recent := time.Now().Add(-time.Hour)
+    cutoff := time.Now().AddDate(0, 0, -30)
+
+    // busy: masih punya pekerjaan aktif; idle: hanya punya pekerjaan di trash (belum lewat retensi)
+    busy := &models.Alumni{NIM: "111111111", Nama: "Busy", CreatedBy: 1}
+    idle := &models.Alumni{NIM: "222222222", Nama: "Idle", CreatedBy: 1}
+    for _, a := range []*models.Alumni{busy, idle} {
+        if err := repo.CreateAlumni(ctx, a); err != nil {
+            t.Fatalf("CreateAlumni: %v", err)
+        }
+    }
+    _, err := database.AlumniCollection.UpdateMany(ctx, bson.M{}, bson.M{"$set": bson.M{"deleted_at": old}})
+    if err != nil {
+        t.Fatalf("trash alumni: %v", err)
+    }
+    _, err = database.PekerjaanCollection.InsertMany(ctx, []interface{}{
+        models.Pekerjaan{AlumniID: busy.AlumniID, AlumniLinked: true, NamaPerusahaan: "aktif"},
+        models.Pekerjaan{AlumniID: idle.AlumniID, AlumniLinked: true, NamaPerusahaan: "trash", DeletedAt: &recent},
+        // Pekerjaan lama dengan alumni_id sama tidak ikut di-purge karena pemiliknya tidak diketahui
+        models.Pekerjaan{AlumniID: idle.AlumniID, NamaPerusahaan: "lama", DeletedAt: &recent},
+    })
+    if err != nil {
+        t.Fatalf("insert pekerjaan: %v", err)
+    }
+
+    report, err := NewTrashRepo().PurgeTrash(ctx, cutoff, false)
+    if err != nil {
+        t.Fatalf("PurgeTrash: %v", err)
+    }
+    pekerjaan, alumni := report[0], report[1]
+    if alumni.Purged != 1 || alumni.Skipped != 1 {
+        t.Errorf("alumni purged=%d skipped=%d, want 1 dan 1", alumni.Purged, alumni.Skipped)
+    }
+    if pekerjaan.Cascaded != 1 {
+        t.Errorf("pekerjaan cascaded=%d, want 1", pekerjaan.Cascaded)
+    }
+
+    if n, _ := database.AlumniCollection.CountDocuments(ctx, bson.M{"_id": busy.ID}); n != 1 {
+        t.Error("alumni dengan pekerjaan aktif ikut terhapus")
+    }
+    if n, _ := database.PekerjaanCollection.CountDocuments(ctx, bson.M{"alumni_id": idle.AlumniID, "alumni_linked": true}); n != 0 {
+        t.Error("pekerjaan di trash milik alumni yang di-purge masih tersisa")
+    }
+    if n, _ := database.PekerjaanCollection.CountDocuments(ctx, bson.M{"nama_perusahaan": "lama"}); n != 1 {
+        t.Error("pekerjaan lama tanpa alumni_linked ikut di-purge")
+    }
+}
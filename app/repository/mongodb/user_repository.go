@@ -2,11 +2,11 @@ package repository
 
 import (
 	"context"
-	"log"
-	"time"
+	"log/slog"
 
 	"alumniproject/app/models/mongodb"
 	db "alumniproject/database/mongodb"
+	"alumniproject/utils/filter"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -14,8 +14,8 @@ import (
 )
 
 // Ambil user berdasarkan username atau email
-func GetUserByUsernameOrEmail(usernameOrEmail string) (models.User, string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetUserByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (models.User, string, error) {
+	ctx, cancel := db.WithQueryTimeout(ctx)
 	defer cancel()
 
 	var user models.User
@@ -32,7 +32,7 @@ func GetUserByUsernameOrEmail(usernameOrEmail string) (models.User, string, erro
 		if err == mongo.ErrNoDocuments {
 			return models.User{}, "", err
 		}
-		log.Println("FindOne error:", err)
+		slog.ErrorContext(ctx, "find user gagal", "error", err)
 		return models.User{}, "", err
 	}
 
@@ -40,8 +40,8 @@ func GetUserByUsernameOrEmail(usernameOrEmail string) (models.User, string, erro
 }
 
 // Ambil daftar user dengan fitur search, sort, pagination
-func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.User, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetUsersRepo(ctx context.Context, search, sortBy, order string, limit, offset int, f filter.Filter) ([]models.User, error) {
+	ctx, cancel := db.WithQueryTimeout(ctx)
 	defer cancel()
 
 	filter := bson.M{}
@@ -52,6 +52,8 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.Use
 		}
 	}
 
+	filter = f.Apply(filter)
+
 	sortOrder := 1
 	if order == "desc" {
 		sortOrder = -1
@@ -60,18 +62,19 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.Use
 	opts := options.Find().
 		SetSort(bson.M{sortBy: sortOrder}).
 		SetLimit(int64(limit)).
-		SetSkip(int64(offset))
+		SetSkip(int64(offset)).
+		SetProjection(bson.M{"password": 0})
 
 	cursor, err := db.DB.Collection("users").Find(ctx, filter, opts)
 	if err != nil {
-		log.Println("Find error:", err)
+		slog.ErrorContext(ctx, "find users gagal", "error", err)
 		return nil, err
 	}
 	defer cursor.Close(ctx)
 
 	var users []models.User
 	if err := cursor.All(ctx, &users); err != nil {
-		log.Println("Cursor error:", err)
+		slog.ErrorContext(ctx, "decode users gagal", "error", err)
 		return nil, err
 	}
 
@@ -79,8 +82,8 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.Use
 }
 
 // Hitung total user untuk pagination
-func CountUsersRepo(search string) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func CountUsersRepo(ctx context.Context, search string, f filter.Filter) (int, error) {
+	ctx, cancel := db.WithQueryTimeout(ctx)
 	defer cancel()
 
 	filter := bson.M{}
@@ -91,9 +94,11 @@ func CountUsersRepo(search string) (int, error) {
 		}
 	}
 
+	filter = f.Apply(filter)
+
 	count, err := db.DB.Collection("users").CountDocuments(ctx, filter)
 	if err != nil {
-		log.Println("CountDocuments error:", err)
+		slog.ErrorContext(ctx, "count users gagal", "error", err)
 		return 0, err
 	}
 
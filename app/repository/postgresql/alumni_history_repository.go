@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/database/postgresql"
+)
+
+const alumniHistoryColumns = `
+	alumni_id, version, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
+	valid_from, valid_to, changed_by`
+
+func scanAlumniVersion(row interface{ Scan(...interface{}) error }) (models.AlumniVersion, error) {
+	var v models.AlumniVersion
+	var validTo time.Time
+	var changedBy sql.NullInt64
+	err := row.Scan(
+		&v.AlumniID, &v.Version, &v.NIM, &v.Nama, &v.Jurusan, &v.Angkatan, &v.TahunLulus,
+		&v.Email, &v.NoTelepon, &v.Alamat, &v.ValidFrom, &validTo, &changedBy,
+	)
+	if err != nil {
+		return v, err
+	}
+	v.ValidTo = &validTo
+	if changedBy.Valid {
+		id := int(changedBy.Int64)
+		v.ChangedBy = &id
+	}
+	return v, nil
+}
+
+// GetAlumniHistory -> semua snapshot alumni, versi terbaru di depan (tanpa versi saat ini)
+func GetAlumniHistory(ctx context.Context, alumniID int) ([]models.AlumniVersion, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := postgresql.DB.QueryContext(ctx, `
+		SELECT `+alumniHistoryColumns+`
+		FROM alumni_history
+		WHERE alumni_id = $1
+		ORDER BY version DESC`, alumniID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []models.AlumniVersion{}
+	for rows.Next() {
+		v, err := scanAlumniVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, v)
+	}
+	return list, rows.Err()
+}
+
+// GetAlumniVersion -> snapshot alumni pada versi tertentu, nil jika tidak ada
+func GetAlumniVersion(ctx context.Context, alumniID, version int) (*models.AlumniVersion, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	row := postgresql.DB.QueryRowContext(ctx, `
+		SELECT `+alumniHistoryColumns+`
+		FROM alumni_history
+		WHERE alumni_id = $1 AND version = $2`, alumniID, version)
+	v, err := scanAlumniVersion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// GetAlumniAsOf -> snapshot alumni yang berlaku pada waktu asOf, nil jika tidak ada.
+// Versi saat ini tidak ada di alumni_history, jadi dicek terpisah oleh pemanggil.
+func GetAlumniAsOf(ctx context.Context, alumniID int, asOf time.Time) (*models.AlumniVersion, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	row := postgresql.DB.QueryRowContext(ctx, `
+		SELECT `+alumniHistoryColumns+`
+		FROM alumni_history
+		WHERE alumni_id = $1 AND valid_from <= $2 AND valid_to > $2
+		ORDER BY version DESC
+		LIMIT 1`, alumniID, asOf)
+	v, err := scanAlumniVersion(row)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
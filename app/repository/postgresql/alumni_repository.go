@@ -3,33 +3,38 @@ package repository
 import (
 	"context"
 	"time"
-	"log"
+	"log/slog"
 	"fmt"
 	"database/sql"
 	
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
 	"alumniproject/database/postgresql"
 	"alumniproject/app/models/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/filter"
 )
 
 
-func GetAllAlumni(role string, userID int) ([]models.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetAllAlumni -> semua alumni sesuai ?filter=, non-admin hanya data miliknya
+func GetAllAlumni(ctx context.Context, role string, userID int, f filter.Filter) ([]models.Alumni, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
-	baseQuery := `
-		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, created_by
+	where, args := f.SQL(1)
+	query := `
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, created_by, version
 		FROM alumni
-	`
+		WHERE deleted_at IS NULL AND ` + where
 
-	var rows *sql.Rows
-	var err error
-
-	if role == "admin" {
-		rows, err = postgresql.DB.QueryContext(ctx, baseQuery + " ORDER BY created_at DESC")
-	} else {
-		rows, err = postgresql.DB.QueryContext(ctx, baseQuery + " WHERE created_by = $1 ORDER BY created_at DESC", userID)
+	if role != "admin" {
+		args = append(args, userID)
+		query += fmt.Sprintf(" AND created_by = $%d", len(args))
 	}
 
+	rows, err := postgresql.DB.QueryContext(ctx, query+" ORDER BY created_at DESC", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -40,7 +45,7 @@ func GetAllAlumni(role string, userID int) ([]models.Alumni, error) {
 		var a models.Alumni
 		err := rows.Scan(
 			&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus,
-			&a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.CreatedBy,
+			&a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.CreatedBy, &a.Version,
 		)
 		if err != nil {
 			return nil, err
@@ -51,14 +56,14 @@ func GetAllAlumni(role string, userID int) ([]models.Alumni, error) {
 }
 
 
-func GetAlumniByID(id int) (models.Alumni, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetAlumniByID(ctx context.Context, id int) (models.Alumni, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
 	var a models.Alumni
 	query := `
 		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, 
-		       created_at, updated_at, created_by, deleted_at
+		       created_at, updated_at, created_by, deleted_at, version
 		FROM alumni
 		WHERE id = $1
 	`
@@ -77,6 +82,7 @@ func GetAlumniByID(id int) (models.Alumni, error) {
 		&a.UpdatedAt,
 		&a.CreatedBy,
 		&a.DeletedAt,
+		&a.Version,
 	)
 
 	if err != nil {
@@ -91,88 +97,209 @@ func GetAlumniByID(id int) (models.Alumni, error) {
 
 
 // CreateAlumni sama saja, tambahkan created_by
-func CreateAlumni(a *models.Alumni) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func CreateAlumni(ctx context.Context, a *models.Alumni) error {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 	return postgresql.DB.QueryRowContext(ctx, `
 		INSERT INTO alumni (
 			nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_by, created_at, updated_at
-		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id
-	`, a.NIM, a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.CreatedBy, time.Now(), time.Now()).Scan(&a.ID)
+		) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING id, version
+	`, a.NIM, a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.CreatedBy, time.Now(), time.Now()).Scan(&a.ID, &a.Version)
 }
 
-// UpdateAlumni dengan role-based
-func UpdateAlumni(a *models.Alumni, userID int, role string) error {
-    var query string
-    var args []interface{}
-
-    if role == "admin" {
-        query = `
-            UPDATE alumni 
-            SET nama=$1, jurusan=$2, angkatan=$3, tahun_lulus=$4, email=$5, no_telepon=$6, alamat=$7, updated_at=$8
-            WHERE id=$9`
-        args = []interface{}{a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.UpdatedAt, a.ID}
-    } else {
+// ErrVersionConflict -> versi di If-Match tidak sama dengan versi di database
+var ErrVersionConflict = apperror.PreconditionFailed("version_conflict").WithCode(apperror.CodeVersionConflict)
+
+// UpdateAlumni dengan role-based.
+// ifMatch > 0 -> update hanya jika versi di database masih sama (optimistic locking).
+// Data lama disimpan ke alumni_history dalam transaksi yang sama.
+func UpdateAlumni(ctx context.Context, a *models.Alumni, userID int, role string, ifMatch int) error {
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
+    defer cancel()
+
+    tx, err := postgresql.DB.BeginTx(ctx, nil)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback()
+
+    query := `
+        SELECT id, version, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, updated_at
+        FROM alumni
+        WHERE id=$1 AND deleted_at IS NULL`
+    args := []interface{}{a.ID}
+
+    if role != "admin" {
         // hanya boleh update data miliknya sendiri
-        query = `
-            UPDATE alumni 
-            SET nama=$1, jurusan=$2, angkatan=$3, tahun_lulus=$4, email=$5, no_telepon=$6, alamat=$7, updated_at=$8
-            WHERE id=$9 AND created_by=$10`
-        args = []interface{}{a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.UpdatedAt, a.ID, userID}
+        args = append(args, userID)
+        query += fmt.Sprintf(" AND created_by=$%d", len(args))
+    }
+    if ifMatch > 0 {
+        args = append(args, ifMatch)
+        query += fmt.Sprintf(" AND version=$%d", len(args))
     }
 
-    _, err := postgresql.DB.Exec(query, args...)
-    return err
+    var old models.AlumniVersion
+    err = tx.QueryRowContext(ctx, query+" FOR UPDATE", args...).Scan(
+        &old.AlumniID, &old.Version, &old.NIM, &old.Nama, &old.Jurusan, &old.Angkatan, &old.TahunLulus,
+        &old.Email, &old.NoTelepon, &old.Alamat, &old.ValidFrom,
+    )
+    if err == sql.ErrNoRows {
+        if ifMatch > 0 {
+            return ErrVersionConflict
+        }
+        return fmt.Errorf("data alumni tidak ditemukan")
+    }
+    if err != nil {
+        return err
+    }
+
+    _, err = tx.ExecContext(ctx, `
+        INSERT INTO alumni_history (
+            alumni_id, version, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
+            valid_from, valid_to, changed_by
+        ) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+        ON CONFLICT (alumni_id, version) DO NOTHING`,
+        old.AlumniID, old.Version, old.NIM, old.Nama, old.Jurusan, old.Angkatan, old.TahunLulus,
+        old.Email, old.NoTelepon, old.Alamat, old.ValidFrom, a.UpdatedAt, userID,
+    )
+    if err != nil {
+        return err
+    }
+
+    err = tx.QueryRowContext(ctx, `
+        UPDATE alumni
+        SET nama=$1, jurusan=$2, angkatan=$3, tahun_lulus=$4, email=$5, no_telepon=$6, alamat=$7, updated_at=$8, version = version + 1
+        WHERE id=$9
+        RETURNING version`,
+        a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.UpdatedAt, a.ID,
+    ).Scan(&a.Version)
+    if err != nil {
+        return err
+    }
+
+    return tx.Commit()
 }
 
 
-// DeleteAlumni (soft delete)
-func DeleteAlumni(id int, userID int, role string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// DeleteAlumni (soft delete). Pekerjaan aktif milik alumni ikut di-soft delete
+// dengan deletion_batch yang sama, sehingga RestoreAlumni bisa mengembalikan persis batch tersebut.
+func DeleteAlumni(ctx context.Context, id int, userID int, role string, ifMatch int) (*models.DeletionBatch, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
 	defer cancel()
 
-	var res sql.Result
-	var err error
-
-	if role == "admin" {
-		res, err = postgresql.DB.ExecContext(ctx, `
-			UPDATE alumni SET deleted_at=$1 WHERE id=$2
-		`, time.Now(), id)
-	} else {
-		res, err = postgresql.DB.ExecContext(ctx, `
-			UPDATE alumni SET deleted_at=$1 WHERE id=$2 AND created_by=$3
-		`, time.Now(), id, userID)
+	tx, err := postgresql.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	batch := &models.DeletionBatch{ID: uuid.NewString()}
+
+	query := `UPDATE alumni SET deleted_at=$1, deletion_batch=$2, version = version + 1 WHERE id=$3 AND deleted_at IS NULL`
+	args := []interface{}{now, batch.ID, id}
+
+	if role != "admin" {
+		args = append(args, userID)
+		query += fmt.Sprintf(" AND created_by=$%d", len(args))
+	}
+	if ifMatch > 0 {
+		args = append(args, ifMatch)
+		query += fmt.Sprintf(" AND version=$%d", len(args))
 	}
 
+	res, err := tx.ExecContext(ctx, query, args...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	rows, _ := res.RowsAffected()
 	if rows == 0 {
-		return fmt.Errorf("tidak boleh hapus data ini")
+		if ifMatch > 0 {
+			return nil, ErrVersionConflict
+		}
+		return nil, fmt.Errorf("tidak boleh hapus data ini")
+	}
+
+	// Pekerjaan yang sudah di trash sebelumnya tidak disentuh, jadi tidak ikut ter-restore nanti
+	res, err = tx.ExecContext(ctx, `
+		UPDATE pekerjaan_alumni
+		SET deleted_at = $1, deleted_by = $2, deletion_batch = $3, version = version + 1
+		WHERE alumni_id = $4 AND deleted_at IS NULL
+	`, now, userID, batch.ID, id)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	if batch.Pekerjaan, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return batch, nil
 }
 
-func RestoreAlumni(id int) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ErrAlumniTidakDiTrash -> alumni tidak ada di trash atau bukan milik user ini
+var ErrAlumniTidakDiTrash = apperror.NotFound("alumni.not_in_trash")
+
+// RestoreAlumni mengembalikan alumni beserta pekerjaan dari batch penghapusan yang sama.
+// Non-admin hanya bisa restore alumni miliknya sendiri, sama seperti DeleteAlumni.
+func RestoreAlumni(ctx context.Context, id int, userID int, role string) (*models.DeletionBatch, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
 	defer cancel()
 
-	res, err := postgresql.DB.ExecContext(ctx, `
+	tx, err := postgresql.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `SELECT deletion_batch FROM alumni WHERE id = $1 AND deleted_at IS NOT NULL`
+	args := []interface{}{id}
+	if role != "admin" {
+		args = append(args, userID)
+		query += fmt.Sprintf(" AND created_by = $%d", len(args))
+	}
+
+	var batchID sql.NullString
+	err = tx.QueryRowContext(ctx, query+" FOR UPDATE", args...).Scan(&batchID)
+	if err == sql.ErrNoRows {
+		return nil, ErrAlumniTidakDiTrash
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
 		UPDATE alumni 
-		SET deleted_at = NULL 
+		SET deleted_at = NULL, deletion_batch = NULL, version = version + 1
 		WHERE id = $1
 	`, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	rows, _ := res.RowsAffected()
-	if rows == 0 {
-		return fmt.Errorf("data tidak ditemukan atau belum dihapus")
+	batch := &models.DeletionBatch{ID: batchID.String}
+	if batchID.Valid {
+		res, err := tx.ExecContext(ctx, `
+			UPDATE pekerjaan_alumni
+			SET deleted_at = NULL, deleted_by = NULL, deletion_batch = NULL, version = version + 1
+			WHERE deletion_batch = $1
+		`, batchID.String)
+		if err != nil {
+			return nil, err
+		}
+		if batch.Pekerjaan, err = res.RowsAffected(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
-	return nil
+	return batch, nil
 }
 
 
@@ -269,18 +396,50 @@ func RestoreAlumni(id int) error {
 
 
 // GetAlumniPaginated -> ambil data alumni dengan search, sort, paginate (adapt dari GetUsersRepo)
-func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.Alumni, error) {
+func GetAlumniRepo(ctx context.Context, search, sortBy, order string, limit, offset int, f filter.Filter) ([]models.Alumni, error) {
+    where, args := f.SQL(4)
     query := fmt.Sprintf(`
-        SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at
+        SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version
         FROM alumni
-        WHERE nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1
+        WHERE deleted_at IS NULL AND (nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1) AND %s
         ORDER BY %s %s
         LIMIT $2 OFFSET $3
-    `, sortBy, order)
+    `, where, sortBy, order)
+
+    return queryAlumniList(ctx, query, append([]interface{}{"%" + search + "%", limit, offset}, args...))
+}
 
-    rows, err := postgresql.DB.Query(query, "%"+search+"%", limit, offset)
+// GetAlumniKeyset -> seperti GetAlumniRepo tapi keyset pagination: baris setelah (atau sebelum) cur,
+// diurutkan berdasarkan sortBy lalu id. cur nil -> halaman pertama.
+func GetAlumniKeyset(ctx context.Context, search, sortBy, order string, limit int, cur *cursor.Cursor, f filter.Filter) ([]models.Alumni, error) {
+    where, args := f.SQL(3)
+    args = append([]interface{}{"%" + search + "%", limit}, args...)
+
+    desc := cursor.Descending(order, cur)
+    dir, op := "ASC", ">"
+    if desc {
+        dir, op = "DESC", "<"
+    }
+    if cur != nil {
+        where += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", sortBy, op, len(args)+1, len(args)+2)
+        args = append(args, cur.Value, cur.ID)
+    }
+
+    query := fmt.Sprintf(`
+        SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, version
+        FROM alumni
+        WHERE deleted_at IS NULL AND (nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1) AND %s
+        ORDER BY %s %s, id %s
+        LIMIT $2
+    `, where, sortBy, dir, dir)
+
+    return queryAlumniList(ctx, query, args)
+}
+
+func queryAlumniList(ctx context.Context, query string, args []interface{}) ([]models.Alumni, error) {
+    rows, err := postgresql.DB.QueryContext(ctx, query, args...)
     if err != nil {
-        log.Println("Query error:", err)
+        slog.ErrorContext(ctx, "query alumni gagal", "error", err)
         return nil, err
     }
     defer rows.Close()
@@ -288,7 +447,7 @@ func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.Al
     var alumni []models.Alumni
     for rows.Next() {
         var a models.Alumni
-        if err := rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt); err != nil {
+        if err := rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.Version); err != nil {
             return nil, err
         }
         alumni = append(alumni, a)
@@ -296,12 +455,46 @@ func GetAlumniRepo(search, sortBy, order string, limit, offset int) ([]models.Al
     return alumni, nil
 }
 
-func CountAlumniRepo(search string) (int, error) {
+func CountAlumniRepo(ctx context.Context, search string, f filter.Filter) (int, error) {
     var total int
-    query := `SELECT COUNT(*) FROM alumni WHERE nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1`
-    err := postgresql.DB.QueryRow(query, "%"+search+"%").Scan(&total)
+    where, args := f.SQL(2)
+    query := `SELECT COUNT(*) FROM alumni WHERE deleted_at IS NULL AND (nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1) AND ` + where
+    err := postgresql.DB.QueryRowContext(ctx, query, append([]interface{}{"%" + search + "%"}, args...)...).Scan(&total)
     if err != nil && err != sql.ErrNoRows {
         return 0, err
     }
     return total, nil
-}
\ No newline at end of file
+}
+// GetAlumniByNIMs -> alumni (termasuk yang sudah dihapus) dengan NIM di daftar, dikelompokkan per NIM
+func GetAlumniByNIMs(ctx context.Context, nims []string) (map[string]models.Alumni, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	result := make(map[string]models.Alumni)
+	if len(nims) == 0 {
+		return result, nil
+	}
+
+	rows, err := postgresql.DB.QueryContext(ctx, `
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat,
+		       created_at, updated_at, created_by, deleted_at, version
+		FROM alumni
+		WHERE nim = ANY($1)`, pq.Array(nims))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var a models.Alumni
+		err := rows.Scan(
+			&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email, &a.NoTelepon, &a.Alamat,
+			&a.CreatedAt, &a.UpdatedAt, &a.CreatedBy, &a.DeletedAt, &a.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result[a.NIM] = a
+	}
+	return result, rows.Err()
+}
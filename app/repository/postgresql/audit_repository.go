@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/database/postgresql"
+)
+
+// InsertAudit menyimpan satu catatan audit log
+func InsertAudit(ctx context.Context, e *models.AuditLog) error {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	diff, err := json.Marshal(e.Diff)
+	if err != nil {
+		return err
+	}
+
+	return postgresql.DB.QueryRowContext(ctx, `
+		INSERT INTO audit_log (actor_id, action, entity, entity_id, ip, diff, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`, e.ActorID, e.Action, e.Entity, e.EntityID, e.IP, diff, e.CreatedAt).Scan(&e.ID)
+}
+
+func auditWhere(f models.AuditFilter) (string, []interface{}) {
+	var conds []string
+	var args []interface{}
+
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if f.ActorID != nil {
+		add("actor_id = $%d", *f.ActorID)
+	}
+	if f.Action != "" {
+		add("action = $%d", f.Action)
+	}
+	if f.Entity != "" {
+		add("entity = $%d", f.Entity)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if f.From != nil {
+		add("created_at >= $%d", *f.From)
+	}
+	if f.To != nil {
+		add("created_at < $%d", *f.To)
+	}
+
+	if len(conds) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conds, " AND "), args
+}
+
+// GetAuditLogs -> audit log terbaru lebih dulu, dengan filter dan pagination
+func GetAuditLogs(ctx context.Context, f models.AuditFilter, limit, offset int) ([]models.AuditLog, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	where, args := auditWhere(f)
+	args = append(args, limit, offset)
+	query := `
+		SELECT id, actor_id, action, entity, entity_id, COALESCE(ip, ''), diff, created_at
+		FROM audit_log` + where +
+		fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := postgresql.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	logs := []models.AuditLog{}
+	for rows.Next() {
+		var e models.AuditLog
+		var diff []byte
+		if err := rows.Scan(&e.ID, &e.ActorID, &e.Action, &e.Entity, &e.EntityID, &e.IP, &diff, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(diff, &e.Diff); err != nil {
+			return nil, err
+		}
+		logs = append(logs, e)
+	}
+	return logs, rows.Err()
+}
+
+// CountAuditLogs -> total audit log yang cocok dengan filter
+func CountAuditLogs(ctx context.Context, f models.AuditFilter) (int, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	where, args := auditWhere(f)
+	var total int
+	err := postgresql.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM audit_log"+where, args...).Scan(&total)
+	return total, err
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/database/postgresql"
+)
+
+// exportTimeout -> batas waktu satu query export (lebih panjang dari query biasa)
+const exportTimeout = 10 * time.Minute
+
+// Cursor membaca hasil query satu baris per panggilan Next, tanpa memuat semua data ke memori
+type Cursor[T any] struct {
+	rows   *sql.Rows
+	cancel context.CancelFunc
+	scan   func(*sql.Rows, *T) error
+}
+
+// Next mengisi dst dengan baris berikutnya, false jika data sudah habis
+func (c *Cursor[T]) Next(dst *T) (bool, error) {
+	if !c.rows.Next() {
+		return false, c.rows.Err()
+	}
+	return true, c.scan(c.rows, dst)
+}
+
+// Close menutup cursor dan membatalkan context query
+func (c *Cursor[T]) Close() error {
+	defer c.cancel()
+	return c.rows.Close()
+}
+
+func openCursor[T any](ctx context.Context, query string, args []interface{}, scan func(*sql.Rows, *T) error) (*Cursor[T], error) {
+	ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+	rows, err := postgresql.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &Cursor[T]{rows: rows, cancel: cancel, scan: scan}, nil
+}
+
+// alumniExportWhere -> filter export alumni: data aktif sesuai search & ?filter=, non-admin hanya data miliknya
+func alumniExportWhere(f models.ExportFilter, role string, userID int) (string, []interface{}) {
+	where := ` WHERE deleted_at IS NULL AND (nama ILIKE $1 OR nim ILIKE $1 OR jurusan ILIKE $1)`
+	args := []interface{}{"%" + f.Search + "%"}
+	if role != "admin" {
+		args = append(args, userID)
+		where += fmt.Sprintf(" AND created_by = $%d", len(args))
+	}
+	cond, condArgs := f.Filter.SQL(len(args) + 1)
+	return where + " AND " + cond, append(args, condArgs...)
+}
+
+// StreamAlumni -> alumni aktif sesuai search & sort, non-admin hanya data miliknya
+func StreamAlumni(ctx context.Context, f models.ExportFilter, role string, userID int) (*Cursor[models.Alumni], error) {
+	where, args := alumniExportWhere(f, role, userID)
+	query := `
+		SELECT id, nim, nama, jurusan, angkatan, tahun_lulus, email, no_telepon, alamat, created_at, updated_at, created_by, version
+		FROM alumni` + where + fmt.Sprintf(" ORDER BY %s %s, id", f.SortBy, f.Order)
+
+	return openCursor(ctx, query, args, func(rows *sql.Rows, a *models.Alumni) error {
+		return rows.Scan(&a.ID, &a.NIM, &a.Nama, &a.Jurusan, &a.Angkatan, &a.TahunLulus, &a.Email,
+			&a.NoTelepon, &a.Alamat, &a.CreatedAt, &a.UpdatedAt, &a.CreatedBy, &a.Version)
+	})
+}
+
+// CountAlumniExport -> jumlah baris yang akan ditulis StreamAlumni (untuk progres job)
+func CountAlumniExport(ctx context.Context, f models.ExportFilter, role string, userID int) (int, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	where, args := alumniExportWhere(f, role, userID)
+	var total int
+	err := postgresql.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM alumni`+where, args...).Scan(&total)
+	return total, err
+}
+
+// pekerjaanExportWhere -> filter export pekerjaan: data aktif sesuai search & ?filter=, non-admin hanya data miliknya
+func pekerjaanExportWhere(f models.ExportFilter, role string, userID int) (string, []interface{}) {
+	where := ` WHERE deleted_at IS NULL AND (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1)`
+	args := []interface{}{"%" + f.Search + "%"}
+	if role != "admin" {
+		args = append(args, userID)
+		where += fmt.Sprintf(" AND created_by = $%d", len(args))
+	}
+	cond, condArgs := f.Filter.SQL(len(args) + 1)
+	return where + " AND " + cond, append(args, condArgs...)
+}
+
+// StreamPekerjaan -> pekerjaan aktif sesuai search & sort, non-admin hanya data miliknya
+func StreamPekerjaan(ctx context.Context, f models.ExportFilter, role string, userID int) (*Cursor[models.Pekerjaan], error) {
+	where, args := pekerjaanExportWhere(f, role, userID)
+	query := `
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
+		       created_at, updated_at, created_by, version
+		FROM pekerjaan_alumni` + where + fmt.Sprintf(" ORDER BY %s %s, id", f.SortBy, f.Order)
+
+	return openCursor(ctx, query, args, func(rows *sql.Rows, p *models.Pekerjaan) error {
+		var tanggalSelesai sql.NullTime
+		err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri,
+			&p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &tanggalSelesai, &p.StatusPekerjaan,
+			&p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.CreatedBy, &p.Version)
+		if err != nil {
+			return err
+		}
+		p.TanggalSelesaiKerja = nil
+		if tanggalSelesai.Valid {
+			p.TanggalSelesaiKerja = &tanggalSelesai.Time
+		}
+		return nil
+	})
+}
+
+// CountPekerjaanExport -> jumlah baris yang akan ditulis StreamPekerjaan (untuk progres job)
+func CountPekerjaanExport(ctx context.Context, f models.ExportFilter, role string, userID int) (int, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	where, args := pekerjaanExportWhere(f, role, userID)
+	var total int
+	err := postgresql.DB.QueryRowContext(ctx, `SELECT COUNT(*) FROM pekerjaan_alumni`+where, args...).Scan(&total)
+	return total, err
+}
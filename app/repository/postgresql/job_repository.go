@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+
+	"alumniproject/database/postgresql"
+	"alumniproject/utils/jobs"
+)
+
+// JobStore -> penyimpanan antrian job di tabel jobs (implementasi jobs.Store)
+type JobStore struct{}
+
+const jobColumns = `
+	id, type, status, payload, attempts, max_attempts, progress, message, error,
+	result_path, result_name, result_type, created_by, run_after, created_at, updated_at, started_at, finished_at`
+
+func scanJob(row interface{ Scan(...interface{}) error }) (*jobs.Job, error) {
+	var j jobs.Job
+	var payload []byte
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(
+		&j.ID, &j.Type, &j.Status, &payload, &j.Attempts, &j.MaxAttempts, &j.Progress, &j.Message, &j.Error,
+		&j.ResultPath, &j.ResultName, &j.ResultType, &j.CreatedBy, &j.RunAfter, &j.CreatedAt, &j.UpdatedAt,
+		&startedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	j.Payload = payload
+	if startedAt.Valid {
+		j.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		j.FinishedAt = &finishedAt.Time
+	}
+	return &j, nil
+}
+
+func (JobStore) Create(ctx context.Context, j *jobs.Job) error {
+	_, err := postgresql.DB.ExecContext(ctx, `
+		INSERT INTO jobs (id, type, status, payload, max_attempts, created_by, run_after, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		j.ID, j.Type, j.Status, []byte(j.Payload), j.MaxAttempts, j.CreatedBy, j.RunAfter, j.CreatedAt, j.UpdatedAt,
+	)
+	return err
+}
+
+func (JobStore) Get(ctx context.Context, id string) (*jobs.Job, error) {
+	j, err := scanJob(postgresql.DB.QueryRowContext(ctx, `SELECT `+jobColumns+` FROM jobs WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return j, err
+}
+
+// ClaimNext memakai FOR UPDATE SKIP LOCKED agar satu job tidak diambil dua worker
+func (JobStore) ClaimNext(ctx context.Context, types []string, now time.Time) (*jobs.Job, error) {
+	if len(types) == 0 {
+		return nil, nil
+	}
+	j, err := scanJob(postgresql.DB.QueryRowContext(ctx, `
+		UPDATE jobs
+		SET status = $1, attempts = attempts + 1, started_at = $2, updated_at = $2, error = ''
+		WHERE id = (
+			SELECT id FROM jobs
+			WHERE status = $3 AND run_after <= $2 AND type = ANY($4)
+			ORDER BY created_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+jobColumns,
+		jobs.StatusRunning, now, jobs.StatusQueued, pq.Array(types),
+	))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return j, err
+}
+
+func (JobStore) UpdateProgress(ctx context.Context, id string, progress int, message string) error {
+	_, err := postgresql.DB.ExecContext(ctx,
+		`UPDATE jobs SET progress = $1, message = $2, updated_at = NOW() WHERE id = $3`,
+		progress, message, id,
+	)
+	return err
+}
+
+func (JobStore) Finish(ctx context.Context, j *jobs.Job) error {
+	_, err := postgresql.DB.ExecContext(ctx, `
+		UPDATE jobs
+		SET status = $1, progress = $2, message = $3, error = $4, result_path = $5, result_name = $6,
+		    result_type = $7, run_after = $8, updated_at = $9, finished_at = $10
+		WHERE id = $11`,
+		j.Status, j.Progress, j.Message, j.Error, j.ResultPath, j.ResultName,
+		j.ResultType, j.RunAfter, j.UpdatedAt, j.FinishedAt, j.ID,
+	)
+	return err
+}
+
+func (JobStore) Recover(ctx context.Context) (int64, error) {
+	res, err := postgresql.DB.ExecContext(ctx,
+		`UPDATE jobs SET status = $1, run_after = NOW(), updated_at = NOW() WHERE status = $2`,
+		jobs.StatusQueued, jobs.StatusRunning,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -5,33 +5,36 @@ import (
 	"time"
 	"database/sql"
 	"fmt"
-	"log"
+	"log/slog"
 
 	"alumniproject/database/postgresql"
 	"alumniproject/app/models/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/bulk"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/filter"
 )
 
-func GetAllPekerjaan(role string, userID int) ([]models.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetAllPekerjaan -> semua pekerjaan aktif sesuai ?filter=, non-admin hanya data miliknya
+func GetAllPekerjaan(ctx context.Context, role string, userID int, f filter.Filter) ([]models.Pekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
-	baseQuery := `
+	where, args := f.SQL(1)
+	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
 		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
-		       created_by, created_at, updated_at
+		       created_by, created_at, updated_at, version
 		FROM pekerjaan_alumni
-	`
-
-	var rows *sql.Rows
-	var err error
+		WHERE deleted_at IS NULL AND ` + where
 
-	if role == "admin" {
-		rows, err = postgresql.DB.QueryContext(ctx, baseQuery+" ORDER BY created_at DESC")
-	} else {
+	if role != "admin" {
 		// Filter berdasarkan JWT userID
-		rows, err = postgresql.DB.QueryContext(ctx, baseQuery+" WHERE created_by = $1 ORDER BY created_at DESC", userID)
+		args = append(args, userID)
+		query += fmt.Sprintf(" AND created_by = $%d", len(args))
 	}
 
+	rows, err := postgresql.DB.QueryContext(ctx, query+" ORDER BY created_at DESC", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +46,7 @@ func GetAllPekerjaan(role string, userID int) ([]models.Pekerjaan, error) {
 		if err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan,
 			&p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja,
 			&p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan,
-			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt); err != nil {
+			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.Version); err != nil {
 			return nil, err
 		}
 		list = append(list, p)
@@ -53,23 +56,30 @@ func GetAllPekerjaan(role string, userID int) ([]models.Pekerjaan, error) {
 }
 
 
-func GetPekerjaanByID(id int) (models.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ErrPekerjaanTidakDitemukan -> pekerjaan tidak ada atau sudah di trash
+var ErrPekerjaanTidakDitemukan = apperror.NotFound("pekerjaan.not_found")
+
+// GetPekerjaanByID -> pekerjaan aktif, yang sudah di trash dianggap tidak ada
+func GetPekerjaanByID(ctx context.Context, id int) (models.Pekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 	var p models.Pekerjaan
 	err := postgresql.DB.QueryRowContext(ctx, `
-		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at
-		FROM pekerjaan_alumni WHERE id = $1
-	`, id).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt)
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_by, created_at, updated_at, version
+		FROM pekerjaan_alumni WHERE id = $1 AND deleted_at IS NULL
+	`, id).Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan, &p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.Version)
+	if err == sql.ErrNoRows {
+		return p, ErrPekerjaanTidakDitemukan
+	}
 	return p, err
 }
 
-func GetPekerjaanByAlumniID(alumniID int) ([]models.Pekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetPekerjaanByAlumniID(ctx context.Context, alumniID int) ([]models.Pekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 	rows, err := postgresql.DB.QueryContext(ctx, `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at
-		FROM pekerjaan_alumni WHERE alumni_id = $1 ORDER BY created_at DESC
+		FROM pekerjaan_alumni WHERE alumni_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC
 	`, alumniID)
 	if err != nil {
 		return nil, err
@@ -87,8 +97,8 @@ func GetPekerjaanByAlumniID(alumniID int) ([]models.Pekerjaan, error) {
 	return list, nil
 }
 
-func CreatePekerjaan(p *models.Pekerjaan) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func CreatePekerjaan(ctx context.Context, p *models.Pekerjaan) error {
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
     defer cancel()
 
     err := postgresql.DB.QueryRowContext(ctx, `
@@ -97,7 +107,7 @@ func CreatePekerjaan(p *models.Pekerjaan) error {
             gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
             deskripsi_pekerjaan, created_by, created_at, updated_at
         ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
-        RETURNING id
+        RETURNING id, version
     `,
         p.AlumniID,
         p.NamaPerusahaan,
@@ -112,19 +122,33 @@ func CreatePekerjaan(p *models.Pekerjaan) error {
         p.CreatedBy,   // ⬅️ penting
         time.Now(),
         time.Now(),
-    ).Scan(&p.ID)
+    ).Scan(&p.ID, &p.Version)
 
     return err
 }
 
 
-func UpdatePekerjaan(p *models.Pekerjaan) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// UpdatePekerjaan -> ifMatch > 0 berarti update hanya jika versi di database masih sama (optimistic locking)
+func UpdatePekerjaan(ctx context.Context, p *models.Pekerjaan, ifMatch int) error {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
-	_, err := postgresql.DB.ExecContext(ctx, `
-		UPDATE pekerjaan_alumni SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3, lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6, tanggal_selesai_kerja = $7, status_pekerjaan = $8, deskripsi_pekerjaan = $9, updated_at = $10
-		WHERE id = $11
-	`, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, time.Now(), p.ID)
+
+	query := `
+		UPDATE pekerjaan_alumni SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3, lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6, tanggal_selesai_kerja = $7, status_pekerjaan = $8, deskripsi_pekerjaan = $9, updated_at = $10, version = version + 1
+		WHERE id = $11 AND deleted_at IS NULL`
+	args := []interface{}{p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, time.Now(), p.ID}
+	if ifMatch > 0 {
+		args = append(args, ifMatch)
+		query += " AND version = $12"
+	}
+
+	err := postgresql.DB.QueryRowContext(ctx, query+" RETURNING version", args...).Scan(&p.Version)
+	if err == sql.ErrNoRows {
+		if ifMatch > 0 {
+			return ErrVersionConflict
+		}
+		return ErrPekerjaanTidakDitemukan
+	}
 	return err
 }
 
@@ -222,13 +246,13 @@ func UpdatePekerjaan(p *models.Pekerjaan) error {
 // }
 
 
-func GetTrashPekerjaan() ([]models.GetTrashPekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetTrashPekerjaan(ctx context.Context) ([]models.GetTrashPekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
 	query := `
 		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, 
-		       lokasi_kerja, status_pekerjaan, deleted_at, created_by
+		       lokasi_kerja, status_pekerjaan, deleted_at, created_by, deleted_by
 		FROM pekerjaan_alumni
 		WHERE deleted_at IS NOT NULL
 		ORDER BY deleted_at DESC;
@@ -253,6 +277,7 @@ func GetTrashPekerjaan() ([]models.GetTrashPekerjaan, error) {
 			&t.StatusPekerjaan,
 			&t.DeletedAt,
 			&t.CreatedBy, // pastikan ada di SELECT
+			&t.DeletedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -262,8 +287,8 @@ func GetTrashPekerjaan() ([]models.GetTrashPekerjaan, error) {
 	return result, nil
 }
 
-func HardDeletePekerjaanByID(id int64) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func HardDeletePekerjaanByID(ctx context.Context, id int64, ifMatch int) error {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
 	query := `
@@ -271,8 +296,13 @@ func HardDeletePekerjaanByID(id int64) error {
 		WHERE id = $1 
 		AND deleted_at IS NOT NULL
 	`
+	args := []interface{}{id}
+	if ifMatch > 0 {
+		query += " AND version = $2"
+		args = append(args, ifMatch)
+	}
 
-	result, err := postgresql.DB.ExecContext(ctx, query, id)
+	result, err := postgresql.DB.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
 	}
@@ -283,6 +313,9 @@ func HardDeletePekerjaanByID(id int64) error {
 	}
 
 	if rowsAffected == 0 {
+		if ifMatch > 0 {
+			return ErrVersionConflict
+		}
 		return fmt.Errorf("data tidak ditemukan atau belum dihapus (soft delete)")
 	}
 
@@ -290,13 +323,13 @@ func HardDeletePekerjaanByID(id int64) error {
 }
 
 
-func RestorePekerjaan(id int) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func RestorePekerjaan(ctx context.Context, id int) error {
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
     defer cancel()
 
     res, err := postgresql.DB.ExecContext(ctx, `
         UPDATE pekerjaan_alumni 
-        SET deleted_at = NULL 
+        SET deleted_at = NULL, deleted_by = NULL, deletion_batch = NULL, version = version + 1
         WHERE id = $1
     `, id)
     if err != nil {
@@ -341,8 +374,8 @@ func HardDeleteBarang(db *sql.DB, id int) error {
 	return err
 }
 
-func GetAlumniWithPekerjaan() ([]models.AlumniWithPekerjaan, error) {
-    rows, err := postgresql.DB.Query(`
+func GetAlumniWithPekerjaan(ctx context.Context) ([]models.AlumniWithPekerjaan, error) {
+    rows, err := postgresql.DB.QueryContext(ctx, `
 		SELECT 
 			a.id, a.nim, a.nama, a.jurusan, a.angkatan, a.tahun_lulus, a.email, a.no_telepon, a.alamat, 
 			a.created_at AS alumni_created_at, a.updated_at AS alumni_updated_at,
@@ -350,7 +383,8 @@ func GetAlumniWithPekerjaan() ([]models.AlumniWithPekerjaan, error) {
 			p.tanggal_mulai_kerja, p.tanggal_selesai_kerja, p.status_pekerjaan, p.deskripsi_pekerjaan, 
 			p.created_at AS pekerjaan_created_at, p.updated_at AS pekerjaan_updated_at
 		FROM alumni a
-		LEFT JOIN pekerjaan_alumni p ON a.id = p.alumni_id
+		LEFT JOIN pekerjaan_alumni p ON a.id = p.alumni_id AND p.deleted_at IS NULL
+		WHERE a.deleted_at IS NULL
 		ORDER BY a.id, p.tanggal_mulai_kerja DESC;
 
     `)
@@ -446,22 +480,22 @@ func derefTime(t *time.Time) time.Time {
 }
 
 // GetPekerjaanPaginated -> ambil data pekerjaan dengan search, sort, paginate
-func GetPekerjaanPaginated(search, sortBy, order string, limit, offset int) ([]models.Pekerjaan, error) {
+func GetPekerjaanPaginated(ctx context.Context, search, sortBy, order string, limit, offset int) ([]models.Pekerjaan, error) {
     query := fmt.Sprintf(`
         SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, 
-               tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at
+               tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_at, updated_at, version
         FROM pekerjaan_alumni
-        WHERE nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1
+        WHERE deleted_at IS NULL AND (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1)
         ORDER BY %s %s
         LIMIT $2 OFFSET $3
     `, sortBy, order)
 
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
     defer cancel()
 
     rows, err := postgresql.DB.QueryContext(ctx, query, "%"+search+"%", limit, offset)
     if err != nil {
-        log.Println("Query error:", err)
+        slog.ErrorContext(ctx, "query pekerjaan gagal", "error", err)
         return nil, err
     }
     defer rows.Close()
@@ -472,7 +506,7 @@ func GetPekerjaanPaginated(search, sortBy, order string, limit, offset int) ([]m
         var tanggalSelesai sql.NullTime
         err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan, &p.BidangIndustri, 
             &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja, &tanggalSelesai, &p.StatusPekerjaan, 
-            &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt)
+            &p.DeskripsiPekerjaan, &p.CreatedAt, &p.UpdatedAt, &p.Version)
         if err != nil {
             return nil, err
         }
@@ -484,41 +518,254 @@ func GetPekerjaanPaginated(search, sortBy, order string, limit, offset int) ([]m
     return pekerjaan, nil
 }
 
+// GetPekerjaanKeyset -> pekerjaan aktif dengan keyset pagination: baris setelah (atau sebelum) cur,
+// diurutkan berdasarkan sortBy lalu id. cur nil -> halaman pertama. Non-admin hanya data miliknya.
+func GetPekerjaanKeyset(ctx context.Context, role string, userID int, search, sortBy, order string, limit int, cur *cursor.Cursor, f filter.Filter) ([]models.Pekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	where, args := f.SQL(3)
+	args = append([]interface{}{"%" + search + "%", limit}, args...)
+
+	if role != "admin" {
+		args = append(args, userID)
+		where += fmt.Sprintf(" AND created_by = $%d", len(args))
+	}
+
+	desc := cursor.Descending(order, cur)
+	dir, op := "ASC", ">"
+	if desc {
+		dir, op = "DESC", "<"
+	}
+	if cur != nil {
+		where += fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", sortBy, op, len(args)+1, len(args)+2)
+		args = append(args, cur.Value, cur.ID)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range,
+		       tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan,
+		       created_by, created_at, updated_at, version
+		FROM pekerjaan_alumni
+		WHERE deleted_at IS NULL AND (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1) AND %s
+		ORDER BY %s %s, id %s
+		LIMIT $2
+	`, where, sortBy, dir, dir)
+
+	rows, err := postgresql.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "query pekerjaan gagal", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []models.Pekerjaan
+	for rows.Next() {
+		var p models.Pekerjaan
+		if err := rows.Scan(&p.ID, &p.AlumniID, &p.NamaPerusahaan, &p.PosisiJabatan,
+			&p.BidangIndustri, &p.LokasiKerja, &p.GajiRange, &p.TanggalMulaiKerja,
+			&p.TanggalSelesaiKerja, &p.StatusPekerjaan, &p.DeskripsiPekerjaan,
+			&p.CreatedBy, &p.CreatedAt, &p.UpdatedAt, &p.Version); err != nil {
+			return nil, err
+		}
+		list = append(list, p)
+	}
+	return list, rows.Err()
+}
+
 // CountPekerjaan -> hitung total
-func CountPekerjaan(search string) (int, error) {
+func CountPekerjaan(ctx context.Context, search string) (int, error) {
     var total int
-    query := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1`
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+    query := `SELECT COUNT(*) FROM pekerjaan_alumni WHERE deleted_at IS NULL AND (nama_perusahaan ILIKE $1 OR posisi_jabatan ILIKE $1)`
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
     defer cancel()
     err := postgresql.DB.QueryRowContext(ctx, query, "%"+search+"%").Scan(&total)
     return total, err
 }
 
-func SoftDeletePekerjaan(id int, userID int, role string) error {
-    ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// ErrPekerjaanBukanMilik -> non-admin mencoba menghapus pekerjaan milik user lain
+var ErrPekerjaanBukanMilik = apperror.Forbidden("pekerjaan.forbidden_delete")
+
+func SoftDeletePekerjaan(ctx context.Context, id int, userID int, role string, ifMatch int) error {
+    ctx, cancel := postgresql.WithQueryTimeout(ctx)
     defer cancel()
 
-    var res sql.Result
-    var err error
+    query := "UPDATE pekerjaan_alumni SET deleted_at = NOW(), deleted_by = $2, version = version + 1 WHERE id=$1 AND deleted_at IS NULL"
+    args := []interface{}{id, userID}
 
-    if role == "admin" {
-        // Admin boleh delete semua
-        res, err = postgresql.DB.ExecContext(ctx,
-            "UPDATE pekerjaan_alumni SET deleted_at = NOW() WHERE id=$1", id)
-    } else {
+    if role != "admin" {
         // User hanya boleh delete miliknya sendiri
-        res, err = postgresql.DB.ExecContext(ctx,
-            "UPDATE pekerjaan_alumni SET deleted_at = NOW() WHERE id=$1 AND created_by=$2", id, userID)
+        query += " AND created_by=$2"
+    }
+    if ifMatch > 0 {
+        args = append(args, ifMatch)
+        query += fmt.Sprintf(" AND version=$%d", len(args))
     }
 
+    res, err := postgresql.DB.ExecContext(ctx, query, args...)
     if err != nil {
         return err
     }
 
     rows, _ := res.RowsAffected()
     if rows == 0 {
-        return fmt.Errorf("tidak ada data yang dihapus, mungkin bukan milik user ini")
+        // Cari tahu kenapa tidak ada baris yang terhapus: tidak ada/sudah di trash, bukan milik user, atau versi berubah
+        var createdBy int
+        err := postgresql.DB.QueryRowContext(ctx,
+            "SELECT created_by FROM pekerjaan_alumni WHERE id=$1 AND deleted_at IS NULL", id).Scan(&createdBy)
+        if err == sql.ErrNoRows {
+            return ErrPekerjaanTidakDitemukan
+        }
+        if err != nil {
+            return err
+        }
+        if role != "admin" && createdBy != userID {
+            return ErrPekerjaanBukanMilik
+        }
+        return ErrVersionConflict
     }
 
     return nil
 }
+
+// ErrBulkDibatalkan -> item tidak disimpan karena item lain gagal pada mode all-or-nothing
+var ErrBulkDibatalkan = bulk.ErrCancelled
+
+// runBulkTx menjalankan fn untuk setiap item dalam satu transaksi.
+// Mode atomic: satu item gagal -> seluruh transaksi di-rollback.
+// Mode best-effort: item yang gagal di-rollback lewat SAVEPOINT, item lain tetap di-commit.
+func runBulkTx(ctx context.Context, n int, atomic bool, fn func(ctx context.Context, tx *sql.Tx, i int) error) ([]error, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	tx, err := postgresql.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	errs := make([]error, n)
+	failed := false
+	for i := 0; i < n; i++ {
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT bulk_item"); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := fn(ctx, tx, i); err != nil {
+			errs[i] = err
+			failed = true
+			if atomic {
+				break
+			}
+			if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT bulk_item"); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if !atomic {
+			if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT bulk_item"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if atomic && failed {
+		for i := range errs {
+			if errs[i] == nil {
+				errs[i] = ErrBulkDibatalkan
+			}
+		}
+		return errs, nil
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return errs, nil
+}
+
+// BulkCreatePekerjaan menyimpan banyak pekerjaan sekaligus dalam satu transaksi
+func BulkCreatePekerjaan(ctx context.Context, list []*models.Pekerjaan, atomic bool) ([]error, error) {
+	now := time.Now()
+	errs, err := runBulkTx(ctx, len(list), atomic, func(ctx context.Context, tx *sql.Tx, i int) error {
+		p := list[i]
+		return tx.QueryRowContext(ctx, `
+			INSERT INTO pekerjaan_alumni (
+				alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja,
+				gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan,
+				deskripsi_pekerjaan, created_by, created_at, updated_at
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			RETURNING id, version
+		`, p.AlumniID, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja,
+			p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan,
+			p.DeskripsiPekerjaan, p.CreatedBy, now, now,
+		).Scan(&p.ID, &p.Version)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// ID yang sempat dibuat sebelum rollback tidak berlaku lagi
+	for i, e := range errs {
+		if e != nil {
+			list[i].ID = 0
+		}
+	}
+	return errs, nil
+}
+
+// BulkUpdatePekerjaan mengubah banyak pekerjaan sekaligus; non-admin hanya bisa ubah miliknya sendiri.
+// p.Version > 0 -> item hanya diubah jika versi di database masih sama, selain itu ErrVersionConflict.
+// Baris lama dikunci (SELECT ... FOR UPDATE) dan dikembalikan di before untuk audit; field yang tidak ikut
+// diubah (alumni_id, created_by, created_at) disalin ke list sehingga list berisi data baru yang lengkap.
+func BulkUpdatePekerjaan(ctx context.Context, list []*models.Pekerjaan, atomic bool, userID int, role string) ([]*models.Pekerjaan, []error, error) {
+	now := time.Now()
+	before := make([]*models.Pekerjaan, len(list))
+	errs, err := runBulkTx(ctx, len(list), atomic, func(ctx context.Context, tx *sql.Tx, i int) error {
+		p := list[i]
+		query := `
+			SELECT id, alumni_id, nama_perusahaan, posisi_jabatan, bidang_industri, lokasi_kerja, gaji_range, tanggal_mulai_kerja, tanggal_selesai_kerja, status_pekerjaan, deskripsi_pekerjaan, created_by, created_at, updated_at, version
+			FROM pekerjaan_alumni WHERE id = $1 AND deleted_at IS NULL`
+		args := []interface{}{p.ID}
+		if role != "admin" {
+			query += " AND created_by = $2"
+			args = append(args, userID)
+		}
+
+		var old models.Pekerjaan
+		err := tx.QueryRowContext(ctx, query+" FOR UPDATE", args...).Scan(&old.ID, &old.AlumniID, &old.NamaPerusahaan, &old.PosisiJabatan, &old.BidangIndustri, &old.LokasiKerja, &old.GajiRange, &old.TanggalMulaiKerja, &old.TanggalSelesaiKerja, &old.StatusPekerjaan, &old.DeskripsiPekerjaan, &old.CreatedBy, &old.CreatedAt, &old.UpdatedAt, &old.Version)
+		if err == sql.ErrNoRows {
+			return apperror.NotFound("pekerjaan.not_found_or_not_owned")
+		}
+		if err != nil {
+			return err
+		}
+
+		query = `
+			UPDATE pekerjaan_alumni SET nama_perusahaan = $1, posisi_jabatan = $2, bidang_industri = $3, lokasi_kerja = $4, gaji_range = $5, tanggal_mulai_kerja = $6, tanggal_selesai_kerja = $7, status_pekerjaan = $8, deskripsi_pekerjaan = $9, updated_at = $10, version = version + 1
+			WHERE id = $11`
+		args = []interface{}{p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange, p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, now, p.ID}
+		if p.Version > 0 {
+			args = append(args, p.Version)
+			query += " AND version = $12"
+		}
+
+		err = tx.QueryRowContext(ctx, query+" RETURNING version", args...).Scan(&p.Version)
+		if err == sql.ErrNoRows {
+			return ErrVersionConflict
+		}
+		if err != nil {
+			return err
+		}
+		p.AlumniID, p.CreatedBy, p.CreatedAt, p.UpdatedAt = old.AlumniID, old.CreatedBy, old.CreatedAt, now
+		before[i] = &old
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return before, errs, nil
+}
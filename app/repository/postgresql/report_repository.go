@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"alumniproject/database/postgresql"
+	"alumniproject/utils/report"
+)
+
+// tracerAlumniWhere -> alumni aktif sesuai filter laporan ($1 angkatan, 0 = semua; $2 jurusan, kosong = semua)
+const tracerAlumniWhere = `
+	a.deleted_at IS NULL
+	AND ($1 = 0 OR a.angkatan = $1)
+	AND ($2 = '' OR LOWER(a.jurusan) = LOWER($2))`
+
+// GetTracerStats mengambil data laporan tracer study: keterserapan per angkatan serta
+// breakdown bidang industri, perusahaan teratas, dan rentang gaji dari pekerjaan aktif.
+func GetTracerStats(ctx context.Context, f report.TracerFilter) (*report.TracerStats, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	stats := &report.TracerStats{}
+
+	rows, err := postgresql.DB.QueryContext(ctx, `
+		SELECT a.angkatan, COUNT(*),
+			COUNT(*) FILTER (WHERE EXISTS (
+				SELECT 1 FROM pekerjaan_alumni p
+				WHERE p.alumni_id = a.id AND p.deleted_at IS NULL AND p.status_pekerjaan = 'aktif'
+			))
+		FROM alumni a
+		WHERE `+tracerAlumniWhere+`
+		GROUP BY a.angkatan
+		ORDER BY a.angkatan`, f.Angkatan, f.Jurusan)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var c report.CohortStat
+		if err := rows.Scan(&c.Angkatan, &c.Total, &c.Employed); err != nil {
+			return nil, err
+		}
+		stats.Cohorts = append(stats.Cohorts, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if stats.Industries, err = countActivePekerjaan(ctx, "bidang_industri", f, 0); err != nil {
+		return nil, err
+	}
+	if stats.Employers, err = countActivePekerjaan(ctx, "nama_perusahaan", f, report.TopEmployers); err != nil {
+		return nil, err
+	}
+	if stats.SalaryBands, err = countActivePekerjaan(ctx, "gaji_range", f, 0); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// countActivePekerjaan menghitung pekerjaan aktif per nilai kolom, terbanyak lebih dulu. limit 0 = semua.
+// column hanya diisi konstanta dari GetTracerStats.
+func countActivePekerjaan(ctx context.Context, column string, f report.TracerFilter, limit int) ([]report.CountStat, error) {
+	query := fmt.Sprintf(`
+		SELECT COALESCE(p.%[1]s, ''), COUNT(*)
+		FROM pekerjaan_alumni p
+		JOIN alumni a ON a.id = p.alumni_id
+		WHERE p.deleted_at IS NULL AND p.status_pekerjaan = 'aktif' AND `+tracerAlumniWhere+`
+		GROUP BY 1
+		ORDER BY 2 DESC, 1`, column)
+	args := []interface{}{f.Angkatan, f.Jurusan}
+	if limit > 0 {
+		query += " LIMIT $3"
+		args = append(args, limit)
+	}
+
+	rows, err := postgresql.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []report.CountStat
+	for rows.Next() {
+		var label sql.NullString
+		var s report.CountStat
+		if err := rows.Scan(&label, &s.Count); err != nil {
+			return nil, err
+		}
+		s.Label = label.String
+		list = append(list, s)
+	}
+	return list, rows.Err()
+}
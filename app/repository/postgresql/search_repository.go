@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/database/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/search"
+)
+
+// ErrSearchTidakTersedia -> skema pencarian belum terpasang (migrasi pencarian gagal saat start)
+var ErrSearchTidakTersedia = apperror.Unavailable("search.unavailable")
+
+// prefixQuery mengubah kata-kata query menjadi tsquery prefix, mis. "budi san" -> "budi:* & san:*".
+// Terms hanya berisi huruf & angka sehingga aman dipakai di to_tsquery.
+func prefixQuery(q string) string {
+	terms := search.Terms(q)
+	for i, t := range terms {
+		terms[i] = t + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// SearchAlumni mencari alumni aktif berdasarkan nama, NIM, dan jurusan.
+// Skor = ts_rank full-text + kemiripan trigram nama/NIM, sehingga salah ketik tetap ditemukan.
+// Non-admin hanya mencari data miliknya.
+func SearchAlumni(ctx context.Context, q string, limit int, role string, userID int) (*models.SearchGroup, error) {
+	if !postgresql.SearchEnabled() {
+		return nil, ErrSearchTidakTersedia
+	}
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := postgresql.DB.QueryContext(ctx, `
+		SELECT a.id, a.nama, a.nim, a.jurusan, a.angkatan,
+			ts_rank(a.search_vector, to_tsquery('simple', $2)) + GREATEST(similarity(a.nama, $1), similarity(a.nim, $1)) AS score,
+			COUNT(*) OVER()
+		FROM alumni a
+		WHERE a.deleted_at IS NULL
+			AND (($2 <> '' AND a.search_vector @@ to_tsquery('simple', $2)) OR a.nama % $1 OR a.nim % $1)
+			AND ($4 = 'admin' OR a.created_by = $5)
+		ORDER BY score DESC, a.id
+		LIMIT $3`, q, prefixQuery(q), limit, role, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	group := &models.SearchGroup{Type: search.TypeAlumni, Items: []models.SearchHit{}}
+	for rows.Next() {
+		var h models.SearchHit
+		var nim, jurusan string
+		var angkatan int
+		if err := rows.Scan(&h.ID, &h.Title, &nim, &jurusan, &angkatan, &h.Score, &group.Total); err != nil {
+			return nil, err
+		}
+		h.Subtitle = joinNonEmpty(nim, jurusan, angkatanLabel(angkatan))
+		h.Text = strings.Join([]string{h.Title, nim, jurusan}, " ")
+		group.Items = append(group.Items, h)
+	}
+	return group, rows.Err()
+}
+
+// SearchPekerjaan mencari pekerjaan aktif berdasarkan perusahaan, posisi, bidang industri, dan deskripsi.
+// Non-admin hanya mencari data miliknya.
+func SearchPekerjaan(ctx context.Context, q string, limit int, role string, userID int) (*models.SearchGroup, error) {
+	if !postgresql.SearchEnabled() {
+		return nil, ErrSearchTidakTersedia
+	}
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := postgresql.DB.QueryContext(ctx, `
+		SELECT p.id, p.posisi_jabatan, p.nama_perusahaan, p.bidang_industri, p.lokasi_kerja, COALESCE(p.deskripsi_pekerjaan, ''),
+			ts_rank(p.search_vector, to_tsquery('simple', $2)) + GREATEST(similarity(p.nama_perusahaan, $1), similarity(p.posisi_jabatan, $1)) AS score,
+			COUNT(*) OVER()
+		FROM pekerjaan_alumni p
+		WHERE p.deleted_at IS NULL
+			AND (($2 <> '' AND p.search_vector @@ to_tsquery('simple', $2)) OR p.nama_perusahaan % $1 OR p.posisi_jabatan % $1)
+			AND ($4 = 'admin' OR p.created_by = $5)
+		ORDER BY score DESC, p.id
+		LIMIT $3`, q, prefixQuery(q), limit, role, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	group := &models.SearchGroup{Type: search.TypePekerjaan, Items: []models.SearchHit{}}
+	for rows.Next() {
+		var h models.SearchHit
+		var posisi, perusahaan, bidang, lokasi, deskripsi string
+		if err := rows.Scan(&h.ID, &posisi, &perusahaan, &bidang, &lokasi, &deskripsi, &h.Score, &group.Total); err != nil {
+			return nil, err
+		}
+		h.Title = posisi + " - " + perusahaan
+		h.Subtitle = joinNonEmpty(bidang, lokasi)
+		h.Text = strings.Join([]string{posisi, perusahaan, bidang, deskripsi}, " ")
+		group.Items = append(group.Items, h)
+	}
+	return group, rows.Err()
+}
+
+func joinNonEmpty(parts ...string) string {
+	var out []string
+	for _, p := range parts {
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return strings.Join(out, " · ")
+}
+
+func angkatanLabel(angkatan int) string {
+	if angkatan == 0 {
+		return ""
+	}
+	return "Angkatan " + strconv.Itoa(angkatan)
+}
@@ -2,15 +2,14 @@ package repository
 
 import (
 	"context"
-	"time"
 
 	"alumniproject/app/models/postgresql"
 	"alumniproject/database/postgresql"
 )
 
 // GetAlumniByStatusPekerjaan retrieves alumni filtered by job status with more than 1 year of work
-func GetAlumniByStatusPekerjaan(status string) ([]models.AlumniPekerjaan, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetAlumniByStatusPekerjaan(ctx context.Context, status string) ([]models.AlumniPekerjaan, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
 	rows, err := postgresql.DB.QueryContext(ctx, `
@@ -22,6 +21,7 @@ func GetAlumniByStatusPekerjaan(status string) ([]models.AlumniPekerjaan, error)
 		FROM alumni a
 		JOIN pekerjaan_alumni p ON a.id = p.alumni_id
 		WHERE p.status_pekerjaan = $1
+			AND a.deleted_at IS NULL AND p.deleted_at IS NULL
 			AND AGE(CURRENT_DATE, p.tanggal_mulai_kerja) > INTERVAL '1 year'
 		ORDER BY a.id
 	`, status)
@@ -51,6 +51,6 @@ func GetAlumniByStatusPekerjaan(status string) ([]models.AlumniPekerjaan, error)
 }
 
 // GetAlumniWithLongTermJobs retrieves alumni with active jobs lasting more than 1 year
-func GetAlumniWithLongTermJobs() ([]models.AlumniPekerjaan, error) {
-	return GetAlumniByStatusPekerjaan("aktif")
+func GetAlumniWithLongTermJobs(ctx context.Context) ([]models.AlumniPekerjaan, error) {
+	return GetAlumniByStatusPekerjaan(ctx, "aktif")
 }
\ No newline at end of file
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/database/postgresql"
+)
+
+// purgeableAlumni -> alumni yang melewati masa retensi dan tidak punya pekerjaan aktif lagi.
+// $1 = cutoff
+const purgeableAlumni = `
+	SELECT a.id FROM alumni a
+	WHERE a.deleted_at IS NOT NULL AND a.deleted_at < $1
+	  AND NOT EXISTS (
+	      SELECT 1 FROM pekerjaan_alumni p
+	      WHERE p.alumni_id = a.id AND p.deleted_at IS NULL
+	  )`
+
+// PurgeTrash menghapus permanen data di trash yang deleted_at-nya sebelum cutoff.
+// Pekerjaan yang masih di trash ikut terhapus jika alumninya di-purge.
+// Alumni yang masih punya pekerjaan aktif dilewati (dilaporkan sebagai skipped).
+// Semua berjalan dalam satu transaksi; dryRun -> transaksi di-rollback sehingga
+// jumlah yang dilaporkan sama persis dengan purge sebenarnya.
+func PurgeTrash(ctx context.Context, cutoff time.Time, dryRun bool) ([]models.PurgeTableReport, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	tx, err := postgresql.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pekerjaan := models.PurgeTableReport{Table: "pekerjaan_alumni"}
+	alumni := models.PurgeTableReport{Table: "alumni"}
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM alumni a
+		WHERE a.deleted_at IS NOT NULL AND a.deleted_at < $1
+		  AND EXISTS (
+		      SELECT 1 FROM pekerjaan_alumni p
+		      WHERE p.alumni_id = a.id AND p.deleted_at IS NULL
+		  )`, cutoff).Scan(&alumni.Skipped)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		DELETE FROM pekerjaan_alumni
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1`, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	if pekerjaan.Purged, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	// Cascade: pekerjaan di trash milik alumni yang akan di-purge, walau belum lewat retensi
+	res, err = tx.ExecContext(ctx, `
+		DELETE FROM pekerjaan_alumni
+		WHERE deleted_at IS NOT NULL AND alumni_id IN (`+purgeableAlumni+`)`, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	if pekerjaan.Cascaded, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	res, err = tx.ExecContext(ctx, `DELETE FROM alumni WHERE id IN (`+purgeableAlumni+`)`, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	if alumni.Purged, err = res.RowsAffected(); err != nil {
+		return nil, err
+	}
+
+	report := []models.PurgeTableReport{pekerjaan, alumni}
+	if dryRun {
+		return report, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// trashWhere membangun kondisi WHERE untuk data pekerjaan di trash.
+// Non-admin hanya bisa menyentuh data miliknya sendiri, sama seperti GetTrashPekerjaanService.
+func trashWhere(f models.TrashFilter, userID int, role string) (string, []interface{}) {
+	conds := []string{"deleted_at IS NOT NULL"}
+	var args []interface{}
+
+	add := func(cond string, v interface{}) {
+		args = append(args, v)
+		conds = append(conds, fmt.Sprintf(cond, len(args)))
+	}
+
+	if len(f.IDs) > 0 {
+		add("id = ANY($%d)", pq.Array(f.IDs))
+	}
+	if f.AlumniID != nil {
+		add("alumni_id = $%d", *f.AlumniID)
+	}
+	if f.DeletedBy != nil {
+		add("deleted_by = $%d", *f.DeletedBy)
+	}
+	if f.DeletedBefore != nil {
+		add("deleted_at < $%d", *f.DeletedBefore)
+	}
+	if role != "admin" {
+		add("created_by = $%d", userID)
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+func collectIDs(ctx context.Context, query string, args []interface{}) ([]int64, error) {
+	rows, err := postgresql.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int64{}
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RestorePekerjaanBatch mengembalikan semua pekerjaan di trash yang cocok dengan filter
+func RestorePekerjaanBatch(ctx context.Context, f models.TrashFilter, userID int, role string) ([]int64, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	where, args := trashWhere(f, userID, role)
+	return collectIDs(ctx, `
+		UPDATE pekerjaan_alumni
+		SET deleted_at = NULL, deleted_by = NULL, deletion_batch = NULL, version = version + 1
+		WHERE `+where+`
+		RETURNING id`, args)
+}
+
+// PurgePekerjaanBatch menghapus permanen semua pekerjaan di trash yang cocok dengan filter
+func PurgePekerjaanBatch(ctx context.Context, f models.TrashFilter, userID int, role string) ([]int64, error) {
+	ctx, cancel := postgresql.WithTxTimeout(ctx)
+	defer cancel()
+
+	where, args := trashWhere(f, userID, role)
+	return collectIDs(ctx, `DELETE FROM pekerjaan_alumni WHERE `+where+` RETURNING id`, args)
+}
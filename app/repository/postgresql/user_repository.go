@@ -2,19 +2,19 @@ package repository
 
 import (
 	"context"
-	"time"
-	"log"
+	"log/slog"
 	"database/sql"
 	"fmt"
 
 
 	"alumniproject/app/models/postgresql"
 	"alumniproject/database/postgresql"
+	"alumniproject/utils/filter"
 )
 
 // GetUserByUsernameOrEmail retrieves user and password hash for login
-func GetUserByUsernameOrEmail(usernameOrEmail string) (models.User, string, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+func GetUserByUsernameOrEmail(ctx context.Context, usernameOrEmail string) (models.User, string, error) {
+	ctx, cancel := postgresql.WithQueryTimeout(ctx)
 	defer cancel()
 
 	var user models.User
@@ -32,18 +32,19 @@ func GetUserByUsernameOrEmail(usernameOrEmail string) (models.User, string, erro
 }
 // GetUsersRepo -> ambil data users dari DB
 // GetUsersRepo -> ambil data users dari DB
-func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.User, error) {
+func GetUsersRepo(ctx context.Context, search, sortBy, order string, limit, offset int, f filter.Filter) ([]models.User, error) {
+    where, args := f.SQL(4)
     query := fmt.Sprintf(`
-        SELECT id, name, email, created_at
+        SELECT id, username, email, role, created_at
         FROM users
-        WHERE name ILIKE $1 OR email ILIKE $1
+        WHERE (username ILIKE $1 OR email ILIKE $1) AND %s
         ORDER BY %s %s
         LIMIT $2 OFFSET $3
-    `, sortBy, order)
+    `, where, sortBy, order)
 
-    rows, err := postgresql.DB.Query(query, "%"+search+"%", limit, offset)
+    rows, err := postgresql.DB.QueryContext(ctx, query, append([]interface{}{"%" + search + "%", limit, offset}, args...)...)
     if err != nil {
-        log.Println("Query error:", err)
+        slog.ErrorContext(ctx, "query users gagal", "error", err)
         return nil, err
     }
     defer rows.Close()
@@ -51,7 +52,7 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.Use
     var users []models.User
     for rows.Next() {
         var u models.User
-        if err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.CreatedAt); err != nil {
+        if err := rows.Scan(&u.ID, &u.Username, &u.Email, &u.Role, &u.CreatedAt); err != nil {
             return nil, err
         }
         users = append(users, u)
@@ -61,10 +62,11 @@ func GetUsersRepo(search, sortBy, order string, limit, offset int) ([]models.Use
 }
 
 // CountUsersRepo -> hitung total data untuk pagination
-func CountUsersRepo(search string) (int, error) {
+func CountUsersRepo(ctx context.Context, search string, f filter.Filter) (int, error) {
     var total int
-    countQuery := `SELECT COUNT(*) FROM users WHERE name ILIKE $1 OR email ILIKE $1`
-    err := postgresql.DB.QueryRow(countQuery, "%"+search+"%").Scan(&total)
+    where, args := f.SQL(2)
+    countQuery := `SELECT COUNT(*) FROM users WHERE (username ILIKE $1 OR email ILIKE $1) AND ` + where
+    err := postgresql.DB.QueryRowContext(ctx, countQuery, append([]interface{}{"%" + search + "%"}, args...)...).Scan(&total)
     if err != nil && err != sql.ErrNoRows {
         return 0, err
     }
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/app/models/mongodb"
+	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/validate"
+)
+
+// currentAlumniVersion -> data alumni saat ini dalam bentuk snapshot (ValidTo nil)
+func currentAlumniVersion(a *models.Alumni) models.AlumniVersion {
+	return models.AlumniVersion{
+		AlumniID:   a.ID,
+		Version:    a.Version,
+		NIM:        a.NIM,
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+		NoTelepon:  a.NoTelepon,
+		Alamat:     a.Alamat,
+		ValidFrom:  a.UpdatedAt,
+	}
+}
+
+// GetAlumniByIDService godoc
+// @Summary Ambil data alumni berdasarkan ID
+// @Description Mengambil data alumni. Dengan as_of (YYYY-MM-DD atau RFC3339) yang dikirim adalah data seperti pada waktu tersebut.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Param as_of query string false "Waktu yang ingin dilihat (YYYY-MM-DD atau RFC3339)"
+// @Success 200 {object} models.Alumni
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [get]
+func GetAlumniByIDService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	asOf, err := parseTimeParam(c.Query("as_of"))
+	if err != nil {
+		return apperror.Validation("request.datetime_format").WithArgs("as_of")
+	}
+
+	data, err := repo.GetByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if data == nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+
+	if asOf == nil {
+		c.Set(fiber.HeaderETag, etag.Format(data.Version))
+		return c.JSON(data)
+	}
+
+	if asOf.Before(data.CreatedAt) {
+		return apperror.NotFound("alumni.not_yet_exist")
+	}
+	if !asOf.Before(data.UpdatedAt) {
+		return c.JSON(currentAlumniVersion(data))
+	}
+
+	v, err := repo.GetAsOf(c.UserContext(), id, *asOf)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if v == nil {
+		// Perubahan sebelum riwayat mulai dicatat tidak punya snapshot
+		return apperror.NotFound("alumni.history_unavailable")
+	}
+	return c.JSON(v)
+}
+
+// GetAlumniHistoryService godoc
+// @Summary Riwayat perubahan alumni
+// @Description Daftar semua versi data alumni, dimulai dari versi saat ini. Non-admin hanya bisa melihat data miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Success 200 {object} object{success=bool,data=[]models.AlumniVersion}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/history [get]
+func GetAlumniHistoryService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	data, err := repo.GetByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if data == nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_history")
+	}
+
+	history, err := repo.GetHistory(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    append([]models.AlumniVersion{currentAlumniVersion(data)}, history...),
+	})
+}
+
+// RevertAlumniService godoc
+// @Summary Kembalikan alumni ke versi sebelumnya
+// @Description Mengisi ulang data alumni dari snapshot versi tertentu. Revert disimpan sebagai update baru, jadi versi saat ini tetap ada di riwayat.
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.RevertAlumniRequest true "Versi tujuan"
+// @Success 200 {object} object{success=bool,message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/revert [post]
+func RevertAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var req models.RevertAlumniRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	data, err := repo.GetByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if data == nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_update")
+	}
+	if req.Version == data.Version {
+		return apperror.Validation("alumni.same_version")
+	}
+
+	target, err := repo.GetVersion(c.UserContext(), id, req.Version)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if target == nil {
+		return apperror.NotFound("alumni.version_not_found")
+	}
+
+	before := *data
+	data.Nama = target.Nama
+	data.Jurusan = target.Jurusan
+	data.Angkatan = target.Angkatan
+	data.TahunLulus = target.TahunLulus
+	data.Email = target.Email
+	data.NoTelepon = target.NoTelepon
+	data.Alamat = target.Alamat
+	data.UpdatedAt = time.Now()
+
+	if err := repo.UpdateAlumni(c.UserContext(), id, data, role, userID, ifMatch); err != nil {
+		if err == repository.ErrVersionConflict || err == repository.ErrAlumniTidakDitemukan {
+			return err
+		}
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionRevert, audit.EntityAlumni, id, before, data)
+
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "alumni.reverted", req.Version),
+		"data":    data,
+	})
+}
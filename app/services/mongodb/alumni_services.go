@@ -0,0 +1,343 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/app/models/mongodb"
+	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/mergepatch"
+	"alumniproject/utils/validate"
+)
+
+// alumniFilterFields -> field alumni yang boleh dipakai di ?filter=
+var alumniFilterFields = filter.Schema{
+	"nim":         {Kind: filter.String, Key: "nim"},
+	"nama":        {Kind: filter.String, Key: "nama"},
+	"jurusan":     {Kind: filter.String, Key: "jurusan"},
+	"angkatan":    {Kind: filter.Int, Key: "angkatan"},
+	"tahun_lulus": {Kind: filter.Int, Key: "tahun_lulus"},
+	"email":       {Kind: filter.String, Key: "email"},
+	"created_at":  {Kind: filter.Time, Key: "created_at"},
+	"updated_at":  {Kind: filter.Time, Key: "updated_at"},
+}
+
+// GetAlumniService godoc
+// @Summary Menampilkan data alumni dengan pagination
+// @Description Mengambil data alumni aktif dengan pagination, sorting, search, dan filter. Non-admin hanya melihat data miliknya.
+// @Description Jika ada ?cursor= (kosong untuk halaman pertama) dipakai keyset pagination: meta berisi next_cursor/prev_cursor, total tidak dihitung.
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param page query int false "Nomor halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param sort_by query string false "Kolom untuk sorting (default: _id)"
+// @Param order query string false "Urutan sort asc/desc (default: asc)"
+// @Param search query string false "Cari berdasarkan nama, NIM, atau jurusan"
+// @Param filter query string false "Filter field:operator:nilai, mis. angkatan:gte:2018,jurusan:in:TI|SI"
+// @Param cursor query string false "Cursor dari next_cursor/prev_cursor (mode keyset)"
+// @Success 200 {object} models.AlumniResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni [get]
+func GetAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+	if c.Context().QueryArgs().Has("cursor") {
+		return getAlumniKeyset(c, repo, role, userID)
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	sortBy := c.Query("sort_by", "_id")
+	if !alumniSortColumns[sortBy] {
+		sortBy = "_id"
+	}
+	order := strings.ToLower(c.Query("order", "asc"))
+	if order != "desc" {
+		order = "asc"
+	}
+	search := c.Query("search", "")
+
+	f, err := filter.Parse(c.Query("filter"), alumniFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	list, err := repo.GetAlumniPaginated(c.UserContext(), search, sortBy, order, limit, (page-1)*limit, role, userID, f)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	total, err := repo.CountAlumni(c.UserContext(), search, role, userID, f)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+
+	return c.JSON(&models.AlumniResponse{
+		Data: list,
+		Meta: &models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  int(total),
+			Pages:  (int(total) + limit - 1) / limit,
+			SortBy: sortBy,
+			Order:  order,
+			Search: search,
+			Filter: f.String(),
+		},
+	})
+}
+
+// getAlumniKeyset -> GetAlumniService mode cursor
+func getAlumniKeyset(c *fiber.Ctx, repo *repository.AlumniMongoRepo, role string, userID int) error {
+	cur, sortBy, order, limit, err := keysetParams(c, alumniSortColumns, "_id", "asc")
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidCursor)
+	}
+	search := c.Query("search", "")
+
+	f, err := filter.Parse(c.Query("filter"), alumniFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	list, err := repo.GetAlumniKeyset(c.UserContext(), search, sortBy, order, limit+1, cur, role, userID, f)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	list, hasMore := cursor.Trim(list, limit, cur)
+	next, prev := cursor.Links(list, hasMore, cur, sortBy, order, func(a *models.Alumni) (interface{}, interface{}) {
+		return alumniSortValue(a, sortBy), a.ID
+	})
+
+	return c.JSON(&models.AlumniResponse{
+		Data: list,
+		Meta: &models.MetaInfo{
+			Limit:      limit,
+			SortBy:     sortBy,
+			Order:      order,
+			Search:     search,
+			Filter:     f.String(),
+			NextCursor: next,
+			PrevCursor: prev,
+		},
+	})
+}
+
+// alumniSortValue -> nilai kolom sort satu alumni untuk cursor
+func alumniSortValue(a *models.Alumni, sortBy string) interface{} {
+	switch sortBy {
+	case "nim":
+		return a.NIM
+	case "nama":
+		return a.Nama
+	case "jurusan":
+		return a.Jurusan
+	case "angkatan":
+		return a.Angkatan
+	case "tahun_lulus":
+		return a.TahunLulus
+	default:
+		return a.ID
+	}
+}
+
+// PatchAlumniService godoc
+// @Summary Update sebagian data alumni (JSON Merge Patch)
+// @Description Mengubah hanya field alumni yang dikirim (RFC 7396). Field bernilai null akan dikosongkan. Hasil merge tetap divalidasi.
+// @Description Non-admin hanya bisa mengubah alumni miliknya; alumni lama tanpa pemilik (created_by 0, lihat backfill saat koneksi) hanya bisa diubah admin.
+// @Tags Alumni
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdateAlumniRequest true "Field alumni yang akan diubah"
+// @Success 200 {object} object{success=bool,message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 415 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [patch]
+func PatchAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	if !isMergePatchRequest(c) {
+		return apperror.UnsupportedMediaType("request.content_type").WithArgs(mergepatch.ContentType)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	data, err := repo.GetByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if data == nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+	if ifMatch > 0 && ifMatch != data.Version {
+		return repository.ErrVersionConflict
+	}
+
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_update")
+	}
+
+	current := models.UpdateAlumniRequest{
+		Nama:       data.Nama,
+		Jurusan:    data.Jurusan,
+		Angkatan:   data.Angkatan,
+		TahunLulus: data.TahunLulus,
+		Email:      data.Email,
+		NoTelepon:  data.NoTelepon,
+		Alamat:     data.Alamat,
+	}
+
+	var req models.UpdateAlumniRequest
+	if err := mergepatch.ApplyTo(current, c.Body(), &req); err != nil {
+		return err
+	}
+
+	// Validasi hasil merge, bukan hanya isi patch
+	if err := validate.Check(&req); err != nil {
+		return err
+	}
+
+	before := *data
+	data.Nama = req.Nama
+	data.Jurusan = req.Jurusan
+	data.Angkatan = req.Angkatan
+	data.TahunLulus = req.TahunLulus
+	data.Email = req.Email
+	data.NoTelepon = req.NoTelepon
+	data.Alamat = req.Alamat
+
+	if err := repo.UpdateAlumni(c.UserContext(), id, data, role, userID, data.Version); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) || errors.Is(err, repository.ErrAlumniTidakDitemukan) {
+			return err
+		}
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionUpdate, audit.EntityAlumni, id, before, data)
+
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "alumni.updated"),
+		"data":    data,
+	})
+}
+
+// DeleteAlumniService godoc
+// @Summary Hapus alumni (soft delete + cascade)
+// @Description Soft delete alumni beserta pekerjaan dan file miliknya dengan satu batch ID. Pekerjaan yang ada sebelum alumni memiliki alumni_id tidak ikut terhapus. Non-admin hanya bisa hapus data miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string,cascade=models.DeletionBatch}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [delete]
+func DeleteAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return err
+	}
+
+	batch, err := repo.SoftDeleteAlumni(c.UserContext(), id, userID, role, ifMatch)
+	if errors.Is(err, repository.ErrVersionConflict) || errors.Is(err, repository.ErrAlumniTidakDitemukan) {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionDelete, audit.EntityAlumni, id, nil, batch)
+
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.deleted"),
+		"cascade": batch,
+	})
+}
+
+// RestoreAlumniService godoc
+// @Summary Restore alumni (beserta batch cascade)
+// @Description Mengembalikan alumni serta pekerjaan dan file yang terhapus bersamanya (batch yang sama). Non-admin hanya bisa restore alumni miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path string true "ID alumni"
+// @Success 200 {object} object{message=string,cascade=models.DeletionBatch}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/restore [post]
+func RestoreAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	id := c.Params("id")
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	batch, err := repo.RestoreAlumni(c.UserContext(), id, userID, role)
+	if errors.Is(err, repository.ErrAlumniTidakDiTrash) || errors.Is(err, repository.ErrIDTidakValid) {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionRestore, audit.EntityAlumni, id, nil, batch)
+
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.restored"),
+		"cascade": batch,
+	})
+}
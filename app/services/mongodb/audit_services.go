@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"alumniproject/app/models/mongodb"
+	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"github.com/gofiber/fiber/v2"
+)
+
+// recordAudit mencatat satu perubahan data. Kegagalan mencatat hanya di-log,
+// tidak menggagalkan request yang datanya sudah tersimpan.
+func recordAudit(c *fiber.Ctx, action, entity string, entityID interface{}, before, after interface{}) {
+	var actorID *int
+	if userID, ok := c.Locals("user_id").(int); ok {
+		actorID = &userID
+	}
+	insertAudit(c.UserContext(), actorID, c.IP(), action, entity, entityID, before, after)
+}
+
+// insertAudit -> recordAudit tanpa request, mis. dari job yang mencatat atas nama user pembuat job
+func insertAudit(ctx context.Context, actorID *int, ip, action, entity string, entityID interface{}, before, after interface{}) {
+	entry := &models.AuditLog{
+		ActorID:   actorID,
+		Action:    action,
+		Entity:    entity,
+		EntityID:  fmt.Sprint(entityID),
+		IP:        ip,
+		Diff:      audit.Diff(before, after),
+		CreatedAt: time.Now(),
+	}
+
+	if err := repository.NewAuditRepo().Insert(ctx, entry); err != nil {
+		slog.WarnContext(ctx, "gagal mencatat audit", "action", action, "entity", entity, "entity_id", entry.EntityID, "error", err)
+	}
+}
+
+// parseAuditFilter membaca query actor_id, action, entity, entity_id, from, to (YYYY-MM-DD atau RFC3339)
+func parseAuditFilter(c *fiber.Ctx) (models.AuditFilter, error) {
+	f := models.AuditFilter{
+		Action:   c.Query("action"),
+		Entity:   c.Query("entity"),
+		EntityID: c.Query("entity_id"),
+	}
+
+	if v := c.Query("actor_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return f, apperror.Validation("audit.actor_invalid")
+		}
+		f.ActorID = &id
+	}
+
+	var err error
+	if f.From, err = parseTimeParam(c.Query("from")); err != nil {
+		return f, apperror.Validation("request.datetime_format").WithArgs("from")
+	}
+	if f.To, err = parseTimeParam(c.Query("to")); err != nil {
+		return f, apperror.Validation("request.datetime_format").WithArgs("to")
+	}
+	return f, nil
+}
+
+// GetAuditLogsService godoc
+// @Summary Menampilkan audit log (khusus admin)
+// @Description Riwayat semua perubahan data (siapa, aksi, entity, waktu, IP, diff), terbaru lebih dulu
+// @Tags Audit
+// @Produce json
+// @Param actor_id query int false "ID user yang melakukan perubahan"
+// @Param action query string false "Aksi (create, update, delete, restore, hard_delete, login)"
+// @Param entity query string false "Entity (alumni, pekerjaan, file, user)"
+// @Param entity_id query string false "ID data yang berubah"
+// @Param from query string false "Mulai tanggal (YYYY-MM-DD atau RFC3339)"
+// @Param to query string false "Sampai sebelum tanggal (YYYY-MM-DD atau RFC3339)"
+// @Param page query int false "Nomor halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: 20, max 100)"
+// @Success 200 {object} models.AuditResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /audit [get]
+func GetAuditLogsService(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	f, err := parseAuditFilter(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	repo := repository.NewAuditRepo()
+	logs, err := repo.Find(c.UserContext(), f, limit, offset)
+	if err != nil {
+		return apperror.Internal("audit.fetch_failed", err)
+	}
+
+	total, err := repo.Count(c.UserContext(), f)
+	if err != nil {
+		return apperror.Internal("audit.count_failed", err)
+	}
+
+	return c.JSON(&models.AuditResponse{
+		Data: logs,
+		Meta: &models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  int(total),
+			Pages:  (int(total) + limit - 1) / limit,
+			SortBy: "created_at",
+			Order:  "desc",
+		},
+	})
+}
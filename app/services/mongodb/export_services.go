@@ -0,0 +1,330 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alumniproject/app/models/mongodb"
+	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/export"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/jobs"
+)
+
+// alumniSortColumns -> kolom alumni yang boleh dipakai untuk sorting
+var alumniSortColumns = map[string]bool{
+	"_id": true, "nim": true, "nama": true,
+	"jurusan": true, "angkatan": true, "tahun_lulus": true,
+}
+
+var alumniExportColumns = []string{
+	"id", "nim", "nama", "jurusan", "angkatan", "tahun_lulus", "email", "no_telepon", "alamat", "created_at", "updated_at",
+}
+
+var pekerjaanExportColumns = []string{
+	"id", "alumni_id", "nama_perusahaan", "posisi_jabatan", "bidang_industri", "lokasi_kerja", "gaji_range",
+	"tanggal_mulai_kerja", "tanggal_selesai_kerja", "status_pekerjaan", "deskripsi_pekerjaan", "created_at", "updated_at",
+}
+
+// exportParams -> parameter export dari query HTTP, juga disimpan sebagai payload job export
+type exportParams struct {
+	Format string `json:"format"`
+	Search string `json:"search"`
+	Filter string `json:"filter"`
+	SortBy string `json:"sort_by"`
+	Order  string `json:"order"`
+	Role   string `json:"role"`
+}
+
+// filter -> ExportFilter dari parameter; ?filter= di-parse dengan schema yang sama dengan endpoint list
+func (p exportParams) filter(sortColumns map[string]bool, fields filter.Schema) (models.ExportFilter, error) {
+	conds, err := filter.Parse(p.Filter, fields)
+	if err != nil {
+		return models.ExportFilter{}, err
+	}
+	f := models.ExportFilter{Search: p.Search, Filter: conds, SortBy: p.SortBy, Order: strings.ToLower(p.Order)}
+	if f.SortBy == "id" {
+		f.SortBy = "_id"
+	}
+	if !sortColumns[f.SortBy] {
+		f.SortBy = "_id"
+	}
+	if f.Order != "desc" {
+		f.Order = "asc"
+	}
+	return f, nil
+}
+
+// ExportAlumniService godoc
+// @Summary Export data alumni
+// @Description Mengunduh data alumni dalam format CSV, XLSX, atau NDJSON (di-stream dari database). Admin mendapat semua data, user lain hanya data miliknya. async=true menjalankan export sebagai job (202 + header Location ke /api/v1/jobs/{id}).
+// @Tags Alumni
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default), xlsx, atau ndjson"
+// @Param search query string false "Kata kunci pencarian (nama, nim, jurusan)"
+// @Param filter query string false "Filter field:op:value dipisah koma, sama dengan endpoint list"
+// @Param sortBy query string false "Kolom untuk sorting (default: _id)"
+// @Param order query string false "Urutan sort asc/desc (default: asc)"
+// @Param async query bool false "true -> jalankan sebagai job latar belakang"
+// @Success 200 {file} file
+// @Success 202 {object} object{success=bool,message=string,data=jobs.Job}
+// @Failure 400 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/export [get]
+func ExportAlumniService(c *fiber.Ctx) error {
+	repo := repository.NewAlumniRepo()
+	userID := c.Locals("user_id").(int)
+	params := exportParams{
+		Format: strings.ToLower(c.Query("format", export.FormatCSV)),
+		Search: c.Query("search"),
+		Filter: c.Query("filter"),
+		SortBy: c.Query("sortBy", "_id"),
+		Order:  c.Query("order", "asc"),
+		Role:   c.Locals("role").(string),
+	}
+	if !export.Valid(params.Format) {
+		return apperror.Validation("export.format_invalid")
+	}
+	f, err := params.filter(alumniSortColumns, alumniFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+	if c.QueryBool("async") {
+		return JobHandler.Enqueue(c, jobs.TypeExportAlumni, params)
+	}
+
+	cursor, err := repo.StreamAlumni(streamContext(c), f, params.Role, userID)
+	if err != nil {
+		return apperror.Internal("alumni.fetch_failed", err)
+	}
+
+	return streamExport(c, "alumni", params.Format, alumniExportColumns, cursor.Close, func(w export.Writer) error {
+		return writeAlumniRows(cursor, w, nil)
+	})
+}
+
+// ExportPekerjaanService godoc
+// @Summary Export data pekerjaan
+// @Description Mengunduh data pekerjaan dalam format CSV, XLSX, atau NDJSON (di-stream dari database). Parameter sama dengan /api/v1/pekerjaan/paginated. Admin mendapat semua data, user lain hanya data miliknya. async=true menjalankan export sebagai job (202 + header Location ke /api/v1/jobs/{id}).
+// @Tags Pekerjaan
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/x-ndjson
+// @Param format query string false "csv (default), xlsx, atau ndjson"
+// @Param search query string false "Kata kunci pencarian"
+// @Param filter query string false "Filter field:op:value dipisah koma, sama dengan endpoint list"
+// @Param sort_by query string false "Kolom untuk sorting (default: created_at)"
+// @Param order query string false "Urutan sort asc/desc (default: desc)"
+// @Param async query bool false "true -> jalankan sebagai job latar belakang"
+// @Success 200 {file} file
+// @Success 202 {object} object{success=bool,message=string,data=jobs.Job}
+// @Failure 400 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/export [get]
+func ExportPekerjaanService(c *fiber.Ctx) error {
+	repo := repository.New()
+	userID := c.Locals("user_id").(int)
+	params := exportParams{
+		Format: strings.ToLower(c.Query("format", export.FormatCSV)),
+		Search: c.Query("search"),
+		Filter: c.Query("filter"),
+		SortBy: c.Query("sort_by", "created_at"),
+		Order:  c.Query("order", "desc"),
+		Role:   c.Locals("role").(string),
+	}
+	if !export.Valid(params.Format) {
+		return apperror.Validation("export.format_invalid")
+	}
+	f, err := params.filter(pekerjaanSortColumns, pekerjaanFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+	if c.QueryBool("async") {
+		return JobHandler.Enqueue(c, jobs.TypeExportPekerjaan, params)
+	}
+
+	cursor, err := repo.StreamPekerjaan(streamContext(c), f, params.Role, userID)
+	if err != nil {
+		return apperror.Internal("pekerjaan.fetch_failed", err)
+	}
+
+	return streamExport(c, "pekerjaan", params.Format, pekerjaanExportColumns, cursor.Close, func(w export.Writer) error {
+		return writePekerjaanRows(cursor, w, nil)
+	})
+}
+
+// writeAlumniRows menulis semua baris dari cursor. onRow (opsional) dipanggil dengan jumlah baris yang sudah ditulis.
+func writeAlumniRows(cursor *repository.Cursor[models.Alumni], w export.Writer, onRow func(n int)) error {
+	var a models.Alumni
+	for n := 1; ; n++ {
+		ok, err := cursor.Next(&a)
+		if err != nil || !ok {
+			return err
+		}
+		err = w.WriteRow([]interface{}{
+			a.ID.Hex(), a.NIM, a.Nama, a.Jurusan, a.Angkatan, a.TahunLulus, a.Email, a.NoTelepon, a.Alamat, a.CreatedAt, a.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if onRow != nil {
+			onRow(n)
+		}
+	}
+}
+
+// writePekerjaanRows menulis semua baris dari cursor. onRow (opsional) dipanggil dengan jumlah baris yang sudah ditulis.
+func writePekerjaanRows(cursor *repository.Cursor[models.Pekerjaan], w export.Writer, onRow func(n int)) error {
+	var p models.Pekerjaan
+	for n := 1; ; n++ {
+		ok, err := cursor.Next(&p)
+		if err != nil || !ok {
+			return err
+		}
+		err = w.WriteRow([]interface{}{
+			p.ID.Hex(), p.AlumniID, p.NamaPerusahaan, p.PosisiJabatan, p.BidangIndustri, p.LokasiKerja, p.GajiRange,
+			p.TanggalMulaiKerja, p.TanggalSelesaiKerja, p.StatusPekerjaan, p.DeskripsiPekerjaan, p.CreatedAt, p.UpdatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if onRow != nil {
+			onRow(n)
+		}
+	}
+}
+
+// streamContext -> context request tanpa pembatalan, karena cursor stream masih dibaca setelah handler
+// (dan deadline-nya) selesai. Batas waktunya diatur exportTimeout di repository.
+func streamContext(c *fiber.Ctx) context.Context {
+	return context.WithoutCancel(c.UserContext())
+}
+
+// streamExport mengirim file export sebagai response stream. writeRows dijalankan saat body dikirim,
+// jadi status 200 sudah terkirim; kegagalan di tengah jalan hanya bisa di-log.
+func streamExport(c *fiber.Ctx, name, format string, columns []string, closeCursor func() error, writeRows func(export.Writer) error) error {
+	c.Set(fiber.HeaderContentType, export.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+export.FileName(name, format, time.Now())+`"`)
+
+	// c tidak boleh dipakai lagi di stream writer, jadi context request diambil lebih dulu
+	ctx := c.UserContext()
+	c.Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		defer closeCursor()
+
+		w, err := export.NewWriter(format, bw, columns)
+		if err != nil {
+			slog.ErrorContext(ctx, "export gagal dimulai", "export", name, "error", err)
+			return
+		}
+		if err := writeRows(w); err != nil {
+			slog.ErrorContext(ctx, "export terhenti", "export", name, "error", err)
+		}
+		if err := w.Close(); err != nil {
+			slog.ErrorContext(ctx, "export gagal ditutup", "export", name, "error", err)
+		}
+	})
+	return nil
+}
+
+// runExportJob -> handler job export alumni / pekerjaan, hasilnya file sesuai format
+func runExportJob(ctx context.Context, run *jobs.Run) error {
+	var params exportParams
+	if err := run.Decode(&params); err != nil {
+		return err
+	}
+	if !export.Valid(params.Format) {
+		return jobs.Permanent(fmt.Errorf("format %q tidak didukung", params.Format))
+	}
+	userID := run.Job.CreatedBy
+
+	var (
+		name        string
+		columns     []string
+		total       int64
+		closeCursor func() error
+		write       func(export.Writer, func(int)) error
+	)
+	switch run.Job.Type {
+	case jobs.TypeExportAlumni:
+		repo := repository.NewAlumniRepo()
+		f, err := params.filter(alumniSortColumns, alumniFilterFields)
+		if err != nil {
+			return jobs.Permanent(err)
+		}
+		name, columns = "alumni", alumniExportColumns
+		if total, err = repo.CountExport(ctx, f, params.Role, userID); err != nil {
+			return err
+		}
+		cursor, err := repo.StreamAlumni(ctx, f, params.Role, userID)
+		if err != nil {
+			return err
+		}
+		closeCursor = cursor.Close
+		write = func(w export.Writer, onRow func(int)) error { return writeAlumniRows(cursor, w, onRow) }
+	default:
+		repo := repository.New()
+		f, err := params.filter(pekerjaanSortColumns, pekerjaanFilterFields)
+		if err != nil {
+			return jobs.Permanent(err)
+		}
+		name, columns = "pekerjaan", pekerjaanExportColumns
+		if total, err = repo.CountExport(ctx, f, params.Role, userID); err != nil {
+			return err
+		}
+		cursor, err := repo.StreamPekerjaan(ctx, f, params.Role, userID)
+		if err != nil {
+			return err
+		}
+		closeCursor = cursor.Close
+		write = func(w export.Writer, onRow func(int)) error { return writePekerjaanRows(cursor, w, onRow) }
+	}
+	defer closeCursor()
+
+	return writeExportResult(ctx, run, name, params.Format, columns, int(total), write)
+}
+
+// writeExportResult menulis file hasil export job dan melaporkan progres setiap 500 baris
+func writeExportResult(ctx context.Context, run *jobs.Run, name, format string, columns []string, total int,
+	write func(export.Writer, func(int)) error) error {
+	file, err := run.CreateResult(export.FileName(name, format, time.Now()), export.ContentType(format))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bw := bufio.NewWriter(file)
+	w, err := export.NewWriter(format, bw, columns)
+	if err != nil {
+		return err
+	}
+
+	run.Progress(0, fmt.Sprintf("Menulis %d baris %s", total, name))
+	err = write(w, func(n int) {
+		if n%500 == 0 && total > 0 {
+			run.Progress(n*100/total, fmt.Sprintf("%d dari %d baris ditulis", n, total))
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	run.Job.Message = fmt.Sprintf("%d baris %s diekspor", total, name)
+	return nil
+}
@@ -8,10 +8,30 @@ import (
 	models "alumniproject/app/models/mongodb"
 	repository "alumniproject/app/repository/mongodb"
 	db "alumniproject/database/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/metrics"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// parseFileAlumniID membaca form field alumni_id (opsional) saat upload file
+func parseFileAlumniID(c *fiber.Ctx) (*primitive.ObjectID, error) {
+	v := c.FormValue("alumni_id")
+	if v == "" {
+		return nil, nil
+	}
+	id, err := primitive.ObjectIDFromHex(v)
+	if err != nil {
+		return nil, apperror.Validation("alumni.id_invalid")
+	}
+	return &id, nil
+}
+
 type FotoService struct {
 	repo repository.FotoRepository
 	path string
@@ -28,34 +48,33 @@ func NewFotoService(repo repository.FotoRepository, path string) *FotoService {
 // @Accept multipart/form-data
 // @Produce json
 // @Param foto formData file true "File foto (jpeg/jpg/png, max 1MB)"
+// @Param alumni_id formData string false "ID alumni pemilik file (ikut terhapus saat alumni dihapus)"
 // @Param kategori formData string false "Kategori foto (contoh: profil, dokumen, event)"
 // @Param deskripsi formData string false "Deskripsi singkat foto"
 // @Param uploader_id formData int false "ID pengguna yang mengunggah (opsional)"
-// @Success 200 {object} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/foto/upload [post]
+// @Success 200 {object} object{success=bool,message=string,data=models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /foto/upload [post]
 func (s *FotoService) UploadFoto(c *fiber.Ctx) error {
 	fileHeader, err := c.FormFile("foto")
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "No file uploaded",
-		})
+		return apperror.Validation("file.required")
 	}
+	metrics.AddUploadBytes("foto", fileHeader.Size)
 
 	allowed := map[string]bool{"image/jpeg": true, "image/jpg": true, "image/png": true}
 	if !allowed[fileHeader.Header.Get("Content-Type")] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Only jpeg/jpg/png allowed",
-		})
+		return apperror.Validation("file.image_only")
 	}
 	if fileHeader.Size > 1*1024*1024 {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Max file size 1MB",
-		})
+		return apperror.Validation("file.too_large").WithArgs("1MB")
+	}
+
+	alumniID, err := parseFileAlumniID(c)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
 	os.MkdirAll(s.path, os.ModePerm)
@@ -63,10 +82,7 @@ func (s *FotoService) UploadFoto(c *fiber.Ctx) error {
 	filePath := filepath.Join(s.path, newFileName)
 
 	if err := c.SaveFile(fileHeader, filePath); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save file",
-		})
+		return apperror.Internal("file.save_failed", err)
 	}
 
 	fileModel := &models.File{
@@ -75,23 +91,32 @@ func (s *FotoService) UploadFoto(c *fiber.Ctx) error {
 		FilePath:     filePath,
 		FileSize:     fileHeader.Size,
 		FileType:     fileHeader.Header.Get("Content-Type"),
+		AlumniID:     alumniID,
 	}
 
-	if err := s.repo.Create(fileModel); err != nil {
+	if err := s.repo.Create(c.UserContext(), fileModel); err != nil {
 		os.Remove(filePath)
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save metadata",
-		})
+		return apperror.Internal("file.metadata_failed", err)
 	}
+	recordAudit(c, audit.ActionCreate, audit.EntityFile, fileModel.ID, nil, fileModel)
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Foto uploaded successfully",
+		"message": i18n.Msg(c, "foto.uploaded"),
 		"data":    fileModel,
 	})
 }
 
+// fileFilterFields -> field foto & sertifikat yang boleh dipakai di ?filter=
+var fileFilterFields = filter.Schema{
+	"id":            {Kind: filter.Int, Key: "id"},
+	"file_name":     {Kind: filter.String, Key: "file_name"},
+	"original_name": {Kind: filter.String, Key: "original_name"},
+	"file_type":     {Kind: filter.String, Key: "file_type"},
+	"file_size":     {Kind: filter.Int, Key: "file_size"},
+	"uploaded_at":   {Kind: filter.Time, Key: "uploaded_at"},
+}
+
 // GetAllFoto godoc
 // @Summary Menampilkan semua foto
 // @Description Mengambil seluruh data foto yang tersimpan di MongoDB dengan opsi filter, search, pagination, dan sorting
@@ -104,24 +129,27 @@ func (s *FotoService) UploadFoto(c *fiber.Ctx) error {
 // @Param sort_by query string false "Kolom untuk sorting (default: uploaded_at)"
 // @Param order query string false "Urutan sort (asc/desc)"
 // @Param file_type query string false "Filter berdasarkan tipe file (contoh: image/jpeg, image/png)"
-// @Success 200 {array} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/foto [get]
+// @Param filter query string false "Filter field:operator:nilai, mis. file_type:in:image/jpeg|image/png,file_size:lte:500000"
+// @Success 200 {object} object{success=bool,message=string,data=[]models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /foto [get]
 func GetAllFoto(c *fiber.Ctx) error {
+	f, err := filter.Parse(c.Query("filter"), fileFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
 	fotoRepo := repository.NewFotoRepository(db.DB)
-	fotos, err := fotoRepo.FindAll()
+	fotos, err := fotoRepo.FindAll(c.UserContext(), f)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to get photos",
-			"error":   err.Error(),
-		})
+		return apperror.Internal("foto.list_failed", err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Photos retrieved successfully",
+		"message": i18n.Msg(c, "foto.listed"),
 		"data":    fotos,
 	})
 }
@@ -135,32 +163,28 @@ func GetAllFoto(c *fiber.Ctx) error {
 // @Param id path int true "ID foto"
 // @Param include_metadata query bool false "Tampilkan metadata tambahan (true/false)"
 // @Param view_mode query string false "Mode tampilan (contoh: thumbnail/full)"
-// @Success 200 {object} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /api/foto/{id} [get]
+// @Success 200 {object} object{success=bool,message=string,data=models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /foto/{id} [get]
 func GetFotoByID(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid ID format",
-		})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
 	fotoRepo := repository.NewFotoRepository(db.DB)
-	foto, err := fotoRepo.FindByID(id)
+	foto, err := fotoRepo.FindByID(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Photo not found",
-		})
+		return apperror.NotFound("foto.not_found")
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(foto.Version))
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Photo retrieved successfully",
+		"message": i18n.Msg(c, "foto.fetched"),
 		"data":    foto,
 	})
 }
@@ -175,34 +199,43 @@ func GetFotoByID(c *fiber.Ctx) error {
 // @Param permanent query bool false "Hapus permanen (true) atau soft delete (false)"
 // @Param reason query string false "Alasan penghapusan foto (opsional)"
 // @Param admin_id query int false "ID admin yang menghapus (opsional)"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /api/foto/{id} [delete]
+// @Param If-Match header string false "ETag versi metadata yang terakhir dibaca"
+// @Success 200 {object} object{success=bool,message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /foto/{id} [delete]
 func DeleteFoto(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"success": false,
-			"message": "Invalid ID format",
-		})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
 	fotoRepo := repository.NewFotoRepository(db.DB)
-	foto, err := fotoRepo.FindByID(id)
+	foto, err := fotoRepo.FindByID(c.UserContext(), id)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"success": false,
-			"message": "Photo not found",
-		})
+		return apperror.NotFound("foto.not_found")
 	}
 
+	// Hapus metadata dulu (dengan cek versi), file fisik hanya dihapus jika berhasil
+	if err := fotoRepo.Delete(c.UserContext(), id, ifMatch); err != nil {
+		if err == repository.ErrVersionConflict {
+			return err
+		}
+		return apperror.Internal("foto.delete_failed", err)
+	}
 	os.Remove(foto.FilePath)
-	fotoRepo.Delete(id)
+	recordAudit(c, audit.ActionHardDelete, audit.EntityFile, foto.ID, foto, nil)
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Photo deleted successfully",
+		"message": i18n.Msg(c, "foto.deleted"),
 	})
 }
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"alumniproject/app/repository/mongodb"
+	"alumniproject/config"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/export"
+	"alumniproject/utils/jobs"
+	"alumniproject/utils/jobs/handler"
+)
+
+// JobHandler -> antrian job latar belakang dan endpoint /jobs, dijalankan oleh StartJobQueue
+var JobHandler = handler.New(repository.NewJobRepo(), exportJobPayload)
+
+// StartJobQueue mendaftarkan handler job lalu menjalankan worker sampai ctx dibatalkan
+func StartJobQueue(ctx context.Context, cfg config.JobsConfig) {
+	JobHandler.Start(ctx, jobs.Config{
+		Workers:      cfg.Workers,
+		PollInterval: cfg.PollInterval,
+		MaxAttempts:  cfg.MaxAttempts,
+		ResultDir:    cfg.ResultDir,
+	}, map[string]jobs.Handler{
+		jobs.TypeExportAlumni:    runExportJob,
+		jobs.TypeExportPekerjaan: runExportJob,
+		jobs.TypeImportAlumni:    runImportAlumniJob,
+		jobs.TypePurgeTrash:      handler.PurgeTrashJob(PurgeTrash),
+		jobs.TypeTracerReport:    runTracerReportJob,
+	})
+}
+
+// WaitJobQueue menunggu worker job berhenti setelah ctx StartJobQueue dibatalkan, paling lama sampai ctx habis
+func WaitJobQueue(ctx context.Context) error {
+	return JobHandler.Wait(ctx)
+}
+
+// exportJobPayload -> payload job export dari params POST /jobs (sama dengan query export)
+func exportJobPayload(jobType, role string, raw json.RawMessage) (interface{}, error) {
+	params := exportParams{Format: "csv"}
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &params); err != nil {
+			return nil, apperror.Validation("request.invalid_params")
+		}
+	}
+	if !export.Valid(params.Format) {
+		return nil, apperror.Validation("export.format_invalid")
+	}
+	sortColumns, fields := alumniSortColumns, alumniFilterFields
+	if jobType == jobs.TypeExportPekerjaan {
+		sortColumns, fields = pekerjaanSortColumns, pekerjaanFilterFields
+	}
+	if _, err := params.filter(sortColumns, fields); err != nil {
+		return nil, apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+	// Role diambil dari token, bukan dari params
+	params.Role = role
+	return params, nil
+}
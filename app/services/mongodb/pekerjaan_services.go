@@ -1,8 +1,8 @@
 package service
 
 import (
-	"fmt"
-	"log"
+	"errors"
+	"log/slog"
 	"strings"
 	"time"
 	"strconv"
@@ -13,6 +13,15 @@ import (
 
 	"alumniproject/app/models/mongodb"
 	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/bulk"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/mergepatch"
+	"alumniproject/utils/validate"
 )
 // GetAllPekerjaanService godoc
 // @Summary Menampilkan semua data pekerjaan
@@ -24,8 +33,11 @@ import (
 // @Param sort_by query string false "Urutkan berdasarkan kolom (contoh: nama_perusahaan, tanggal_mulai_kerja)"
 // @Param order query string false "Urutan data (asc/desc)"
 // @Param limit query int false "Jumlah maksimum data (default: 10)"
-// @Success 200 {array} models.Pekerjaan
-// @Router /api/pekerjaan [get]
+// @Param filter query string false "Filter field:operator:nilai, mis. status_pekerjaan:eq:aktif,alumni_id:in:1|2"
+// @Success 200 {object} object{success=bool,count=int,data=[]models.ResponsePekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan [get]
 func GetAllPekerjaanService(c *fiber.Ctx) error {
     // implementasi asli kamu
 
@@ -33,19 +45,24 @@ func GetAllPekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	userID, ok := c.Locals("user_id").(int)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID tidak ditemukan"})
+		return apperror.Unauthorized("auth.user_id_missing")
 	}
 
 	role, ok := c.Locals("role").(string)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Role tidak ditemukan"})
+		return apperror.Unauthorized("auth.role_missing")
 	}
 
-	fmt.Printf("👤 Authenticated user: %d (role: %s)\n", userID, role)
+	slog.DebugContext(c.UserContext(), "get all pekerjaan", "user_id", userID, "role", role)
 
-	list, err := repo.GetAllPekerjaan(role, userID)
+	f, err := filter.Parse(c.Query("filter"), pekerjaanFilterFields)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	list, err := repo.GetAllPekerjaan(c.UserContext(), role, userID, f)
+	if err != nil {
+		return apperror.Internal("", err)
 	}
 
 	var filtered []map[string]interface{}
@@ -81,33 +98,34 @@ func GetAllPekerjaanService(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "ID pekerjaan"
 // @Param include_deleted query bool false "Tampilkan juga jika pekerjaan sudah dihapus (soft delete)"
-// @Success 200 {object} models.Pekerjaan
-// @Failure 404 {object} map[string]string
-// @Router /api/pekerjaan/{id} [get]
+// @Success 200 {object} object{success=bool,data=models.ResponsePekerjaan}
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [get]
 func GetPekerjaanByID(c *fiber.Ctx) error {
 	repo := repository.New()
 	id := c.Params("id")
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
 	username, _ := c.Locals("username").(string)
 	userID, _ := c.Locals("user_id").(int)
 	role, _ := c.Locals("role").(string)
 
-	log.Printf("👤 User %s (ID: %d, role: %s) akses GET /api/pekerjaan/%s", username, userID, role, id)
+	slog.DebugContext(c.UserContext(), "get pekerjaan", "username", username, "user_id", userID, "role", role, "id", id)
 
-	p, err := repo.GetByID(role, userID, id)
+	p, err := repo.GetByID(c.UserContext(), role, userID, id)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+			return apperror.NotFound("pekerjaan.not_found")
 		}
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Internal("", err)
 	}
 
 	if p == nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+		return apperror.NotFound("pekerjaan.not_found")
 	}
 
 	filtered := map[string]interface{}{
@@ -122,8 +140,10 @@ func GetPekerjaanByID(c *fiber.Ctx) error {
 		"tanggal_selesai_kerja": p.TanggalSelesaiKerja,
 		"status_pekerjaan":      p.StatusPekerjaan,
 		"deskripsi_pekerjaan":   p.DeskripsiPekerjaan,
+		"version":               p.Version,
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(p.Version))
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    filtered,
@@ -141,24 +161,22 @@ func GetPekerjaanByID(c *fiber.Ctx) error {
 // @Param limit query int false "Jumlah data per halaman (default: 10)"
 // @Param sort_by query string false "Kolom untuk sorting (contoh: nama, perusahaan)"
 // @Param order query string false "Urutan data (asc/desc)"
-// @Success 200 {array} models.AlumniPekerjaan
-// @Failure 403 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/alumni-pekerjaan [get]
+// @Success 200 {object} object{success=bool,message=string,count=int,data=[]models.AlumniWithPekerjaan}
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/alumni-pekerjaan [get]
 func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 	repo := repository.New() // ✅ pindahkan ke sini
 	username := c.Locals("username").(string)
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
-	log.Printf("User %s (ID: %d, role: %s) mengakses GET /api/pekerjaan/alumni-pekerjaan", username, userID, role)
+	slog.DebugContext(c.UserContext(), "get alumni pekerjaan", "username", username, "user_id", userID, "role", role)
 
 	isAdmin := role == "admin"
-	list, err := repo.GetAlumniWithPekerjaan(userID, isAdmin)
+	list, err := repo.GetAlumniWithPekerjaan(c.UserContext(), userID, isAdmin)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"error":   "Gagal mengambil data: " + err.Error(),
-		})
+		return apperror.Internal("data.fetch_failed", err)
 	}
 
 	if len(list) == 0 {
@@ -166,7 +184,7 @@ func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 			"success": true,
 			"count":   0,
 			"data":    []models.AlumniWithPekerjaan{},
-			"message": "Belum ada data alumni",
+			"message": i18n.Msg(c, "alumni.empty"),
 		})
 	}
 
@@ -174,7 +192,7 @@ func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 		"success": true,
 		"count":   len(list),
 		"data":    list,
-		"message": "Data alumni beserta pekerjaan berhasil diambil",
+		"message": i18n.Msg(c, "alumni.with_pekerjaan_fetched"),
 	})
 }
 
@@ -190,38 +208,39 @@ func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 // @Param order query string false "Urutan data (asc/desc)"
 // @Param page query int false "Nomor halaman (default: 1)"
 // @Param limit query int false "Jumlah data per halaman (default: 10)"
-// @Success 200 {array} models.Pekerjaan
-// @Failure 400 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/alumni/{alumni_id} [get]
+// @Success 200 {object} object{success=bool,message=string,data=[]models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/alumni/{alumni_id} [get]
 func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 	repo := repository.New() // ✅ pindahkan ke sini
 	alumniIDStr := c.Params("alumni_id")
 	alumniID, err := strconv.Atoi(alumniIDStr)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Alumni ID tidak valid"})
+		return apperror.Validation("alumni.id_invalid")
 	}
 
 	username := c.Locals("username").(string)
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
-	log.Printf("User %s (ID: %d, role: %s) mengakses GET /api/pekerjaan/alumni/%d", username, userID, role, alumniID)
+	slog.DebugContext(c.UserContext(), "get pekerjaan by alumni", "username", username, "user_id", userID, "role", role, "alumni_id", alumniID)
 
 	// ✅ Check admin (karena route sudah AdminOnly, tapi double-check)
 	if role != "admin" {
-		return c.Status(403).JSON(fiber.Map{"error": "Hanya admin yang boleh akses"})
+		return apperror.Forbidden("auth.admin_only")
 	}
 
-	list, err := repo.GetPekerjaanByAlumniID(alumniID, role, userID)
+	list, err := repo.GetPekerjaanByAlumniID(c.UserContext(), alumniID, role, userID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data pekerjaan"})
+		return apperror.Internal("pekerjaan.fetch_failed", err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    list,
-		"message": "Data pekerjaan berhasil diambil",
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
 	})
 }
 
@@ -234,32 +253,24 @@ func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 // @Produce json
 // @Param validate query bool false "Validasi data sebelum insert (true/false)"
 // @Param body body models.CreatePekerjaanRequest true "Data pekerjaan baru"
-// @Success 201 {object} models.ResponsePekerjaan
-// @Router /api/pekerjaan [post]
+// @Success 200 {object} object{success=bool,data=models.ResponsePekerjaan}
+// @Failure 400 {object} apperror.Problem "Field tidak valid, detail per field di errors"
+// @Security BearerAuth
+// @Router /pekerjaan [post]
 func CreatePekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	userID := c.Locals("user_id").(int)
 	var req models.CreatePekerjaanRequest
 
-	// Parsing request body JSON
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	// Parsing & validasi request body JSON
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	// Parsing tanggal mulai
-	tMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+	// Parsing tanggal mulai & selesai (opsional)
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Tanggal mulai tidak valid"})
-	}
-
-	// Parsing tanggal selesai (opsional)
-	var tSelesai *time.Time
-	if req.TanggalSelesaiKerja != "" {
-		t, err := time.Parse("2006-01-02", req.TanggalSelesaiKerja)
-		if err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "Tanggal selesai tidak valid"})
-		}
-		tSelesai = &t
+		return apperror.Invalid(err)
 	}
 
 	// Membuat object pekerjaan
@@ -278,10 +289,11 @@ func CreatePekerjaanService(c *fiber.Ctx) error {
 	}
 
 	// Insert ke repository
-	if err := repo.CreatePekerjaan(p); err != nil {
-		log.Printf("❌ Create error: %v", err)
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal membuat pekerjaan"})
+	if err := repo.CreatePekerjaan(c.UserContext(), p); err != nil {
+		slog.ErrorContext(c.UserContext(), "create pekerjaan gagal", "error", err)
+		return apperror.Internal("pekerjaan.create_failed", err)
 	}
+	recordAudit(c, audit.ActionCreate, audit.EntityPekerjaan, p.ID.Hex(), nil, p)
 
 	// Mapping ke ResponsePekerjaan
 	res := models.ResponsePekerjaan{
@@ -314,9 +326,13 @@ func CreatePekerjaanService(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "ID pekerjaan"
 // @Param notify query bool false "Kirim notifikasi ke alumni (true/false)"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
 // @Param body body models.UpdatePekerjaanRequest true "Data pekerjaan yang akan diupdate"
-// @Success 200 {object} map[string]interface{}
-// @Router /api/pekerjaan/{id} [put]
+// @Success 200 {object} object{success=bool,message=string}
+// @Failure 400 {object} apperror.Problem "Field tidak valid, detail per field di errors"
+// @Failure 412 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [put]
 func UpdatePekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	id := c.Params("id")
@@ -324,26 +340,22 @@ func UpdatePekerjaanService(c *fiber.Ctx) error {
 	role := c.Locals("role").(string)
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	var req models.UpdatePekerjaanRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
-	tMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
-	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Tanggal mulai tidak valid"})
+	var req models.UpdatePekerjaanRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	var tSelesai *time.Time
-	if req.TanggalSelesaiKerja != "" {
-		t, err := time.Parse("2006-01-02", req.TanggalSelesaiKerja)
-		if err != nil {
-			return c.Status(400).JSON(fiber.Map{"error": "Tanggal selesai tidak valid"})
-		}
-		tSelesai = &t
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
 	p := &models.Pekerjaan{
@@ -358,13 +370,21 @@ func UpdatePekerjaanService(c *fiber.Ctx) error {
 		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
 	}
 
-	if err := repo.UpdatePekerjaan(id, p, role, userID); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	// Data lama hanya untuk audit log
+	before, _ := repo.GetByID(c.UserContext(), role, userID, id)
+
+	if err := repo.UpdatePekerjaan(c.UserContext(), id, p, role, userID, ifMatch); err != nil {
+		if err == repository.ErrVersionConflict {
+			return err
+		}
+		return apperror.Internal("", err)
 	}
+	recordAudit(c, audit.ActionUpdate, audit.EntityPekerjaan, id, pekerjaanAuditFields(before), pekerjaanAuditFields(p))
 
+	c.Set(fiber.HeaderETag, etag.Format(p.Version))
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Pekerjaan berhasil diupdate",
+		"message": i18n.Msg(c, "pekerjaan.updated"),
 	})
 }
 
@@ -377,10 +397,13 @@ func UpdatePekerjaanService(c *fiber.Ctx) error {
 // @Param id path string true "ID pekerjaan"
 // @Param permanent query bool false "Hapus permanen (true) atau soft delete (false)"
 // @Param reason query string false "Alasan penghapusan (opsional)"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/{id} [delete]
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [delete]
 func DeletePekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	id := c.Params("id")
@@ -388,16 +411,26 @@ func DeletePekerjaanService(c *fiber.Ctx) error {
 	role := c.Locals("role").(string)
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	err := repo.SoftDeletePekerjaan(id, userID, role)
+	ifMatch, err := ifMatchVersion(c)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Invalid(err)
 	}
 
+	err = repo.SoftDeletePekerjaan(c.UserContext(), id, userID, role, ifMatch)
+	if err == repository.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionDelete, audit.EntityPekerjaan, id,
+		fiber.Map{"deleted_at": nil}, fiber.Map{"deleted_at": time.Now(), "deleted_by": userID})
+
 	return c.JSON(fiber.Map{
-		"message": "Riwayat pekerjaan berhasil dihapus (soft delete)",
+		"message": i18n.Msg(c, "pekerjaan.deleted"),
 	})
 }
 
@@ -409,23 +442,26 @@ func DeletePekerjaanService(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path string true "ID pekerjaan"
 // @Param notify query bool false "Kirim notifikasi ke user terkait (true/false)"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/{id}/restore [post]
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id}/restore [post]
 func RestorePekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	id := c.Params("id")
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	if err := repo.RestorePekerjaan(id); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	if err := repo.RestorePekerjaan(c.UserContext(), id); err != nil {
+		return apperror.Internal("", err)
 	}
+	recordAudit(c, audit.ActionRestore, audit.EntityPekerjaan, id,
+		fiber.Map{"in_trash": true}, fiber.Map{"in_trash": false})
 
-	return c.JSON(fiber.Map{"message": "Data berhasil direstore"})
+	return c.JSON(fiber.Map{"message": i18n.Msg(c, "data.restored")})
 }
 
 // HardDeletePekerjaanService godoc
@@ -437,24 +473,37 @@ func RestorePekerjaanService(c *fiber.Ctx) error {
 // @Param id path string true "ID pekerjaan"
 // @Param confirm query bool true "Konfirmasi hapus permanen (true untuk melanjutkan)"
 // @Param admin_reason query string false "Alasan admin menghapus data ini (opsional)"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/{id}/hard [delete]
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id}/hard [delete]
 func HardDeletePekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	id := c.Params("id")
 
 	if _, err := primitive.ObjectIDFromHex(id); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	if err := repo.HardDeletePekerjaanByID(id); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
+	if err := repo.HardDeletePekerjaanByID(c.UserContext(), id, ifMatch); err != nil {
+		if err == repository.ErrVersionConflict {
+			return err
+		}
+		return apperror.Internal("", err)
+	}
+	recordAudit(c, audit.ActionHardDelete, audit.EntityPekerjaan, id,
+		fiber.Map{"in_trash": true}, fiber.Map{"in_trash": false})
+
 	return c.JSON(fiber.Map{
-		"message": fmt.Sprintf("Data dengan ID %s dihapus permanen", id),
+		"message": i18n.Msg(c, "data.hard_deleted", id),
 	})
 }
 
@@ -468,17 +517,18 @@ func HardDeletePekerjaanService(c *fiber.Ctx) error {
 // @Param limit query int false "Jumlah data per halaman (default: 10)"
 // @Param search query string false "Kata kunci pencarian (nama perusahaan / posisi)"
 // @Param order query string false "Urutan data (asc/desc)"
-// @Success 200 {array} models.GetTrashPekerjaan
-// @Failure 500 {object} map[string]string
-// @Router /api/pekerjaan/trash [get]
+// @Success 200 {object} object{success=bool,count=int,data=[]models.GetTrashPekerjaan}
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/trash [get]
 func GetTrashPekerjaanService(c *fiber.Ctx) error {
 	repo := repository.New()
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	allTrash, err := repo.GetTrashPekerjaan(userID, role)
+	allTrash, err := repo.GetTrashPekerjaan(c.UserContext(), userID, role)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil trash"})
+		return apperror.Internal("trash.fetch_failed", err)
 	}
 
 	// Konversi ObjectID ke string (hex)
@@ -494,6 +544,7 @@ func GetTrashPekerjaanService(c *fiber.Ctx) error {
 			"status_pekerjaan": t.StatusPekerjaan,
 			"deleted_at":       t.DeletedAt,
 			"created_by":       t.CreatedBy,
+			"deleted_by":       t.DeletedBy,
 		})
 	}
 
@@ -506,9 +557,76 @@ func GetTrashPekerjaanService(c *fiber.Ctx) error {
 
 
 
+// pekerjaanSortColumns -> kolom pekerjaan yang boleh dipakai untuk sorting (list & export)
+var pekerjaanSortColumns = map[string]bool{
+	"_id":                 true,
+	"nama_perusahaan":     true,
+	"posisi_jabatan":      true,
+	"tanggal_mulai_kerja": true,
+	"created_at":          true,
+}
+
+// pekerjaanFilterFields -> field pekerjaan yang boleh dipakai di ?filter=
+var pekerjaanFilterFields = filter.Schema{
+	"alumni_id":             {Kind: filter.Int, Key: "alumni_id"},
+	"nama_perusahaan":       {Kind: filter.String, Key: "nama_perusahaan"},
+	"posisi_jabatan":        {Kind: filter.String, Key: "posisi_jabatan"},
+	"bidang_industri":       {Kind: filter.String, Key: "bidang_industri"},
+	"lokasi_kerja":          {Kind: filter.String, Key: "lokasi_kerja"},
+	"gaji_range":            {Kind: filter.String, Key: "gaji_range"},
+	"status_pekerjaan":      {Kind: filter.String, Key: "status_pekerjaan"},
+	"tanggal_mulai_kerja":   {Kind: filter.Time, Key: "tanggal_mulai_kerja"},
+	"tanggal_selesai_kerja": {Kind: filter.Time, Key: "tanggal_selesai_kerja"},
+	"created_at":            {Kind: filter.Time, Key: "created_at"},
+}
+
+// keysetParams membaca ?cursor= dan ?limit= untuk mode cursor. Sort & order diambil dari cursor jika ada,
+// cursor dengan kolom sort di luar whitelist ditolak.
+func keysetParams(c *fiber.Ctx, sortColumns map[string]bool, defaultSort, defaultOrder string) (cur *cursor.Cursor, sortBy, order string, limit int, err error) {
+	limit, _ = strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	if cur, err = cursor.Decode(c.Query("cursor")); err != nil {
+		return nil, "", "", 0, err
+	}
+
+	sortBy, order = c.Query("sort_by", defaultSort), strings.ToLower(c.Query("order", defaultOrder))
+	if cur != nil {
+		sortBy, order = cur.SortBy, cur.Order
+	}
+	if !sortColumns[sortBy] {
+		if cur != nil {
+			return nil, "", "", 0, repository.ErrInvalidCursor
+		}
+		sortBy = "_id"
+	}
+	if order != "desc" {
+		order = "asc"
+	}
+	return cur, sortBy, order, limit, nil
+}
+
+// pekerjaanSortValue -> nilai kolom sort satu pekerjaan untuk cursor
+func pekerjaanSortValue(p *models.Pekerjaan, sortBy string) interface{} {
+	switch sortBy {
+	case "nama_perusahaan":
+		return p.NamaPerusahaan
+	case "posisi_jabatan":
+		return p.PosisiJabatan
+	case "tanggal_mulai_kerja":
+		return p.TanggalMulaiKerja
+	case "created_at":
+		return p.CreatedAt
+	default:
+		return p.ID
+	}
+}
+
 // GetPekerjaanPaginated godoc
 // @Summary Menampilkan data pekerjaan dengan pagination
-// @Description Mengambil data pekerjaan dengan pagination, sorting, dan search
+// @Description Mengambil data pekerjaan dengan pagination, sorting, dan search.
+// @Description Jika ada ?cursor= (kosong untuk halaman pertama) dipakai keyset pagination: meta berisi next_cursor/prev_cursor, total tidak dihitung.
 // @Tags Pekerjaan
 // @Accept json
 // @Produce json
@@ -518,10 +636,18 @@ func GetTrashPekerjaanService(c *fiber.Ctx) error {
 // @Param order query string false "Urutan sort asc/desc (default: desc)"
 // @Param search query string false "Kata kunci pencarian"
 // @Param only_active query bool false "Tampilkan hanya pekerjaan aktif"
-// @Success 200 {object} models.PekerjaanResponse
-// @Router /api/pekerjaan/paginated [get]
+// @Param filter query string false "Filter field:operator:nilai, mis. status_pekerjaan:eq:aktif,tanggal_mulai_kerja:gte:2020-01-01"
+// @Param cursor query string false "Cursor dari next_cursor/prev_cursor (mode keyset)"
+// @Success 200 {object} object{success=bool,data=models.PekerjaanResponse}
+// @Failure 400 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/paginated [get]
 func GetPekerjaanPaginated(c *fiber.Ctx) error {
 	repo := repository.New()
+	if c.Context().QueryArgs().Has("cursor") {
+		return getPekerjaanKeyset(c, repo)
+	}
+
 	pageStr := c.Query("page", "1")
 	limitStr := c.Query("limit", "5")
 	sortBy := c.Query("sort_by", "created_at")
@@ -538,15 +664,7 @@ func GetPekerjaanPaginated(c *fiber.Ctx) error {
 	}
 	offset := (page - 1) * limit
 
-	// whitelist kolom yang boleh di-sort
-	sortByWhitelist := map[string]bool{
-		"_id":                 true,
-		"nama_perusahaan":     true,
-		"posisi_jabatan":      true,
-		"tanggal_mulai_kerja": true,
-		"created_at":          true,
-	}
-	if _, ok := sortByWhitelist[sortBy]; !ok {
+	if !pekerjaanSortColumns[sortBy] {
 		sortBy = "_id"
 	}
 
@@ -555,18 +673,23 @@ func GetPekerjaanPaginated(c *fiber.Ctx) error {
 		order = "asc"
 	}
 
+	f, err := filter.Parse(c.Query("filter"), pekerjaanFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
 	// ambil data dari repository
-	pekerjaanList, err := repo.GetPekerjaanPaginated(search, sortBy, order, limit, offset, role, userID)
+	pekerjaanList, err := repo.GetPekerjaanPaginated(c.UserContext(), search, sortBy, order, limit, offset, role, userID, f)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Internal("", err)
 	}
 
-	total, err := repo.CountPekerjaan(search, role, userID)
+	total, err := repo.CountPekerjaan(c.UserContext(), search, role, userID, f)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Internal("", err)
 	}
 
 	// langsung pakai ObjectID tanpa convert
@@ -580,6 +703,7 @@ func GetPekerjaanPaginated(c *fiber.Ctx) error {
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
+			Filter: f.String(),
 		},
 	}
 
@@ -589,8 +713,384 @@ func GetPekerjaanPaginated(c *fiber.Ctx) error {
 	})
 }
 
+// getPekerjaanKeyset -> GetPekerjaanPaginated mode cursor
+func getPekerjaanKeyset(c *fiber.Ctx, repo *repository.PekerjaanMongoRepo) error {
+	cur, sortBy, order, limit, err := keysetParams(c, pekerjaanSortColumns, "created_at", "desc")
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidCursor)
+	}
+	search := c.Query("search", "")
+
+	f, err := filter.Parse(c.Query("filter"), pekerjaanFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	list, err := repo.GetPekerjaanKeyset(c.UserContext(), search, sortBy, order, limit+1, cur, role, userID, f)
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	list, hasMore := cursor.Trim(list, limit, cur)
+	next, prev := cursor.Links(list, hasMore, cur, sortBy, order, func(p *models.Pekerjaan) (interface{}, interface{}) {
+		return pekerjaanSortValue(p, sortBy), p.ID
+	})
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": &models.PekerjaanResponse{
+			Data: list,
+			Meta: &models.MetaInfo{
+				Limit:      limit,
+				SortBy:     sortBy,
+				Order:      order,
+				Search:     search,
+				Filter:     f.String(),
+				NextCursor: next,
+				PrevCursor: prev,
+			},
+		},
+	})
+}
+
+
+
+
+// pekerjaanFromCreateRequest memvalidasi satu item bulk create
+func pekerjaanFromCreateRequest(req models.CreatePekerjaanRequest, userID int) (*models.Pekerjaan, error) {
+	if err := validate.Check(&req); err != nil {
+		return nil, err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Pekerjaan{
+		AlumniID:            req.AlumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalMulaiKerja:   tMulai,
+		TanggalSelesaiKerja: tSelesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		CreatedBy:           userID,
+	}, nil
+}
+
+// pekerjaanFromBulkUpdateItem memvalidasi satu item bulk update
+func pekerjaanFromBulkUpdateItem(item models.BulkUpdatePekerjaanItem) (*models.Pekerjaan, error) {
+	if err := validate.Check(&item); err != nil {
+		return nil, err
+	}
+	objID, err := primitive.ObjectIDFromHex(item.ID)
+	if err != nil {
+		return nil, apperror.Validation("invalid_id")
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(item.TanggalMulaiKerja, item.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Pekerjaan{
+		ID:                  objID,
+		NamaPerusahaan:      item.NamaPerusahaan,
+		PosisiJabatan:       item.PosisiJabatan,
+		BidangIndustri:      item.BidangIndustri,
+		LokasiKerja:         item.LokasiKerja,
+		GajiRange:           item.GajiRange,
+		TanggalMulaiKerja:   tMulai,
+		TanggalSelesaiKerja: tSelesai,
+		StatusPekerjaan:     item.StatusPekerjaan,
+		DeskripsiPekerjaan:  item.DeskripsiPekerjaan,
+		Version:             item.Version,
+	}, nil
+}
+
+// runBulkPekerjaan menjalankan bulk.Run untuk bulk create/update lalu membentuk response per item.
+// save mengembalikan data lama per item untuk audit (nil pada bulk create).
+func runBulkPekerjaan(c *fiber.Ctx, action, mode string, atomic bool, n int,
+	parseItem func(i int) (*models.Pekerjaan, error),
+	save func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error),
+) error {
+	results := make([]models.BulkItemResult, n)
+	var before []*models.Pekerjaan
+	items, err := bulk.Run(i18n.Lang(c), atomic, n, parseItem,
+		func(list []*models.Pekerjaan) (errs []error, err error) {
+			before, errs, err = save(list)
+			return errs, err
+		},
+		func(i, j int, p *models.Pekerjaan) {
+			results[i].ID = p.ID.Hex()
+			var old *models.Pekerjaan
+			if before != nil {
+				old = before[j]
+			}
+			recordAudit(c, action, audit.EntityPekerjaan, results[i].ID, pekerjaanAuditFields(old), pekerjaanAuditFields(p))
+		},
+	)
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "bulk pekerjaan gagal", "error", err)
+		return apperror.Internal("bulk.save_failed", err)
+	}
+
+	for i, it := range items {
+		results[i].Index, results[i].Success, results[i].Error = it.Index, it.Success, it.Error
+		results[i].Status, results[i].Code = it.Status, it.Code
+	}
+	response := &models.BulkResponse{Mode: mode, Total: n, Results: results}
+	response.SuccessCount, response.FailedCount = bulk.Count(items)
+	if atomic && response.FailedCount > 0 {
+		return c.Status(400).JSON(response)
+	}
+	return c.JSON(response)
+}
+
+// BulkCreatePekerjaanService godoc
+// @Summary Tambah banyak data pekerjaan sekaligus
+// @Description Membuat banyak data pekerjaan dalam satu request. Mode all_or_nothing memakai transaksi MongoDB (semua berhasil atau semua batal), mode best_effort menyimpan item yang valid saja.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param mode query string false "Mode bulk (all_or_nothing/best_effort, default: all_or_nothing)"
+// @Param body body []models.CreatePekerjaanRequest true "Daftar pekerjaan baru"
+// @Success 200 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkResponse
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/bulk [post]
+func BulkCreatePekerjaanService(c *fiber.Ctx) error {
+	repo := repository.New()
+	userID := c.Locals("user_id").(int)
+
+	mode := c.Query("mode", bulk.ModeAllOrNothing)
+	atomic, err := bulk.ParseMode(mode)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var reqs []models.CreatePekerjaanRequest
+	if err := c.BodyParser(&reqs); err != nil {
+		return apperror.Validation("bulk.array_required")
+	}
+	if len(reqs) == 0 || len(reqs) > bulk.MaxItems {
+		return apperror.Validation("bulk.item_count").WithArgs(bulk.MaxItems)
+	}
+
+	return runBulkPekerjaan(c, audit.ActionCreate, mode, atomic, len(reqs),
+		func(i int) (*models.Pekerjaan, error) {
+			return pekerjaanFromCreateRequest(reqs[i], userID)
+		},
+		func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error) {
+			errs, err := repo.BulkCreatePekerjaan(c.UserContext(), list, atomic)
+			return nil, errs, err
+		},
+	)
+}
+
+// BulkUpdatePekerjaanService godoc
+// @Summary Update banyak data pekerjaan sekaligus
+// @Description Mengubah banyak data pekerjaan dalam satu request. Non-admin hanya bisa mengubah data miliknya sendiri.
+// @Description Item yang menyertakan version hanya diubah jika versinya masih sama; jika tidak, item gagal dengan status 412 dan code version_conflict.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param mode query string false "Mode bulk (all_or_nothing/best_effort, default: all_or_nothing)"
+// @Param body body []models.BulkUpdatePekerjaanItem true "Daftar pekerjaan yang akan diupdate (wajib menyertakan id)"
+// @Success 200 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkResponse
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/bulk [patch]
+func BulkUpdatePekerjaanService(c *fiber.Ctx) error {
+	repo := repository.New()
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	mode := c.Query("mode", bulk.ModeAllOrNothing)
+	atomic, err := bulk.ParseMode(mode)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var items []models.BulkUpdatePekerjaanItem
+	if err := c.BodyParser(&items); err != nil {
+		return apperror.Validation("bulk.array_required")
+	}
+	if len(items) == 0 || len(items) > bulk.MaxItems {
+		return apperror.Validation("bulk.item_count").WithArgs(bulk.MaxItems)
+	}
+
+	return runBulkPekerjaan(c, audit.ActionUpdate, mode, atomic, len(items),
+		func(i int) (*models.Pekerjaan, error) {
+			return pekerjaanFromBulkUpdateItem(items[i])
+		},
+		func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error) {
+			return repo.BulkUpdatePekerjaan(c.UserContext(), list, atomic, role, userID)
+		},
+	)
+}
+
+// isMergePatchRequest memastikan body PATCH dikirim sebagai JSON Merge Patch (atau JSON biasa)
+// pekerjaanAuditFields -> field pekerjaan yang dicatat di audit log (tanpa _id & timestamp internal)
+func pekerjaanAuditFields(p *models.Pekerjaan) *models.ResponsePekerjaan {
+	if p == nil {
+		return nil
+	}
+	return &models.ResponsePekerjaan{
+		ID:                  p.ID.Hex(),
+		AlumniID:            p.AlumniID,
+		NamaPerusahaan:      p.NamaPerusahaan,
+		PosisiJabatan:       p.PosisiJabatan,
+		BidangIndustri:      p.BidangIndustri,
+		LokasiKerja:         p.LokasiKerja,
+		GajiRange:           p.GajiRange,
+		TanggalMulaiKerja:   p.TanggalMulaiKerja,
+		TanggalSelesaiKerja: p.TanggalSelesaiKerja,
+		StatusPekerjaan:     p.StatusPekerjaan,
+		DeskripsiPekerjaan:  p.DeskripsiPekerjaan,
+		Version:             p.Version,
+	}
+}
+
+// ifMatchVersion membaca header If-Match; 0 berarti client tidak meminta pengecekan versi
+func ifMatchVersion(c *fiber.Ctx) (int, error) {
+	return etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+}
+
+func isMergePatchRequest(c *fiber.Ctx) bool {
+	ct := strings.ToLower(c.Get("Content-Type"))
+	return strings.HasPrefix(ct, mergepatch.ContentType) || strings.HasPrefix(ct, fiber.MIMEApplicationJSON)
+}
 
+// pekerjaanToUpdateRequest mengubah data pekerjaan menjadi dokumen yang bisa di-patch
+func pekerjaanToUpdateRequest(p *models.Pekerjaan) models.UpdatePekerjaanRequest {
+	req := models.UpdatePekerjaanRequest{
+		NamaPerusahaan:     p.NamaPerusahaan,
+		PosisiJabatan:      p.PosisiJabatan,
+		BidangIndustri:     p.BidangIndustri,
+		LokasiKerja:        p.LokasiKerja,
+		GajiRange:          p.GajiRange,
+		TanggalMulaiKerja:  p.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    p.StatusPekerjaan,
+		DeskripsiPekerjaan: p.DeskripsiPekerjaan,
+	}
+	if p.TanggalSelesaiKerja != nil {
+		req.TanggalSelesaiKerja = p.TanggalSelesaiKerja.Format("2006-01-02")
+	}
+	return req
+}
+
+// PatchPekerjaanService godoc
+// @Summary Update sebagian data pekerjaan (JSON Merge Patch)
+// @Description Mengubah hanya field yang dikirim (RFC 7396). Field bernilai null akan dikosongkan. Hasil merge tetap divalidasi.
+// @Tags Pekerjaan
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "ID pekerjaan"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdatePekerjaanRequest true "Field pekerjaan yang akan diubah"
+// @Success 200 {object} object{success=bool,message=string,data=models.ResponsePekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 415 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [patch]
+func PatchPekerjaanService(c *fiber.Ctx) error {
+	repo := repository.New()
+	id := c.Params("id")
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
 
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+
+	if !isMergePatchRequest(c) {
+		return apperror.UnsupportedMediaType("request.content_type").WithArgs(mergepatch.ContentType)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	current, err := repo.GetByID(c.UserContext(), role, userID, id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if current == nil {
+		return apperror.NotFound("pekerjaan.not_found")
+	}
+	if ifMatch > 0 && ifMatch != current.Version {
+		return repository.ErrVersionConflict
+	}
+
+	var req models.UpdatePekerjaanRequest
+	if err := mergepatch.ApplyTo(pekerjaanToUpdateRequest(current), c.Body(), &req); err != nil {
+		return apperror.Invalid(err)
+	}
+
+	// Validasi hasil merge, bukan hanya isi patch
+	if err := validate.Check(&req); err != nil {
+		return err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	p := &models.Pekerjaan{
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalMulaiKerja:   tMulai,
+		TanggalSelesaiKerja: tSelesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+	}
+
+	// Versi yang dibaca di atas ikut dicek saat update, agar perubahan lain di antaranya tidak tertimpa
+	if err := repo.UpdatePekerjaan(c.UserContext(), id, p, role, userID, current.Version); err != nil {
+		if err == repository.ErrVersionConflict {
+			return err
+		}
+		return apperror.Internal("", err)
+	}
+	p.AlumniID = current.AlumniID
+	recordAudit(c, audit.ActionUpdate, audit.EntityPekerjaan, id, pekerjaanAuditFields(current), pekerjaanAuditFields(p))
+
+	c.Set(fiber.HeaderETag, etag.Format(p.Version))
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "pekerjaan.updated"),
+		"data": models.ResponsePekerjaan{
+			ID:                  id,
+			AlumniID:            current.AlumniID,
+			NamaPerusahaan:      p.NamaPerusahaan,
+			PosisiJabatan:       p.PosisiJabatan,
+			BidangIndustri:      p.BidangIndustri,
+			LokasiKerja:         p.LokasiKerja,
+			GajiRange:           p.GajiRange,
+			TanggalMulaiKerja:   p.TanggalMulaiKerja,
+			TanggalSelesaiKerja: p.TanggalSelesaiKerja,
+			StatusPekerjaan:     p.StatusPekerjaan,
+			DeskripsiPekerjaan:  p.DeskripsiPekerjaan,
+			Version:             p.Version,
+		},
+	})
+}
 
 // Login: Asumsi sudah ada di service, tapi jika perlu tambah
 // func Login(c *fiber.Ctx) error { ... } // Implement jika belum
\ No newline at end of file
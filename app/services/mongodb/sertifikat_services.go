@@ -8,6 +8,12 @@ import (
     db "alumniproject/database/mongodb"
     models "alumniproject/app/models/mongodb"
     repo "alumniproject/app/repository/mongodb"
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/audit"
+    "alumniproject/utils/etag"
+    "alumniproject/utils/filter"
+    "alumniproject/utils/i18n"
+    "alumniproject/utils/metrics"
     "github.com/gofiber/fiber/v2"
     "github.com/google/uuid"
 )
@@ -28,31 +34,30 @@ func NewSertifikatService(repo repo.FileRepository, path string) *SertifikatServ
 // @Accept multipart/form-data
 // @Produce json
 // @Param sertifikat formData file true "File sertifikat (PDF, max 2MB)"
-// @Success 200 {object} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sertifikat/upload [post]
+// @Param alumni_id formData string false "ID alumni pemilik file (ikut terhapus saat alumni dihapus)"
+// @Success 200 {object} object{success=bool,message=string,data=models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /sertifikat/upload [post]
 func (s *SertifikatService) UploadSertifikat(c *fiber.Ctx) error {
     fileHeader, err := c.FormFile("sertifikat")
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "No file uploaded",
-        })
+        return apperror.Validation("file.required")
     }
+    metrics.AddUploadBytes("sertifikat", fileHeader.Size)
 
     if fileHeader.Header.Get("Content-Type") != "application/pdf" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Only PDF allowed",
-        })
+        return apperror.Validation("file.pdf_only")
     }
 
     if fileHeader.Size > 2*1024*1024 {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Max file size 2MB",
-        })
+        return apperror.Validation("file.too_large").WithArgs("2MB")
+    }
+
+    alumniID, err := parseFileAlumniID(c)
+    if err != nil {
+        return apperror.Invalid(err)
     }
 
     os.MkdirAll(s.path, os.ModePerm)
@@ -60,10 +65,7 @@ func (s *SertifikatService) UploadSertifikat(c *fiber.Ctx) error {
     filePath := filepath.Join(s.path, newFileName)
 
     if err := c.SaveFile(fileHeader, filePath); err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Failed to save file",
-        })
+        return apperror.Internal("file.save_failed", err)
     }
 
     fileModel := &models.File{
@@ -72,19 +74,18 @@ func (s *SertifikatService) UploadSertifikat(c *fiber.Ctx) error {
         FilePath:     filePath,
         FileSize:     fileHeader.Size,
         FileType:     "application/pdf",
+        AlumniID:     alumniID,
     }
 
-    if err := s.repo.Create(fileModel); err != nil {
+    if err := s.repo.Create(c.UserContext(), fileModel); err != nil {
         os.Remove(filePath)
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Failed to save metadata",
-        })
+        return apperror.Internal("file.metadata_failed", err)
     }
+    recordAudit(c, audit.ActionCreate, audit.EntityFile, fileModel.ID, nil, fileModel)
 
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Sertifikat uploaded successfully",
+        "message": i18n.Msg(c, "sertifikat.uploaded"),
         "data":    fileModel,
     })
 }
@@ -99,10 +100,12 @@ func (s *SertifikatService) UploadSertifikat(c *fiber.Ctx) error {
 // @Param limit query int false "Batas jumlah data yang diambil (default 10)"
 // @Param sort query string false "Urutkan berdasarkan field (contoh: uploaded_at, file_name)"
 // @Param order query string false "Urutan data (asc / desc)"
-// @Success 200 {array} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /api/sertifikat [get]
+// @Param filter query string false "Filter field:operator:nilai, mis. uploaded_at:gte:2024-01-01,file_type:eq:application/pdf"
+// @Success 200 {object} object{success=bool,message=string,count=int,sort_by=string,order=string,data=[]models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /sertifikat [get]
 func GetAllSertifikat(c *fiber.Ctx) error {
     repo := repo.NewFileRepository(db.DB)
 
@@ -117,13 +120,15 @@ func GetAllSertifikat(c *fiber.Ctx) error {
         limit = 10
     }
 
+    fl, err := filter.Parse(c.Query("filter"), fileFilterFields)
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+    }
+
     // Ambil semua data
-    files, err := repo.FindAll()
+    files, err := repo.FindAll(c.UserContext(), fl)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "success": false,
-            "message": "Failed to get certificates",
-        })
+        return apperror.Internal("sertifikat.list_failed", err)
     }
 
     // Filter sederhana berdasarkan nama file (kalau search diisi)
@@ -142,7 +147,7 @@ func GetAllSertifikat(c *fiber.Ctx) error {
 
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Certificates retrieved successfully",
+        "message": i18n.Msg(c, "sertifikat.listed"),
         "count":   len(filtered),
         "sort_by": sortField,
         "order":   order,
@@ -159,32 +164,28 @@ func GetAllSertifikat(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path int true "ID sertifikat"
 // @Param include_deleted query bool false "Tampilkan juga sertifikat yang sudah dihapus"
-// @Success 200 {object} models.FileResponse
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /api/sertifikat/{id} [get]
+// @Success 200 {object} object{success=bool,message=string,data=models.File}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /sertifikat/{id} [get]
 func GetSertifikatByID(c *fiber.Ctx) error {
     idParam := c.Params("id")
     id, err := strconv.ParseInt(idParam, 10, 64)
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Invalid ID format",
-        })
+        return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
     }
 
     repo := repo.NewFileRepository(db.DB)
-    file, err := repo.FindByID(id)
+    file, err := repo.FindByID(c.UserContext(), id)
     if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Certificate not found",
-        })
+        return apperror.NotFound("sertifikat.not_found")
     }
 
+    c.Set(fiber.HeaderETag, etag.Format(file.Version))
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Certificate retrieved successfully",
+        "message": i18n.Msg(c, "sertifikat.fetched"),
         "data":    file,
     })
 }
@@ -197,34 +198,43 @@ func GetSertifikatByID(c *fiber.Ctx) error {
 // @Produce json
 // @Param id path int true "ID sertifikat"
 // @Param force query bool false "Hapus permanen (true) atau hanya tandai sebagai deleted"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Router /api/sertifikat/{id} [delete]
+// @Param If-Match header string false "ETag versi metadata yang terakhir dibaca"
+// @Success 200 {object} object{success=bool,message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /sertifikat/{id} [delete]
 func DeleteSertifikat(c *fiber.Ctx) error {
     idParam := c.Params("id")
     id, err := strconv.ParseInt(idParam, 10, 64)
     if err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "success": false,
-            "message": "Invalid ID format",
-        })
+        return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
     }
 
-    repo := repo.NewFileRepository(db.DB)
-    file, err := repo.FindByID(id)
+    ifMatch, err := ifMatchVersion(c)
+    if err != nil {
+        return apperror.Invalid(err)
+    }
+
+    fileRepo := repo.NewFileRepository(db.DB)
+    file, err := fileRepo.FindByID(c.UserContext(), id)
     if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "success": false,
-            "message": "Certificate not found",
-        })
+        return apperror.NotFound("sertifikat.not_found")
     }
 
+    // Hapus metadata dulu (dengan cek versi), file fisik hanya dihapus jika berhasil
+    if err := fileRepo.Delete(c.UserContext(), id, ifMatch); err != nil {
+        if err == repo.ErrVersionConflict {
+            return err
+        }
+        return apperror.Internal("sertifikat.delete_failed", err)
+    }
     os.Remove(file.FilePath)
-    repo.Delete(id)
+    recordAudit(c, audit.ActionHardDelete, audit.EntityFile, file.ID, file, nil)
 
     return c.JSON(fiber.Map{
         "success": true,
-        "message": "Certificate deleted successfully",
+        "message": i18n.Msg(c, "sertifikat.deleted"),
     })
 }
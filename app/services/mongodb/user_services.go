@@ -1,11 +1,19 @@
 package service
 
 import (
+	"strconv"
+	"strings"
+	"time"
 	"alumniproject/app/models/mongodb"
 	"alumniproject/app/repository/mongodb"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/i18n"
 	mongodbutils "alumniproject/utils/mongodb"
-	"fmt"
+	"log/slog"
 
+	"alumniproject/utils/audit"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/validate"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -15,51 +23,48 @@ import (
 // @Tags Auth
 // @Accept json
 // @Produce json
-// @Param lang query string false "Bahasa respon (contoh: id atau en)"
+// @Param lang query string false "Bahasa respon: id atau en (mengalahkan header Accept-Language)"
 // @Param remember query bool false "Login dengan mode remember (persistent)"
 // @Param body body models.LoginRequest true "Data login user"
-// @Success 200 {object} models.LoginResponse
-// @Failure 400 {object} map[string]string "Request body tidak valid atau kosong"
-// @Failure 401 {object} map[string]string "Username atau password salah"
-// @Failure 500 {object} map[string]string "Gagal generate token"
-// @Router /api/login [post]
+// @Success 200 {object} object{success=bool,message=string,lang=string,remember=string,data=models.LoginResponse}
+// @Failure 400 {object} apperror.Problem "Request body tidak valid atau kosong"
+// @Failure 401 {object} apperror.Problem "Username atau password salah"
+// @Failure 500 {object} apperror.Problem "Gagal generate token"
+// @Router /login [post]
 func Login(c *fiber.Ctx) error {
-	fmt.Println("✅ Route /login terpanggil")
-
 	// ambil query parameter opsional
-	lang := c.Query("lang", "id")
+	lang := i18n.Lang(c)
 	remember := c.Query("remember", "false")
-	fmt.Printf("🌐 Language: %s | Remember: %s\n", lang, remember)
+	slog.DebugContext(c.UserContext(), "login", "lang", lang, "remember", remember)
 
 	var req models.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Request body tidak valid"})
-	}
-
-	if req.Username == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Username dan password harus diisi"})
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	user, passwordHash, err := repository.GetUserByUsernameOrEmail(req.Username)
+	user, passwordHash, err := repository.GetUserByUsernameOrEmail(c.UserContext(), req.Username)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"error": "Username atau password salah"})
+		return apperror.Unauthorized("auth.invalid_credentials")
 	}
 
 	if !mongodbutils.CheckPassword(req.Password, passwordHash) {
-		return c.Status(401).JSON(fiber.Map{"error": "Username atau password salah"})
+		return apperror.Unauthorized("auth.invalid_credentials")
 	}
 
 	token, err := mongodbutils.GenerateToken(user)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal generate token"})
+		return apperror.Internal("auth.token_failed", err)
 	}
 
+	// Login belum melewati AuthRequired, jadi actor audit diisi manual
+	c.Locals("user_id", user.ID)
+	recordAudit(c, audit.ActionLogin, audit.EntityUser, user.ID, nil, fiber.Map{"last_login": time.Now()})
+
 	// bisa pakai parameter remember untuk memperpanjang expiry token (kalau kamu implementasikan)
-	// bisa pakai parameter lang untuk ubah pesan respon (id/en)
 
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Login berhasil",
+		"message": i18n.T(lang, "auth.login_success"),
 		"lang":    lang,
 		"remember": remember,
 		"data": fiber.Map{
@@ -68,3 +73,80 @@ func Login(c *fiber.Ctx) error {
 		},
 	})
 }
+
+// userSortColumns -> kolom user yang boleh dipakai untuk sorting
+var userSortColumns = map[string]bool{
+	"id": true, "username": true, "email": true, "role": true,
+}
+
+// userFilterFields -> field user yang boleh dipakai di ?filter=
+var userFilterFields = filter.Schema{
+	"id":       {Kind: filter.Int, Key: "id"},
+	"username": {Kind: filter.String, Key: "username"},
+	"email":    {Kind: filter.String, Key: "email"},
+	"role":     {Kind: filter.String, Key: "role"},
+}
+
+// GetUsersService godoc
+// @Summary Menampilkan daftar user
+// @Description Mengambil daftar user dengan pagination, sorting, search, dan filter (khusus admin). Password tidak ikut dikirim.
+// @Tags Users
+// @Produce json
+// @Param page query int false "Nomor halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param sortBy query string false "Kolom untuk sorting (id, username, email, role)"
+// @Param order query string false "Urutan sort asc/desc (default: asc)"
+// @Param search query string false "Cari berdasarkan username atau email"
+// @Param filter query string false "Filter field:operator:nilai, mis. role:eq:admin"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /users [get]
+func GetUsersService(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	sortBy := c.Query("sortBy", "id")
+	if !userSortColumns[sortBy] {
+		sortBy = "id"
+	}
+	order := strings.ToLower(c.Query("order", "asc"))
+	if order != "desc" {
+		order = "asc"
+	}
+	search := c.Query("search", "")
+
+	f, err := filter.Parse(c.Query("filter"), userFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	users, err := repository.GetUsersRepo(c.UserContext(), search, sortBy, order, limit, (page-1)*limit, f)
+	if err != nil {
+		return apperror.Internal("user.fetch_failed", err)
+	}
+	total, err := repository.CountUsersRepo(c.UserContext(), search, f)
+	if err != nil {
+		return apperror.Internal("user.count_failed", err)
+	}
+
+	return c.JSON(&models.UserResponse{
+		Data: users,
+		Meta: &models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: sortBy,
+			Order:  order,
+			Search: search,
+			Filter: f.String(),
+		},
+	})
+}
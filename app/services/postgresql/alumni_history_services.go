@@ -0,0 +1,176 @@
+package service
+
+import (
+	"strconv"
+	"time"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/validate"
+	"github.com/gofiber/fiber/v2"
+)
+
+// currentAlumniVersion -> data alumni saat ini dalam bentuk snapshot (ValidTo nil)
+func currentAlumniVersion(a models.Alumni) models.AlumniVersion {
+	return models.AlumniVersion{
+		AlumniID:   a.ID,
+		Version:    a.Version,
+		NIM:        a.NIM,
+		Nama:       a.Nama,
+		Jurusan:    a.Jurusan,
+		Angkatan:   a.Angkatan,
+		TahunLulus: a.TahunLulus,
+		Email:      a.Email,
+		NoTelepon:  a.NoTelepon,
+		Alamat:     a.Alamat,
+		ValidFrom:  a.UpdatedAt,
+	}
+}
+
+// alumniAsOf mengirim data alumni yang berlaku pada waktu asOf
+func alumniAsOf(c *fiber.Ctx, data models.Alumni, asOf time.Time) error {
+	if asOf.Before(data.CreatedAt) {
+		return apperror.NotFound("alumni.not_yet_exist")
+	}
+	if !asOf.Before(data.UpdatedAt) {
+		return c.JSON(currentAlumniVersion(data))
+	}
+
+	v, err := repository.GetAlumniAsOf(c.UserContext(), data.ID, asOf)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if v == nil {
+		// Perubahan sebelum riwayat mulai dicatat tidak punya snapshot
+		return apperror.NotFound("alumni.history_unavailable")
+	}
+	return c.JSON(v)
+}
+
+// GetAlumniHistoryService -> daftar versi alumni, dimulai dari versi saat ini
+// @Summary Riwayat perubahan alumni
+// @Description Daftar semua versi data alumni, dimulai dari versi saat ini. Non-admin hanya bisa melihat data miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Success 200 {object} object{success=bool,data=[]models.AlumniVersion}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/history [get]
+func GetAlumniHistoryService(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	data, err := repository.GetAlumniByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_history")
+	}
+
+	history, err := repository.GetAlumniHistory(c.UserContext(), id)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data":    append([]models.AlumniVersion{currentAlumniVersion(data)}, history...),
+	})
+}
+
+// RevertAlumniService mengembalikan data alumni ke versi sebelumnya.
+// Revert disimpan sebagai update baru, jadi versi saat ini tetap ada di riwayat.
+// @Summary Kembalikan alumni ke versi sebelumnya
+// @Description Revert disimpan sebagai update baru, jadi versi saat ini tetap ada di riwayat.
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.RevertAlumniRequest true "Versi tujuan"
+// @Success 200 {object} object{message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id}/revert [post]
+func RevertAlumniService(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var req models.RevertAlumniRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+
+	data, err := repository.GetAlumniByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_update")
+	}
+	if data.DeletedAt != nil {
+		return apperror.Validation("alumni.deleted_restore_first")
+	}
+	if req.Version == data.Version {
+		return apperror.Validation("alumni.same_version")
+	}
+
+	target, err := repository.GetAlumniVersion(c.UserContext(), id, req.Version)
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+	if target == nil {
+		return apperror.NotFound("alumni.version_not_found")
+	}
+
+	before := data
+	data.Nama = target.Nama
+	data.Jurusan = target.Jurusan
+	data.Angkatan = target.Angkatan
+	data.TahunLulus = target.TahunLulus
+	data.Email = target.Email
+	data.NoTelepon = target.NoTelepon
+	data.Alamat = target.Alamat
+	data.UpdatedAt = time.Now()
+
+	err = repository.UpdateAlumni(c.UserContext(), &data, userID, role, ifMatch)
+	if err == repository.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("alumni.restore_failed", err)
+	}
+	recordAudit(c, audit.ActionRevert, audit.EntityAlumni, id, before, data)
+
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.reverted", req.Version),
+		"data":    data,
+	})
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/importer"
+	"alumniproject/utils/jobs"
+	"alumniproject/utils/jobs/handler"
+	"alumniproject/utils/metrics"
+	"github.com/gofiber/fiber/v2"
+)
+
+// importParams -> opsi import alumni, juga menjadi payload job import_alumni.
+// File & Filename hanya dipakai job: path file upload yang disimpan dan nama aslinya (menentukan format).
+type importParams struct {
+	Mode     string            `json:"mode"`
+	DryRun   bool              `json:"dry_run"`
+	Mapping  map[string]string `json:"mapping,omitempty"`
+	UserID   int               `json:"user_id"`
+	Role     string            `json:"role"`
+	IP       string            `json:"ip"`
+	File     string            `json:"file,omitempty"`
+	Filename string            `json:"filename,omitempty"`
+}
+
+// ImportAlumniService mengimpor alumni dari file CSV / XLSX (multipart field "file").
+// Form / query:
+//   - mode     : insert (default, NIM yang sudah ada ditolak) atau upsert (update berdasarkan NIM)
+//   - dry_run  : true -> hanya validasi, tidak ada data yang disimpan
+//   - mapping  : JSON {"Header di file": "field"} untuk header yang tidak dikenali otomatis
+//   - report=csv (query) -> laporan kesalahan per baris dikirim sebagai file CSV
+//   - async=true (query) -> import dijalankan sebagai job, laporan CSV diunduh dari /jobs/:id/result
+//
+// Baris yang valid tetap disimpan walaupun ada baris lain yang gagal.
+// @Summary Import alumni dari CSV / XLSX
+// @Description Mengimpor alumni dari file. Baris yang valid tetap disimpan walaupun ada baris lain yang gagal. async=true -> dibuat sebagai job import_alumni, hasil job berupa CSV kesalahan per baris.
+// @Tags Alumni
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File .csv atau .xlsx"
+// @Param mode formData string false "insert (default) atau upsert berdasarkan NIM"
+// @Param dry_run formData bool false "Hanya validasi, tidak ada data yang disimpan"
+// @Param mapping formData string false "Objek JSON header di file -> nama field, untuk header yang tidak dikenali otomatis"
+// @Param report query string false "csv -> laporan kesalahan per baris dikirim sebagai file CSV"
+// @Param async query bool false "true -> jalankan sebagai job latar belakang"
+// @Success 200 {object} object{success=bool,data=models.ImportAlumniReport}
+// @Success 202 {object} object{success=bool,message=string,data=jobs.Job}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/import [post]
+func ImportAlumniService(c *fiber.Ctx) error {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return apperror.Validation("import.file_required")
+	}
+	metrics.AddUploadBytes("import", fileHeader.Size)
+
+	params := importParams{
+		Mode:   c.FormValue("mode", "insert"),
+		UserID: c.Locals("user_id").(int),
+		Role:   c.Locals("role").(string),
+		IP:     c.IP(),
+	}
+	if params.Mode != "insert" && params.Mode != "upsert" {
+		return apperror.Validation("import.mode_invalid")
+	}
+	params.DryRun, _ = strconv.ParseBool(c.FormValue("dry_run"))
+
+	if v := c.FormValue("mapping"); v != "" {
+		if err := json.Unmarshal([]byte(v), &params.Mapping); err != nil {
+			return apperror.Validation("import.mapping_invalid")
+		}
+	}
+	if _, err := importer.ParseMapping(params.Mapping); err != nil {
+		return apperror.Invalid(err)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return apperror.Internal("import.open_failed", err)
+	}
+	defer file.Close()
+
+	if c.QueryBool("async") {
+		if params.File, err = JobHandler.SaveUpload(fileHeader.Filename, file); err != nil {
+			if err == handler.ErrQueueInactive {
+				return err
+			}
+			return apperror.Internal("import.open_failed", err)
+		}
+		params.Filename = fileHeader.Filename
+		if err := JobHandler.Enqueue(c, jobs.TypeImportAlumni, params); err != nil {
+			os.Remove(params.File)
+			return err
+		}
+		return nil
+	}
+
+	record := func(action string, id, before, after interface{}) {
+		recordAudit(c, action, audit.EntityAlumni, id, before, after)
+	}
+	report, err := importAlumni(c.UserContext(), fileHeader.Filename, file, params, record, nil)
+	if err != nil {
+		return err
+	}
+
+	if c.Query("report") == "csv" {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, `attachment; filename="import-alumni-errors.csv"`)
+		return importer.WriteErrorsCSV(c, report.Errors)
+	}
+
+	return c.JSON(fiber.Map{
+		"success": report.Failed == 0,
+		"data":    report,
+	})
+}
+
+// runImportAlumniJob -> handler job import_alumni, hasilnya laporan kesalahan per baris dalam CSV
+func runImportAlumniJob(ctx context.Context, run *jobs.Run) (err error) {
+	var params importParams
+	if err := run.Decode(&params); err != nil {
+		return err
+	}
+	defer func() { run.RemoveUpload(params.File, err) }()
+
+	file, err := os.Open(params.File)
+	if err != nil {
+		return jobs.Permanent(err)
+	}
+	defer file.Close()
+
+	record := func(action string, id, before, after interface{}) {
+		insertAudit(ctx, &params.UserID, params.IP, action, audit.EntityAlumni, id, before, after)
+	}
+	progress := func(done, total int) {
+		if done%100 == 0 || done == total {
+			run.Progress(done*100/total, fmt.Sprintf("%d dari %d baris diproses", done, total))
+		}
+	}
+	run.Progress(0, "Membaca file import")
+	report, err := importAlumni(ctx, params.Filename, file, params, record, progress)
+	if err != nil {
+		// File tidak bisa dibaca tidak akan berhasil walau dicoba ulang
+		if e := apperror.From(err); e.Status < fiber.StatusInternalServerError {
+			return jobs.Permanent(err)
+		}
+		return err
+	}
+
+	result, err := run.CreateResult("import-alumni-errors.csv", "text/csv; charset=utf-8")
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+	if err := importer.WriteErrorsCSV(result, report.Errors); err != nil {
+		return err
+	}
+	run.Job.Message = fmt.Sprintf("%d baris: %d dibuat, %d diperbarui, %d gagal", report.Total, report.Created, report.Updated, report.Failed)
+	return nil
+}
+
+// importAlumni membaca, memvalidasi, lalu menyimpan alumni dari file. Kesalahan per baris masuk ke report,
+// error hanya dikembalikan jika file tidak bisa dibaca atau data lama gagal diambil (belum ada yang disimpan).
+// record mencatat audit setiap alumni yang dibuat/diubah; progress (opsional) dipanggil setiap baris valid diproses.
+func importAlumni(ctx context.Context, filename string, r io.Reader, params importParams,
+	record func(action string, id, before, after interface{}), progress func(done, total int),
+) (models.ImportAlumniReport, error) {
+	mapping, err := importer.ParseMapping(params.Mapping)
+	if err != nil {
+		return models.ImportAlumniReport{}, apperror.Invalid(err)
+	}
+
+	rows, errs, err := importer.Parse(filename, r, mapping)
+	if err != nil {
+		return models.ImportAlumniReport{}, apperror.Invalid(err)
+	}
+	report := models.ImportAlumniReport{
+		DryRun: params.DryRun,
+		Mode:   params.Mode,
+		Total:  len(rows) + importer.CountLines(errs),
+	}
+
+	valid, validationErrs := importer.Validate(rows)
+	errs = append(errs, validationErrs...)
+
+	nims := make([]string, len(valid))
+	for i, row := range valid {
+		nims[i] = row.NIM
+	}
+	existing, err := repository.GetAlumniByNIMs(ctx, nims)
+	if err != nil {
+		return report, apperror.Internal("", err)
+	}
+
+	userID, role := params.UserID, params.Role
+	for i, row := range valid {
+		if progress != nil {
+			progress(i+1, len(valid))
+		}
+		fail := func(msg string) {
+			errs = append(errs, importer.RowError{Line: row.Line, NIM: row.NIM, Field: importer.FieldNIM, Message: msg})
+		}
+
+		current, found := existing[row.NIM]
+		switch {
+		case found && params.Mode == "insert":
+			fail("NIM sudah terdaftar")
+		case found && current.DeletedAt != nil:
+			fail("NIM milik alumni yang sudah dihapus, restore terlebih dahulu")
+		case found && role != "admin" && current.CreatedBy != userID:
+			fail("NIM milik alumni yang diinput user lain")
+		case found:
+			if params.DryRun {
+				report.Updated++
+				continue
+			}
+			before := current
+			current.Nama = row.Nama
+			current.Jurusan = row.Jurusan
+			current.Angkatan = row.Angkatan
+			current.TahunLulus = row.TahunLulus
+			current.Email = row.Email
+			current.NoTelepon = row.NoTelepon
+			current.Alamat = row.Alamat
+			current.UpdatedAt = time.Now()
+			if err := repository.UpdateAlumni(ctx, &current, userID, role, 0); err != nil {
+				fail("Gagal memperbarui data: " + err.Error())
+				continue
+			}
+			record(audit.ActionUpdate, current.ID, before, current)
+			report.Updated++
+		default:
+			if params.DryRun {
+				report.Created++
+				continue
+			}
+			alumni := models.Alumni{
+				NIM:        row.NIM,
+				Nama:       row.Nama,
+				Jurusan:    row.Jurusan,
+				Angkatan:   row.Angkatan,
+				TahunLulus: row.TahunLulus,
+				Email:      row.Email,
+				NoTelepon:  row.NoTelepon,
+				Alamat:     row.Alamat,
+				CreatedBy:  userID,
+			}
+			if err := repository.CreateAlumni(ctx, &alumni); err != nil {
+				fail("Gagal menyimpan data: " + err.Error())
+				continue
+			}
+			record(audit.ActionCreate, alumni.ID, nil, alumni)
+			report.Created++
+		}
+	}
+
+	importer.SortErrors(errs)
+	report.Errors = append([]importer.RowError{}, errs...)
+	report.Failed = importer.CountLines(errs)
+	return report, nil
+}
@@ -7,48 +7,103 @@ import (
 
 	"alumniproject/app/models/postgresql"
 	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/mergepatch"
+	"alumniproject/utils/validate"
 	"github.com/gofiber/fiber/v2"
 
 )
 
+// GetAllAlumni -> GET /alumni, mendukung ?filter= (lihat alumniFilterFields)
+// @Summary Menampilkan semua alumni
+// @Description Mengambil semua data alumni aktif tanpa pagination. Non-admin hanya melihat data miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param filter query string false "Filter field:operator:nilai, mis. angkatan:gte:2018,jurusan:in:TI|SI"
+// @Success 200 {array} models.Alumni
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni [get]
 func GetAllAlumni(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	list, err := repository.GetAllAlumni(role, userID)
+	f, err := filter.Parse(c.Query("filter"), alumniFilterFields)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	list, err := repository.GetAllAlumni(c.UserContext(), role, userID, f)
+	if err != nil {
+		return apperror.Internal("", err)
 	}
 	return c.JSON(list)
 }
 
 
+// GetAlumniByIDService godoc
+// @Summary Menampilkan detail alumni
+// @Description Mengambil satu data alumni berdasarkan ID. Dengan ?as_of= dikembalikan versi data pada waktu tersebut (models.AlumniVersion).
+// @Tags Alumni
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Param as_of query string false "Waktu yang ingin dilihat (YYYY-MM-DD atau RFC3339)"
+// @Success 200 {object} models.Alumni
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [get]
 func GetAlumniByIDService(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	data, err := repository.GetAlumniByID(id)
-	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Data alumni tidak ditemukan"})
+	data, err := repository.GetAlumniByID(c.UserContext(), id)
+	if err != nil || data.DeletedAt != nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+
+	// ?as_of=YYYY-MM-DD atau RFC3339 -> tampilkan data seperti pada waktu tersebut
+	if v := c.Query("as_of"); v != "" {
+		asOf, err := parseTimeParam(v)
+		if err != nil {
+			return apperror.Validation("request.datetime_format").WithArgs("as_of")
+		}
+		return alumniAsOf(c, data, *asOf)
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
 	return c.JSON(data)
 }
 
 
+// CreateAlumniService godoc
+// @Summary Tambah data alumni baru
+// @Description Membuat data alumni baru, pemiliknya user yang login
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param body body models.CreateAlumniRequest true "Data alumni baru"
+// @Success 200 {object} object{message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem "Field tidak valid, detail per field di errors"
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni [post]
 func CreateAlumniService(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(int)
 
 
 	var req models.CreateAlumniRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Input tidak valid"})
-	}
-
-	if req.NIM == "" || req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Semua field wajib diisi"})
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
 	alumni := models.Alumni{
@@ -63,49 +118,62 @@ func CreateAlumniService(c *fiber.Ctx) error {
 		CreatedBy:  userID,
 	}
 
-	if err := repository.CreateAlumni(&alumni); err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal menyimpan data"})
+	if err := repository.CreateAlumni(c.UserContext(), &alumni); err != nil {
+		return apperror.Internal("data.save_failed", err)
 	}
+	recordAudit(c, audit.ActionCreate, audit.EntityAlumni, alumni.ID, nil, alumni)
 
 	return c.JSON(fiber.Map{
-		"message": "Data alumni berhasil ditambahkan",
+		"message": i18n.Msg(c, "alumni.created"),
 		"data":    alumni,
 	})
 }
 
+// UpdateAlumniService godoc
+// @Summary Update data alumni
+// @Description Mengganti seluruh field alumni. Non-admin hanya bisa mengubah data miliknya.
+// @Tags Alumni
+// @Accept json
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdateAlumniRequest true "Data alumni"
+// @Success 200 {object} object{message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [put]
 func UpdateAlumniService(c *fiber.Ctx) error {
     id, _ := strconv.Atoi(c.Params("id"))
     userID := c.Locals("user_id").(int)
     role := c.Locals("role").(string)
 
-    var req models.UpdateAlumniRequest
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "error": "Input tidak valid",
-        })
+    ifMatch, err := ifMatchVersion(c)
+    if err != nil {
+        return apperror.Invalid(err)
     }
 
-    if req.Nama == "" || req.Jurusan == "" || req.Email == "" {
-        return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-            "error": "Nama, jurusan, dan email wajib diisi",
-        })
+    var req models.UpdateAlumniRequest
+    if err := validate.Body(c, &req); err != nil {
+        return err
     }
 
     // Ambil data dari repository
-    data, err := repository.GetAlumniByID(id)
+    data, err := repository.GetAlumniByID(c.UserContext(), id)
     if err != nil {
-        return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-            "error": "Data alumni tidak ditemukan",
-        })
+        return apperror.NotFound("alumni.not_found")
     }
 
     // Validasi akses
     if role != "admin" && data.CreatedBy != userID {
-        return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-            "error": "Tidak boleh ubah data ini",
-        })
+        return apperror.Forbidden("alumni.forbidden_update")
     }
 
+    before := data
+
     // Update field
     data.Nama = req.Nama
     data.Jurusan = req.Jurusan
@@ -117,53 +185,102 @@ func UpdateAlumniService(c *fiber.Ctx) error {
     data.UpdatedAt = time.Now()
 
     // Simpan ke repository
-    err = repository.UpdateAlumni(&data, userID, role)
+    err = repository.UpdateAlumni(c.UserContext(), &data, userID, role, ifMatch)
+    if err == repository.ErrVersionConflict {
+        return err
+    }
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-            "error": "Gagal memperbarui data alumni",
-        })
+        return apperror.Internal("alumni.update_failed", err)
     }
+    recordAudit(c, audit.ActionUpdate, audit.EntityAlumni, data.ID, before, data)
 
+    c.Set(fiber.HeaderETag, etag.Format(data.Version))
     return c.JSON(fiber.Map{
-        "message": "Data alumni berhasil diperbarui",
+        "message": i18n.Msg(c, "alumni.updated"),
         "data":    data,
     })
 }
 
 
+// DeleteAlumniService godoc
+// @Summary Hapus alumni (soft delete)
+// @Description Memindahkan alumni ke trash beserta pekerjaannya dalam satu deletion batch. Non-admin hanya bisa menghapus data miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string,cascade=models.DeletionBatch}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [delete]
 func DeleteAlumniService(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	data, err := repository.GetAlumniByID(id)
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	data, err := repository.GetAlumniByID(c.UserContext(), id)
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Data alumni tidak ditemukan"})
+		return apperror.NotFound("alumni.not_found")
 	}
 
 	if role != "admin" && data.CreatedBy != userID {
-		return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh hapus data ini"})
+		return apperror.Forbidden("alumni.forbidden_delete")
 	}
 
-	err = repository.DeleteAlumni(id, userID, role)
+	batch, err := repository.DeleteAlumni(c.UserContext(), id, userID, role, ifMatch)
+	if err == repository.ErrVersionConflict {
+		return err
+	}
 	if err != nil {
-		return c.Status(403).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Forbidden("alumni.forbidden_delete")
 	}
+	recordAudit(c, audit.ActionDelete, audit.EntityAlumni, id,
+		fiber.Map{"deleted_at": nil}, fiber.Map{"deleted_at": time.Now(), "deletion_batch": batch.ID, "cascade_pekerjaan": batch.Pekerjaan})
 
-	return c.JSON(fiber.Map{"message": "Data alumni berhasil dihapus"})
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.deleted"),
+		"cascade": batch,
+	})
 }
+// RestoreAlumniService godoc
+// @Summary Restore alumni dari trash
+// @Description Mengembalikan alumni beserta pekerjaan yang ikut terhapus pada deletion batch yang sama. Non-admin hanya bisa restore alumni miliknya.
+// @Tags Alumni
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Success 200 {object} object{message=string,cascade=models.DeletionBatch}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/restore/{id} [put]
 func RestoreAlumniService(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
-	err = repository.RestoreAlumni(id)
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	batch, err := repository.RestoreAlumni(c.UserContext(), id, userID, role)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": err.Error()})
+		return apperror.Invalid(err)
 	}
+	recordAudit(c, audit.ActionRestore, audit.EntityAlumni, id,
+		fiber.Map{"deletion_batch": batch.ID}, fiber.Map{"deletion_batch": nil, "restored_pekerjaan": batch.Pekerjaan})
 
-	return c.JSON(fiber.Map{"message": "Data alumni berhasil dikembalikan"})
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.restored"),
+		"cascade": batch,
+	})
 }
 
 
@@ -227,35 +344,77 @@ func RestoreAlumniService(c *fiber.Ctx) error {
 
 
 
-// GetAlumniPaginated -> ambil data alumni dengan pagination, sorting, dan search
+// alumniSortColumns -> kolom alumni yang boleh dipakai untuk sorting (list & export)
+var alumniSortColumns = map[string]bool{
+    "id": true, "nim": true, "nama": true,
+    "jurusan": true, "angkatan": true, "tahun_lulus": true,
+}
+
+// alumniFilterFields -> field alumni yang boleh dipakai di ?filter=
+var alumniFilterFields = filter.Schema{
+    "id":          {Kind: filter.Int, Column: "id"},
+    "nim":         {Kind: filter.String, Column: "nim"},
+    "nama":        {Kind: filter.String, Column: "nama"},
+    "jurusan":     {Kind: filter.String, Column: "jurusan"},
+    "angkatan":    {Kind: filter.Int, Column: "angkatan"},
+    "tahun_lulus": {Kind: filter.Int, Column: "tahun_lulus"},
+    "email":       {Kind: filter.String, Column: "email"},
+    "created_at":  {Kind: filter.Time, Column: "created_at"},
+    "updated_at":  {Kind: filter.Time, Column: "updated_at"},
+}
+
+// GetAlumniPaginated -> ambil data alumni dengan pagination, sorting, search, dan ?filter=.
+// Jika ada ?cursor= (boleh kosong untuk halaman pertama) dipakai keyset pagination, selain itu page/offset.
+// @Summary Menampilkan alumni dengan pagination
+// @Description Mengambil data alumni dengan pagination, sorting, search, dan filter.
+// @Description Jika ada ?cursor= (kosong untuk halaman pertama) dipakai keyset pagination: meta berisi next_cursor/prev_cursor, total tidak dihitung.
+// @Tags Alumni
+// @Produce json
+// @Param page query int false "Nomor halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param sortBy query string false "Kolom untuk sorting: id, nim, nama, jurusan, angkatan, tahun_lulus (default: id)"
+// @Param order query string false "Urutan sort asc/desc (default: asc)"
+// @Param search query string false "Cari berdasarkan nama, NIM, atau jurusan"
+// @Param filter query string false "Filter field:operator:nilai, mis. angkatan:gte:2018,jurusan:in:TI|SI"
+// @Param cursor query string false "Cursor dari next_cursor/prev_cursor (mode keyset)"
+// @Success 200 {object} models.AlumniResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/all [get]
 func GetAlumniService(c *fiber.Ctx) error {
+    if c.Context().QueryArgs().Has("cursor") {
+        return getAlumniKeyset(c)
+    }
+
     page, _ := strconv.Atoi(c.Query("page", "1"))
     limit, _ := strconv.Atoi(c.Query("limit", "10"))
     sortBy := c.Query("sortBy", "id")
     order := c.Query("order", "asc")
     search := c.Query("search", "")
 
+    f, err := filter.Parse(c.Query("filter"), alumniFilterFields)
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+    }
+
     offset := (page - 1) * limit
 
-    whitelist := map[string]bool{
-        "id": true, "nim": true, "nama": true,
-        "jurusan": true, "angkatan": true, "tahun_lulus": true,
-    }
-    if !whitelist[sortBy] {
+    if !alumniSortColumns[sortBy] {
         sortBy = "id"
     }
     if strings.ToLower(order) != "desc" {
         order = "asc"
     }
 
-    alumni, err := repository.GetAlumniRepo(search, sortBy, order, limit, offset)
+    alumni, err := repository.GetAlumniRepo(c.UserContext(), search, sortBy, order, limit, offset, f)
     if err != nil {
-        return c.Status(500).JSON(fiber.Map{"error": "Gagal ambil data alumni"})
+        return apperror.Internal("alumni.fetch_failed", err)
     }
 
-    total, err := repository.CountAlumniRepo(search)
+    total, err := repository.CountAlumniRepo(c.UserContext(), search, f)
     if err != nil {
-        return c.Status(500).JSON(fiber.Map{"error": "Gagal hitung data alumni"})
+        return apperror.Internal("alumni.count_failed", err)
     }
 
 	response := &models.AlumniResponse{
@@ -268,8 +427,171 @@ func GetAlumniService(c *fiber.Ctx) error {
 			SortBy: sortBy,
 			Order:  order,
 			Search: search,
+			Filter: f.String(),
 		},
 	}
 
     return c.JSON(response)
-}
\ No newline at end of file
+}
+
+// getAlumniKeyset -> GetAlumniService mode cursor. Sort & order diambil dari cursor jika ada.
+func getAlumniKeyset(c *fiber.Ctx) error {
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+    search := c.Query("search", "")
+
+    cur, err := cursor.Decode(c.Query("cursor"))
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidCursor)
+    }
+    sortBy, order := c.Query("sortBy", "id"), strings.ToLower(c.Query("order", "asc"))
+    if cur != nil {
+        sortBy, order = cur.SortBy, cur.Order
+    }
+    if !alumniSortColumns[sortBy] {
+        if cur != nil {
+            return apperror.Validation("invalid_cursor").WithCode(apperror.CodeInvalidCursor)
+        }
+        sortBy = "id"
+    }
+    if order != "desc" {
+        order = "asc"
+    }
+
+    f, err := filter.Parse(c.Query("filter"), alumniFilterFields)
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+    }
+
+    alumni, err := repository.GetAlumniKeyset(c.UserContext(), search, sortBy, order, limit+1, cur, f)
+    if err != nil {
+        return apperror.Internal("alumni.fetch_failed", err)
+    }
+    alumni, hasMore := cursor.Trim(alumni, limit, cur)
+    next, prev := cursor.Links(alumni, hasMore, cur, sortBy, order, func(a models.Alumni) (interface{}, interface{}) {
+        return alumniSortValue(a, sortBy), a.ID
+    })
+
+    return c.JSON(&models.AlumniResponse{
+        Data: alumni,
+        Meta: &models.MetaInfo{
+            Limit:      limit,
+            SortBy:     sortBy,
+            Order:      order,
+            Search:     search,
+            Filter:     f.String(),
+            NextCursor: next,
+            PrevCursor: prev,
+        },
+    })
+}
+
+// alumniSortValue -> nilai kolom sort satu alumni untuk cursor
+func alumniSortValue(a models.Alumni, sortBy string) interface{} {
+    switch sortBy {
+    case "nim":
+        return a.NIM
+    case "nama":
+        return a.Nama
+    case "jurusan":
+        return a.Jurusan
+    case "angkatan":
+        return a.Angkatan
+    case "tahun_lulus":
+        return a.TahunLulus
+    default:
+        return a.ID
+    }
+}
+
+// PatchAlumniService -> PATCH /api/alumni/:id, hanya field yang dikirim yang diubah (RFC 7396)
+// @Summary Update sebagian data alumni
+// @Description Mengubah hanya field alumni yang dikirim (RFC 7396). Field bernilai null akan dikosongkan. Hasil merge tetap divalidasi.
+// @Tags Alumni
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "ID alumni"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdateAlumniRequest true "Field alumni yang akan diubah"
+// @Success 200 {object} object{message=string,data=models.Alumni}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 415 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni/{id} [patch]
+func PatchAlumniService(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if !isMergePatchRequest(c) {
+		return apperror.UnsupportedMediaType("request.content_type").WithArgs(mergepatch.ContentType)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	data, err := repository.GetAlumniByID(c.UserContext(), id)
+	if err != nil {
+		return apperror.NotFound("alumni.not_found")
+	}
+
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("alumni.forbidden_update")
+	}
+
+	current := models.UpdateAlumniRequest{
+		Nama:       data.Nama,
+		Jurusan:    data.Jurusan,
+		Angkatan:   data.Angkatan,
+		TahunLulus: data.TahunLulus,
+		Email:      data.Email,
+		NoTelepon:  data.NoTelepon,
+		Alamat:     data.Alamat,
+	}
+
+	var req models.UpdateAlumniRequest
+	if err := mergepatch.ApplyTo(current, c.Body(), &req); err != nil {
+		return apperror.Invalid(err)
+	}
+
+	// Validasi hasil merge, bukan hanya isi patch
+	if err := validate.Check(&req); err != nil {
+		return err
+	}
+
+	before := data
+	data.Nama = req.Nama
+	data.Jurusan = req.Jurusan
+	data.Angkatan = req.Angkatan
+	data.TahunLulus = req.TahunLulus
+	data.Email = req.Email
+	data.NoTelepon = req.NoTelepon
+	data.Alamat = req.Alamat
+	data.UpdatedAt = time.Now()
+
+	err = repository.UpdateAlumni(c.UserContext(), &data, userID, role, ifMatch)
+	if err == repository.ErrVersionConflict {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("alumni.update_failed", err)
+	}
+	recordAudit(c, audit.ActionUpdate, audit.EntityAlumni, data.ID, before, data)
+
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "alumni.updated"),
+		"data":    data,
+	})
+}
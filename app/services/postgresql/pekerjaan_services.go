@@ -1,61 +1,188 @@
 package service
 
 import (
-	"database/sql"
+	"context"
 	"strconv"
 	"strings"
 	"time"
-	"fmt"
-	"log"
+	"log/slog"
 
 	"alumniproject/app/models/postgresql"
 	"alumniproject/app/repository/postgresql"
-	"alumniproject/database/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/bulk"
+	"alumniproject/utils/cursor"
+	"alumniproject/utils/etag"
+	"alumniproject/utils/filter"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/mergepatch"
+	"alumniproject/utils/validate"
 	"github.com/gofiber/fiber/v2"
 )
 
+// GetAllPekerjaanService -> GET /pekerjaan, mendukung ?filter= (lihat pekerjaanFilterFields)
+// @Summary Menampilkan semua data pekerjaan
+// @Description Mengambil semua data pekerjaan aktif. Non-admin hanya melihat data miliknya.
+// @Description Jika ada ?cursor= (kosong untuk halaman pertama) dipakai keyset pagination: response berupa models.PekerjaanResponse dengan meta next_cursor/prev_cursor, total tidak dihitung.
+// @Tags Pekerjaan
+// @Produce json
+// @Param filter query string false "Filter field:operator:nilai, mis. status_pekerjaan:eq:aktif,alumni_id:in:1|2"
+// @Param cursor query string false "Cursor dari next_cursor/prev_cursor (mode keyset)"
+// @Param limit query int false "Jumlah data per halaman pada mode keyset (default: 10)"
+// @Param sort_by query string false "Kolom sorting pada mode keyset: id, nama_perusahaan, posisi_jabatan, tanggal_mulai_kerja, created_at (default: created_at)"
+// @Param order query string false "Urutan sort asc/desc pada mode keyset (default: desc)"
+// @Param search query string false "Cari nama perusahaan atau posisi pada mode keyset"
+// @Success 200 {array} models.Pekerjaan
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan [get]
 func GetAllPekerjaanService(c *fiber.Ctx) error {
+    if c.Context().QueryArgs().Has("cursor") {
+        return getPekerjaanKeyset(c)
+    }
+
     userID := c.Locals("user_id").(int)
     role := c.Locals("role").(string)
 
-    list, err := repository.GetAllPekerjaan(role, userID)
+    f, err := filter.Parse(c.Query("filter"), pekerjaanFilterFields)
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+    }
+
+    list, err := repository.GetAllPekerjaan(c.UserContext(), role, userID, f)
     if err != nil {
-        return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+        return apperror.Internal("", err)
     }
     return c.JSON(list)
 }
 
+// getPekerjaanKeyset -> GetAllPekerjaanService mode cursor. Sort & order diambil dari cursor jika ada.
+func getPekerjaanKeyset(c *fiber.Ctx) error {
+    userID := c.Locals("user_id").(int)
+    role := c.Locals("role").(string)
+
+    limit, _ := strconv.Atoi(c.Query("limit", "10"))
+    if limit < 1 || limit > 100 {
+        limit = 10
+    }
+    search := c.Query("search", "")
+
+    cur, err := cursor.Decode(c.Query("cursor"))
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidCursor)
+    }
+    sortBy, order := c.Query("sort_by", "created_at"), strings.ToLower(c.Query("order", "desc"))
+    if cur != nil {
+        sortBy, order = cur.SortBy, cur.Order
+    }
+    if !pekerjaanSortColumns[sortBy] {
+        if cur != nil {
+            return apperror.Validation("invalid_cursor").WithCode(apperror.CodeInvalidCursor)
+        }
+        sortBy = "created_at"
+    }
+    if order != "desc" {
+        order = "asc"
+    }
+
+    f, err := filter.Parse(c.Query("filter"), pekerjaanFilterFields)
+    if err != nil {
+        return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+    }
+
+    list, err := repository.GetPekerjaanKeyset(c.UserContext(), role, userID, search, sortBy, order, limit+1, cur, f)
+    if err != nil {
+        return apperror.Internal("pekerjaan.fetch_failed", err)
+    }
+    list, hasMore := cursor.Trim(list, limit, cur)
+    next, prev := cursor.Links(list, hasMore, cur, sortBy, order, func(p models.Pekerjaan) (interface{}, interface{}) {
+        return pekerjaanSortValue(p, sortBy), p.ID
+    })
+
+    return c.JSON(&models.PekerjaanResponse{
+        Data: list,
+        Meta: &models.MetaInfo{
+            Limit:      limit,
+            SortBy:     sortBy,
+            Order:      order,
+            Search:     search,
+            Filter:     f.String(),
+            NextCursor: next,
+            PrevCursor: prev,
+        },
+    })
+}
+
+// pekerjaanSortValue -> nilai kolom sort satu pekerjaan untuk cursor
+func pekerjaanSortValue(p models.Pekerjaan, sortBy string) interface{} {
+    switch sortBy {
+    case "nama_perusahaan":
+        return p.NamaPerusahaan
+    case "posisi_jabatan":
+        return p.PosisiJabatan
+    case "tanggal_mulai_kerja":
+        return p.TanggalMulaiKerja
+    case "created_at":
+        return p.CreatedAt
+    default:
+        return p.ID
+    }
+}
+
+// GetPekerjaanByID godoc
+// @Summary Menampilkan detail pekerjaan
+// @Description Mengambil satu data pekerjaan berdasarkan ID
+// @Tags Pekerjaan
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Success 200 {object} object{success=bool,message=string,data=models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [get]
 func GetPekerjaanByID(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
 	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/pekerjaan/%d", username, id)
+	slog.DebugContext(c.UserContext(), "get pekerjaan", "username", username, "id", id)
 
-	p, err := repository.GetPekerjaanByID(id)
+	p, err := repository.GetPekerjaanByID(c.UserContext(), id)
+	if err == repository.ErrPekerjaanTidakDitemukan {
+		return err
+	}
 	if err != nil {
-		return c.Status(404).JSON(fiber.Map{"error": "Pekerjaan tidak ditemukan"})
+		return apperror.Internal("", err)
 	}
 
+	c.Set(fiber.HeaderETag, etag.Format(p.Version))
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    p,
-		"message": "Data pekerjaan berhasil diambil",
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
 	})
 }
 
+// GetAllAlumniWithPekerjaan godoc
+// @Summary Menampilkan alumni beserta pekerjaannya
+// @Description Mengambil semua alumni aktif beserta daftar pekerjaannya
+// @Tags Alumni Pekerjaan
+// @Produce json
+// @Success 200 {object} object{success=bool,message=string,count=int,data=[]models.AlumniWithPekerjaan}
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni-pekerjaan [get]
 func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni-pekerjaan", username)
+	slog.DebugContext(c.UserContext(), "get alumni pekerjaan", "username", username)
 
-	list, err := repository.GetAlumniWithPekerjaan()
+	list, err := repository.GetAlumniWithPekerjaan(c.UserContext())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"error":   "Gagal mengambil data: " + err.Error(),
-		})
+		return apperror.Internal("data.fetch_failed", err)
 	}
 
 	if len(list) == 0 {
@@ -63,7 +190,7 @@ func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 			"success": true,
 			"count":   0,
 			"data":    []models.AlumniWithPekerjaan{},
-			"message": "Belum ada data alumni",
+			"message": i18n.Msg(c, "alumni.empty"),
 		})
 	}
 
@@ -71,51 +198,66 @@ func GetAllAlumniWithPekerjaan(c *fiber.Ctx) error {
 		"success": true,
 		"count":   len(list),
 		"data":    list,
-		"message": "Data alumni beserta pekerjaan berhasil diambil",
+		"message": i18n.Msg(c, "alumni.with_pekerjaan_fetched"),
 	})
 }
 
+// GetPekerjaanByAlumniID godoc
+// @Summary Menampilkan pekerjaan milik satu alumni
+// @Description Mengambil semua pekerjaan aktif milik alumni tertentu (khusus admin)
+// @Tags Pekerjaan
+// @Produce json
+// @Param alumni_id path int true "ID alumni"
+// @Success 200 {object} object{success=bool,message=string,data=[]models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/alumni/{alumni_id} [get]
 func GetPekerjaanByAlumniID(c *fiber.Ctx) error {
 	alumniID, err := strconv.Atoi(c.Params("alumni_id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Alumni ID tidak valid"})
+		return apperror.Validation("alumni.id_invalid")
 	}
 
 	username := c.Locals("username").(string)
-	log.Printf("Admin %s mengakses GET /api/pekerjaan/alumni/%d", username, alumniID)
+	slog.DebugContext(c.UserContext(), "get pekerjaan by alumni", "username", username, "alumni_id", alumniID)
 
-	list, err := repository.GetPekerjaanByAlumniID(alumniID)
+	list, err := repository.GetPekerjaanByAlumniID(c.UserContext(), alumniID)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data pekerjaan"})
+		return apperror.Internal("pekerjaan.fetch_failed", err)
 	}
 
 	return c.JSON(fiber.Map{
 		"success": true,
 		"data":    list,
-		"message": "Data pekerjaan berhasil diambil",
+		"message": i18n.Msg(c, "pekerjaan.fetched"),
 	})
 }
 
+// CreatePekerjaanService godoc
+// @Summary Tambah data pekerjaan baru
+// @Description Membuat data pekerjaan baru, pemiliknya user yang login
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param body body models.CreatePekerjaanRequest true "Data pekerjaan baru"
+// @Success 200 {object} object{message=string,data=models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem "Field tidak valid, detail per field di errors"
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan [post]
 func CreatePekerjaanService(c *fiber.Ctx) error {
     userID := c.Locals("user_id").(int) // ambil dari JWT
     var req models.CreatePekerjaanRequest
 
-    if err := c.BodyParser(&req); err != nil {
-        return c.Status(400).JSON(fiber.Map{"error": "Invalid input"})
+    if err := validate.Body(c, &req); err != nil {
+        return err
     }
 
-    tMulai, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
+    tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
     if err != nil {
-        return c.Status(400).JSON(fiber.Map{"error": "Tanggal mulai tidak valid"})
-    }
-
-    var tSelesai *time.Time
-    if req.TanggalSelesaiKerja != "" {
-        t, err := time.Parse("2006-01-02", req.TanggalSelesaiKerja)
-        if err != nil {
-            return c.Status(400).JSON(fiber.Map{"error": "Tanggal selesai tidak valid"})
-        }
-        tSelesai = &t
+        return apperror.Invalid(err)
     }
 
     pekerjaan := models.Pekerjaan{
@@ -132,92 +274,93 @@ func CreatePekerjaanService(c *fiber.Ctx) error {
         CreatedBy:           userID, // otomatis dari JWT
     }
 
-    if err := repository.CreatePekerjaan(&pekerjaan); err != nil {
-        return c.Status(500).JSON(fiber.Map{"error": "Gagal simpan data"})
+    if err := repository.CreatePekerjaan(c.UserContext(), &pekerjaan); err != nil {
+        return apperror.Internal("data.save_failed", err)
     }
+    recordAudit(c, audit.ActionCreate, audit.EntityPekerjaan, pekerjaan.ID, nil, pekerjaan)
 
     return c.JSON(fiber.Map{
-        "message": "Pekerjaan berhasil ditambahkan",
+        "message": i18n.Msg(c, "pekerjaan.created"),
         "data":    pekerjaan,
     })
 }
 
+// UpdatePekerjaanService godoc
+// @Summary Update data pekerjaan
+// @Description Mengganti seluruh field pekerjaan. Non-admin hanya bisa mengubah data miliknya.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdatePekerjaanRequest true "Data pekerjaan"
+// @Success 200 {object} object{message=string,data=models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [put]
 func UpdatePekerjaanService(c *fiber.Ctx) error {
 	id, _ := strconv.Atoi(c.Params("id"))
 	userID := c.Locals("user_id").(int)
 	role := c.Locals("role").(string)
 
-	var req models.UpdatePekerjaanRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Invalid request body",
-		})
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
-	// Validasi field wajib
-	if req.NamaPerusahaan == "" || req.PosisiJabatan == "" || req.BidangIndustri == "" || req.LokasiKerja == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Field wajib diisi",
-		})
+	var req models.UpdatePekerjaanRequest
+	if err := validate.Body(c, &req); err != nil {
+		return err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return apperror.Invalid(err)
 	}
 
 	// Ambil data pekerjaan berdasarkan ID
-	data, err := repository.GetPekerjaanByID(id)
+	data, err := repository.GetPekerjaanByID(c.UserContext(), id)
+	if err == repository.ErrPekerjaanTidakDitemukan {
+		return err
+	}
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "Data tidak ditemukan",
-		})
+		return apperror.Internal("", err)
 	}
 
 	// Jika bukan admin, pastikan user hanya bisa ubah datanya sendiri
 	if role != "admin" && data.CreatedBy != userID {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error": "Kamu tidak punya akses untuk update data ini",
-		})
+		return apperror.Forbidden("pekerjaan.forbidden_update")
 	}
 
+	before := data
+
 	// Update field
 	data.NamaPerusahaan = req.NamaPerusahaan
 	data.PosisiJabatan = req.PosisiJabatan
 	data.BidangIndustri = req.BidangIndustri
 	data.LokasiKerja = req.LokasiKerja
 	data.GajiRange = req.GajiRange
+	data.TanggalMulaiKerja = tMulai
+	data.TanggalSelesaiKerja = tSelesai
 	data.StatusPekerjaan = req.StatusPekerjaan
 	data.DeskripsiPekerjaan = req.DeskripsiPekerjaan
 
-	// Tanggal mulai kerja
-	if req.TanggalMulaiKerja != "" {
-		t, err := time.Parse("2006-01-02", req.TanggalMulaiKerja)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Format tanggal mulai kerja tidak valid",
-			})
-		}
-		data.TanggalMulaiKerja = t
-	}
-
-	// Tanggal selesai kerja (optional)
-	if req.TanggalSelesaiKerja != "" {
-		t, err := time.Parse("2006-01-02", req.TanggalSelesaiKerja)
-		if err != nil {
-			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-				"error": "Format tanggal selesai kerja tidak valid",
-			})
-		}
-		data.TanggalSelesaiKerja = &t
-	} else {
-		data.TanggalSelesaiKerja = nil
-	}
-
 	// Simpan ke database
-	if err := repository.UpdatePekerjaan(&data); err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Gagal memperbarui data",
-		})
+	err = repository.UpdatePekerjaan(c.UserContext(), &data, ifMatch)
+	if err == repository.ErrVersionConflict || err == repository.ErrPekerjaanTidakDitemukan {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("data.update_failed", err)
 	}
+	recordAudit(c, audit.ActionUpdate, audit.EntityPekerjaan, data.ID, before, data)
 
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
 	return c.JSON(fiber.Map{
-		"message": "Data pekerjaan berhasil diperbarui",
+		"message": i18n.Msg(c, "pekerjaan.updated"),
 		"data":    data,
 	})
 }
@@ -253,39 +396,60 @@ func UpdatePekerjaanService(c *fiber.Ctx) error {
 // }
 
 
+// DeletePekerjaanService godoc
+// @Summary Hapus pekerjaan (soft delete)
+// @Description Memindahkan pekerjaan ke trash. Non-admin hanya bisa menghapus data miliknya.
+// @Tags Pekerjaan
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [delete]
 func DeletePekerjaanService(c *fiber.Ctx) error {
     userID := c.Locals("user_id").(int)
     role := c.Locals("role").(string)
     id, _ := strconv.Atoi(c.Params("id"))
 
-    var res sql.Result
-    var err error
-
-    if role == "admin" {
-        res, err = postgresql.DB.Exec("UPDATE pekerjaan_alumni SET deleted_at=NOW() WHERE id=$1", id)
-    } else {
-        res, err = postgresql.DB.Exec("UPDATE pekerjaan_alumni SET deleted_at=NOW() WHERE id=$1 AND created_by=$2", id, userID)
-    }
-
+    ifMatch, err := ifMatchVersion(c)
     if err != nil {
-        return c.Status(500).JSON(fiber.Map{"error": "DB error"})
+        return apperror.Invalid(err)
     }
 
-    rows, _ := res.RowsAffected()
-    if rows == 0 {
-        return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh hapus data ini"})
+    err = repository.SoftDeletePekerjaan(c.UserContext(), id, userID, role, ifMatch)
+    if err == repository.ErrVersionConflict || err == repository.ErrPekerjaanTidakDitemukan || err == repository.ErrPekerjaanBukanMilik {
+        return err
     }
+    if err != nil {
+        return apperror.Internal("pekerjaan.delete_failed", err)
+    }
+    recordAudit(c, audit.ActionDelete, audit.EntityPekerjaan, id,
+        fiber.Map{"deleted_at": nil}, fiber.Map{"deleted_at": time.Now(), "deleted_by": userID})
 
-    return c.JSON(fiber.Map{"message": "Riwayat pekerjaan berhasil dihapus (soft delete)"})
+    return c.JSON(fiber.Map{"message": i18n.Msg(c, "pekerjaan.deleted")})
 }
 
+// GetTrashPekerjaanService godoc
+// @Summary Menampilkan pekerjaan di trash
+// @Description Daftar pekerjaan yang di-soft delete. Non-admin hanya melihat data miliknya.
+// @Tags Pekerjaan
+// @Produce json
+// @Success 200 {object} object{success=bool,count=int,data=[]models.GetTrashPekerjaan}
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/trash [get]
 func GetTrashPekerjaanService(c *fiber.Ctx) error {
 	userRole := c.Locals("role").(string)
 	userID := c.Locals("user_id").(int)
 
-	allTrash, err := repository.GetTrashPekerjaan()
+	allTrash, err := repository.GetTrashPekerjaan(c.UserContext())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data trash"})
+		return apperror.Internal("trash.fetch_failed", err)
 	}
 
 		if userRole == "user" {
@@ -312,72 +476,135 @@ func GetTrashPekerjaanService(c *fiber.Ctx) error {
 }
 
 
+// RestorePekerjaanService godoc
+// @Summary Restore pekerjaan dari trash
+// @Description Mengembalikan satu pekerjaan dari trash. Non-admin hanya bisa restore data miliknya.
+// @Tags Pekerjaan
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/restore/{id} [put]
 func RestorePekerjaanService(c *fiber.Ctx) error {
 	id, err := strconv.Atoi(c.Params("id"))
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
 	userRole := c.Locals("role")
 	userID := c.Locals("user_id").(int)
 
 	// Ambil semua trash dulu
-	trash, err := repository.GetTrashPekerjaan()
+	trash, err := repository.GetTrashPekerjaan(c.UserContext())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data"})
+		return apperror.Internal("data.fetch_failed", err)
 	}
 
 	// Pastikan user hanya restore miliknya
 	for _, t := range trash {
 		if int(t.ID) == id {
 			if userRole == "user" && t.CreatedBy != userID {
-				return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh mengakses data milik orang lain"})
+				return apperror.Forbidden("data.forbidden_access")
 			}
-			err = repository.RestorePekerjaan(id)
+			err = repository.RestorePekerjaan(c.UserContext(), id)
 			if err != nil {
-				return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+				return apperror.Internal("", err)
 			}
-			return c.JSON(fiber.Map{"message": "Data berhasil direstore"})
+			recordAudit(c, audit.ActionRestore, audit.EntityPekerjaan, id,
+				fiber.Map{"deleted_at": t.DeletedAt, "deleted_by": t.DeletedBy}, fiber.Map{"deleted_at": nil, "deleted_by": nil})
+			return c.JSON(fiber.Map{"message": i18n.Msg(c, "data.restored")})
 		}
 	}
-	return c.Status(404).JSON(fiber.Map{"error": "Data tidak ditemukan"})
+	return apperror.NotFound("data.not_found")
 }
 
+// HardDeletePekerjaanService godoc
+// @Summary Hapus permanen pekerjaan
+// @Description Menghapus permanen pekerjaan yang sudah ada di trash. Non-admin hanya bisa menghapus data miliknya.
+// @Tags Pekerjaan
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Success 200 {object} object{message=string}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/hard-delete/{id} [delete]
 func HardDeletePekerjaanService(c *fiber.Ctx) error {
 	id, err := strconv.ParseInt(c.Params("id"), 10, 64)
 	if err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "ID tidak valid"})
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
 	}
 
 	userRole := c.Locals("role")
 	userID := c.Locals("user_id").(int)
 
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
 	// Ambil semua trash
-	trash, err := repository.GetTrashPekerjaan()
+	trash, err := repository.GetTrashPekerjaan(c.UserContext())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal mengambil data"})
+		return apperror.Internal("data.fetch_failed", err)
 	}
 
 for _, t := range trash {
     if int64(t.ID) == id { // konversi t.ID ke int64
         if userRole == "user" && t.CreatedBy != userID {
-            return c.Status(403).JSON(fiber.Map{"error": "Tidak boleh menghapus data milik orang lain"})
+            return apperror.Forbidden("data.forbidden_delete")
+        }
+        err = repository.HardDeletePekerjaanByID(c.UserContext(), id, ifMatch)
+        if err == repository.ErrVersionConflict {
+            return err
         }
-        err = repository.HardDeletePekerjaanByID(id)
         if err != nil {
-            return c.Status(500).JSON(fiber.Map{"error": err.Error()})
+            return apperror.Internal("", err)
         }
-        return c.JSON(fiber.Map{"message": fmt.Sprintf("Data dengan ID %d dihapus permanen", id)})
+        recordAudit(c, audit.ActionHardDelete, audit.EntityPekerjaan, id, t, nil)
+        return c.JSON(fiber.Map{"message": i18n.Msg(c, "data.hard_deleted", id)})
     }
 }
 
-	return c.Status(404).JSON(fiber.Map{"error": "Data tidak ditemukan"})
+	return apperror.NotFound("data.not_found")
 }
 
 
 
+// pekerjaanSortColumns -> kolom pekerjaan yang boleh dipakai untuk sorting (list & export)
+var pekerjaanSortColumns = map[string]bool{
+	"id":                  true,
+	"nama_perusahaan":     true,
+	"posisi_jabatan":      true,
+	"tanggal_mulai_kerja": true,
+	"created_at":          true,
+}
+
+// pekerjaanFilterFields -> field pekerjaan yang boleh dipakai di ?filter=
+var pekerjaanFilterFields = filter.Schema{
+	"id":                    {Kind: filter.Int, Column: "id"},
+	"alumni_id":             {Kind: filter.Int, Column: "alumni_id"},
+	"nama_perusahaan":       {Kind: filter.String, Column: "nama_perusahaan"},
+	"posisi_jabatan":        {Kind: filter.String, Column: "posisi_jabatan"},
+	"bidang_industri":       {Kind: filter.String, Column: "bidang_industri"},
+	"lokasi_kerja":          {Kind: filter.String, Column: "lokasi_kerja"},
+	"gaji_range":            {Kind: filter.String, Column: "gaji_range"},
+	"status_pekerjaan":      {Kind: filter.String, Column: "status_pekerjaan"},
+	"tanggal_mulai_kerja":   {Kind: filter.Time, Column: "tanggal_mulai_kerja"},
+	"tanggal_selesai_kerja": {Kind: filter.Time, Column: "tanggal_selesai_kerja"},
+	"created_at":            {Kind: filter.Time, Column: "created_at"},
+}
+
 // GetPekerjaanPaginated -> ambil data pekerjaan dengan pagination, sorting, dan search
-func GetPekerjaanPaginated(pageStr, limitStr, sortBy, order, search string) (*models.PekerjaanResponse, error) {
+func GetPekerjaanPaginated(ctx context.Context, pageStr, limitStr, sortBy, order, search string) (*models.PekerjaanResponse, error) {
 	page, _ := strconv.Atoi(pageStr)
 	if page < 1 {
 		page = 1
@@ -390,14 +617,7 @@ func GetPekerjaanPaginated(pageStr, limitStr, sortBy, order, search string) (*mo
 
 	offset := (page - 1) * limit
 
-	sortByWhitelist := map[string]bool{
-		"id":                   true,
-		"nama_perusahaan":      true,
-		"posisi_jabatan":       true,
-		"tanggal_mulai_kerja": true,
-		"created_at":           true,
-	}
-	if _, ok := sortByWhitelist[sortBy]; !ok {
+	if !pekerjaanSortColumns[sortBy] {
 		sortBy = "id"
 	}
 
@@ -406,12 +626,12 @@ func GetPekerjaanPaginated(pageStr, limitStr, sortBy, order, search string) (*mo
 		order = "asc"
 	}
 
-	pekerjaan, err := repository.GetPekerjaanPaginated(search, sortBy, order, limit, offset)
+	pekerjaan, err := repository.GetPekerjaanPaginated(ctx, search, sortBy, order, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	total, err := repository.CountPekerjaan(search)
+	total, err := repository.CountPekerjaan(ctx, search)
 	if err != nil {
 		return nil, err
 	}
@@ -432,6 +652,295 @@ func GetPekerjaanPaginated(pageStr, limitStr, sortBy, order, search string) (*mo
 	return response, nil
 }
 
-func SoftDeletePekerjaan(id int, userID int, role string) error {
-    return repository.SoftDeletePekerjaan(id, userID, role)
+func SoftDeletePekerjaan(ctx context.Context, id int, userID int, role string) error {
+    return repository.SoftDeletePekerjaan(ctx, id, userID, role, 0)
+}
+
+// pekerjaanFromCreateRequest memvalidasi satu item bulk create
+func pekerjaanFromCreateRequest(req models.CreatePekerjaanRequest, userID int) (*models.Pekerjaan, error) {
+	if err := validate.Check(&req); err != nil {
+		return nil, err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Pekerjaan{
+		AlumniID:            req.AlumniID,
+		NamaPerusahaan:      req.NamaPerusahaan,
+		PosisiJabatan:       req.PosisiJabatan,
+		BidangIndustri:      req.BidangIndustri,
+		LokasiKerja:         req.LokasiKerja,
+		GajiRange:           req.GajiRange,
+		TanggalMulaiKerja:   tMulai,
+		TanggalSelesaiKerja: tSelesai,
+		StatusPekerjaan:     req.StatusPekerjaan,
+		DeskripsiPekerjaan:  req.DeskripsiPekerjaan,
+		CreatedBy:           userID,
+	}, nil
+}
+
+// pekerjaanFromBulkUpdateItem memvalidasi satu item bulk update
+func pekerjaanFromBulkUpdateItem(item models.BulkUpdatePekerjaanItem) (*models.Pekerjaan, error) {
+	if err := validate.Check(&item); err != nil {
+		return nil, err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(item.TanggalMulaiKerja, item.TanggalSelesaiKerja)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Pekerjaan{
+		ID:                  item.ID,
+		NamaPerusahaan:      item.NamaPerusahaan,
+		PosisiJabatan:       item.PosisiJabatan,
+		BidangIndustri:      item.BidangIndustri,
+		LokasiKerja:         item.LokasiKerja,
+		GajiRange:           item.GajiRange,
+		TanggalMulaiKerja:   tMulai,
+		TanggalSelesaiKerja: tSelesai,
+		StatusPekerjaan:     item.StatusPekerjaan,
+		DeskripsiPekerjaan:  item.DeskripsiPekerjaan,
+		Version:             item.Version,
+	}, nil
+}
+
+// runBulkPekerjaan menjalankan bulk.Run untuk bulk create/update lalu membentuk response per item.
+// save mengembalikan data lama per item untuk audit (nil pada bulk create).
+func runBulkPekerjaan(c *fiber.Ctx, action, mode string, atomic bool, n int,
+	parseItem func(i int) (*models.Pekerjaan, error),
+	save func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error),
+) error {
+	results := make([]models.BulkItemResult, n)
+	var before []*models.Pekerjaan
+	items, err := bulk.Run(i18n.Lang(c), atomic, n, parseItem,
+		func(list []*models.Pekerjaan) (errs []error, err error) {
+			before, errs, err = save(list)
+			return errs, err
+		},
+		func(i, j int, p *models.Pekerjaan) {
+			results[i].ID = p.ID
+			var old *models.Pekerjaan
+			if before != nil {
+				old = before[j]
+			}
+			recordAudit(c, action, audit.EntityPekerjaan, p.ID, old, p)
+		},
+	)
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "bulk pekerjaan gagal", "error", err)
+		return apperror.Internal("bulk.save_failed", err)
+	}
+
+	for i, it := range items {
+		results[i].Index, results[i].Success, results[i].Error = it.Index, it.Success, it.Error
+		results[i].Status, results[i].Code = it.Status, it.Code
+	}
+	response := &models.BulkResponse{Mode: mode, Total: n, Results: results}
+	response.SuccessCount, response.FailedCount = bulk.Count(items)
+	if atomic && response.FailedCount > 0 {
+		return c.Status(400).JSON(response)
+	}
+	return c.JSON(response)
+}
+
+// BulkCreatePekerjaanService -> POST /api/pekerjaan/bulk
+// @Summary Tambah banyak data pekerjaan sekaligus
+// @Description Membuat banyak data pekerjaan dalam satu request. Mode all_or_nothing memakai satu transaksi (semua berhasil atau semua batal), mode best_effort menyimpan item yang valid saja.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param mode query string false "Mode bulk (all_or_nothing/best_effort, default: all_or_nothing)"
+// @Param body body []models.CreatePekerjaanRequest true "Daftar pekerjaan baru"
+// @Success 200 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkResponse
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/bulk [post]
+func BulkCreatePekerjaanService(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+
+	mode := c.Query("mode", bulk.ModeAllOrNothing)
+	atomic, err := bulk.ParseMode(mode)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var reqs []models.CreatePekerjaanRequest
+	if err := c.BodyParser(&reqs); err != nil {
+		return apperror.Validation("bulk.array_required")
+	}
+	if len(reqs) == 0 || len(reqs) > bulk.MaxItems {
+		return apperror.Validation("bulk.item_count").WithArgs(bulk.MaxItems)
+	}
+
+	return runBulkPekerjaan(c, audit.ActionCreate, mode, atomic, len(reqs),
+		func(i int) (*models.Pekerjaan, error) {
+			return pekerjaanFromCreateRequest(reqs[i], userID)
+		},
+		func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error) {
+			errs, err := repository.BulkCreatePekerjaan(c.UserContext(), list, atomic)
+			return nil, errs, err
+		},
+	)
+}
+
+// BulkUpdatePekerjaanService -> PATCH /api/pekerjaan/bulk
+// @Summary Update banyak data pekerjaan sekaligus
+// @Description Mengubah banyak data pekerjaan dalam satu request. Non-admin hanya bisa mengubah data miliknya.
+// @Description Item yang menyertakan version hanya diubah jika versinya masih sama; jika tidak, item gagal dengan status 412 dan code version_conflict.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param mode query string false "Mode bulk (all_or_nothing/best_effort, default: all_or_nothing)"
+// @Param body body []models.BulkUpdatePekerjaanItem true "Daftar pekerjaan yang akan diupdate (wajib menyertakan id)"
+// @Success 200 {object} models.BulkResponse
+// @Failure 400 {object} models.BulkResponse
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/bulk [patch]
+func BulkUpdatePekerjaanService(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	mode := c.Query("mode", bulk.ModeAllOrNothing)
+	atomic, err := bulk.ParseMode(mode)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	var items []models.BulkUpdatePekerjaanItem
+	if err := c.BodyParser(&items); err != nil {
+		return apperror.Validation("bulk.array_required")
+	}
+	if len(items) == 0 || len(items) > bulk.MaxItems {
+		return apperror.Validation("bulk.item_count").WithArgs(bulk.MaxItems)
+	}
+
+	return runBulkPekerjaan(c, audit.ActionUpdate, mode, atomic, len(items),
+		func(i int) (*models.Pekerjaan, error) {
+			return pekerjaanFromBulkUpdateItem(items[i])
+		},
+		func(list []*models.Pekerjaan) ([]*models.Pekerjaan, []error, error) {
+			return repository.BulkUpdatePekerjaan(c.UserContext(), list, atomic, userID, role)
+		},
+	)
+}
+
+// ifMatchVersion membaca header If-Match; 0 berarti client tidak meminta pengecekan versi
+func ifMatchVersion(c *fiber.Ctx) (int, error) {
+	return etag.ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+}
+
+// isMergePatchRequest memastikan body PATCH dikirim sebagai JSON Merge Patch (atau JSON biasa)
+func isMergePatchRequest(c *fiber.Ctx) bool {
+	ct := strings.ToLower(c.Get("Content-Type"))
+	return strings.HasPrefix(ct, mergepatch.ContentType) || strings.HasPrefix(ct, fiber.MIMEApplicationJSON)
+}
+
+// pekerjaanToUpdateRequest mengubah data pekerjaan menjadi dokumen yang bisa di-patch
+func pekerjaanToUpdateRequest(p models.Pekerjaan) models.UpdatePekerjaanRequest {
+	req := models.UpdatePekerjaanRequest{
+		NamaPerusahaan:     p.NamaPerusahaan,
+		PosisiJabatan:      p.PosisiJabatan,
+		BidangIndustri:     p.BidangIndustri,
+		LokasiKerja:        p.LokasiKerja,
+		GajiRange:          p.GajiRange,
+		TanggalMulaiKerja:  p.TanggalMulaiKerja.Format("2006-01-02"),
+		StatusPekerjaan:    p.StatusPekerjaan,
+		DeskripsiPekerjaan: p.DeskripsiPekerjaan,
+	}
+	if p.TanggalSelesaiKerja != nil {
+		req.TanggalSelesaiKerja = p.TanggalSelesaiKerja.Format("2006-01-02")
+	}
+	return req
+}
+
+// PatchPekerjaanService -> PATCH /api/pekerjaan/:id, hanya field yang dikirim yang diubah (RFC 7396)
+// @Summary Update sebagian data pekerjaan
+// @Description Mengubah hanya field yang dikirim (RFC 7396). Field bernilai null akan dikosongkan. Hasil merge tetap divalidasi.
+// @Tags Pekerjaan
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path int true "ID pekerjaan"
+// @Param If-Match header string false "ETag versi data yang terakhir dibaca"
+// @Param body body models.UpdatePekerjaanRequest true "Field pekerjaan yang akan diubah"
+// @Success 200 {object} object{message=string,data=models.Pekerjaan}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 412 {object} apperror.Problem
+// @Failure 415 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/{id} [patch]
+func PatchPekerjaanService(c *fiber.Ctx) error {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return apperror.Validation("invalid_id").WithCode(apperror.CodeInvalidID)
+	}
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	if !isMergePatchRequest(c) {
+		return apperror.UnsupportedMediaType("request.content_type").WithArgs(mergepatch.ContentType)
+	}
+
+	ifMatch, err := ifMatchVersion(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	data, err := repository.GetPekerjaanByID(c.UserContext(), id)
+	if err == repository.ErrPekerjaanTidakDitemukan {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("", err)
+	}
+
+	if role != "admin" && data.CreatedBy != userID {
+		return apperror.Forbidden("pekerjaan.forbidden_update")
+	}
+
+	var req models.UpdatePekerjaanRequest
+	if err := mergepatch.ApplyTo(pekerjaanToUpdateRequest(data), c.Body(), &req); err != nil {
+		return apperror.Invalid(err)
+	}
+
+	// Validasi hasil merge, bukan hanya isi patch
+	if err := validate.Check(&req); err != nil {
+		return err
+	}
+	tMulai, tSelesai, err := bulk.ParseTanggalPekerjaan(req.TanggalMulaiKerja, req.TanggalSelesaiKerja)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	before := data
+	data.NamaPerusahaan = req.NamaPerusahaan
+	data.PosisiJabatan = req.PosisiJabatan
+	data.BidangIndustri = req.BidangIndustri
+	data.LokasiKerja = req.LokasiKerja
+	data.GajiRange = req.GajiRange
+	data.TanggalMulaiKerja = tMulai
+	data.TanggalSelesaiKerja = tSelesai
+	data.StatusPekerjaan = req.StatusPekerjaan
+	data.DeskripsiPekerjaan = req.DeskripsiPekerjaan
+
+	err = repository.UpdatePekerjaan(c.UserContext(), &data, ifMatch)
+	if err == repository.ErrVersionConflict || err == repository.ErrPekerjaanTidakDitemukan {
+		return err
+	}
+	if err != nil {
+		return apperror.Internal("data.update_failed", err)
+	}
+	recordAudit(c, audit.ActionUpdate, audit.EntityPekerjaan, data.ID, before, data)
+
+	c.Set(fiber.HeaderETag, etag.Format(data.Version))
+	return c.JSON(fiber.Map{
+		"message": i18n.Msg(c, "pekerjaan.updated"),
+		"data":    data,
+	})
 }
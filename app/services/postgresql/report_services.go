@@ -0,0 +1,92 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/jobs"
+	"alumniproject/utils/report"
+	"github.com/gofiber/fiber/v2"
+)
+
+// parseTracerFilter membaca query angkatan & jurusan (kosong = semua)
+func parseTracerFilter(c *fiber.Ctx) (report.TracerFilter, error) {
+	f := report.TracerFilter{Jurusan: strings.TrimSpace(c.Query("jurusan"))}
+	if v := c.Query("angkatan"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return f, apperror.Validation("report.angkatan_invalid")
+		}
+		f.Angkatan = n
+	}
+	return f, nil
+}
+
+// GetTracerReportService -> GET /reports/tracer?angkatan=&jurusan=[&async=true]
+// Mengunduh laporan tracer study (PDF). async=true -> dibuat sebagai job, hasilnya diunduh dari /jobs/:id/result.
+// @Summary Laporan tracer study (PDF)
+// @Description Mengunduh laporan tracer study per angkatan / jurusan (khusus admin). async=true -> dibuat sebagai job, hasilnya diunduh dari /api/v1/jobs/{id}/result.
+// @Tags Reports
+// @Produce application/pdf
+// @Param angkatan query int false "Filter angkatan"
+// @Param jurusan query string false "Filter jurusan"
+// @Param async query bool false "true -> buat laporan sebagai job latar belakang"
+// @Success 200 {file} file
+// @Success 202 {object} object{success=bool,message=string,data=jobs.Job}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /reports/tracer [get]
+func GetTracerReportService(c *fiber.Ctx) error {
+	f, err := parseTracerFilter(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+	if c.QueryBool("async") {
+		return JobHandler.Enqueue(c, jobs.TypeTracerReport, f)
+	}
+
+	stats, err := repository.GetTracerStats(c.UserContext(), f)
+	if err != nil {
+		return apperror.Internal("report.fetch_failed", err)
+	}
+
+	now := time.Now()
+	var buf bytes.Buffer
+	if err := report.WriteTracerPDF(&buf, f, stats, now); err != nil {
+		return apperror.Internal("report.pdf_failed", err)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+report.FileName(f, now)+`"`)
+	return c.Send(buf.Bytes())
+}
+
+// runTracerReportJob -> handler job tracer_report, hasilnya file PDF
+func runTracerReportJob(ctx context.Context, run *jobs.Run) error {
+	var f report.TracerFilter
+	if err := run.Decode(&f); err != nil {
+		return err
+	}
+
+	run.Progress(10, "Mengambil data laporan")
+	stats, err := repository.GetTracerStats(ctx, f)
+	if err != nil {
+		return err
+	}
+
+	run.Progress(60, "Menyusun PDF")
+	now := time.Now()
+	file, err := run.CreateResult(report.FileName(f, now), "application/pdf")
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return report.WriteTracerPDF(file, f, stats, now)
+}
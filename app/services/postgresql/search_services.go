@@ -0,0 +1,70 @@
+package service
+
+import (
+	"alumniproject/app/models/postgresql"
+	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/search"
+	"github.com/gofiber/fiber/v2"
+)
+
+// SearchService -> GET /search?q=&type=alumni|pekerjaan&limit=
+// Mencari alumni (nama, NIM, jurusan) dan pekerjaan (perusahaan, posisi, bidang, deskripsi) sekaligus.
+// Hasil dikelompokkan per tipe entity dan diurutkan berdasarkan skor relevansi.
+// @Summary Pencarian alumni dan pekerjaan
+// @Description Mencari alumni (nama, NIM, jurusan) dan pekerjaan (perusahaan, posisi, bidang, deskripsi) sekaligus. Hasil dikelompokkan per tipe dan diurutkan berdasarkan skor relevansi.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Kata kunci (minimal 2 karakter)"
+// @Param type query string false "alumni atau pekerjaan (default: keduanya)"
+// @Param limit query int false "Jumlah hasil per tipe (default 10, maks 50)"
+// @Success 200 {object} object{success=bool,query=string,data=[]models.SearchGroup}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /search [get]
+func SearchService(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	opts, err := search.Parse(c.Query("q"), c.Query("type"), c.QueryInt("limit", search.DefaultLimit))
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	groups := []*models.SearchGroup{}
+	if opts.Includes(search.TypeAlumni) {
+		group, err := repository.SearchAlumni(c.UserContext(), opts.Query, opts.Limit, role, userID)
+		if err == repository.ErrSearchTidakTersedia {
+			return err
+		}
+		if err != nil {
+			return apperror.Internal("alumni.search_failed", err)
+		}
+		groups = append(groups, group)
+	}
+	if opts.Includes(search.TypePekerjaan) {
+		group, err := repository.SearchPekerjaan(c.UserContext(), opts.Query, opts.Limit, role, userID)
+		if err == repository.ErrSearchTidakTersedia {
+			return err
+		}
+		if err != nil {
+			return apperror.Internal("pekerjaan.search_failed", err)
+		}
+		groups = append(groups, group)
+	}
+
+	terms := search.Terms(opts.Query)
+	for _, g := range groups {
+		for i := range g.Items {
+			g.Items[i].Snippet = search.Highlight(g.Items[i].Text, terms)
+		}
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"query":   opts.Query,
+		"data":    groups,
+	})
+}
@@ -1,11 +1,13 @@
 package service
 
 import (
-	"log"
+	"log/slog"
 
 	"github.com/gofiber/fiber/v2"
 	"alumniproject/app/models/postgresql"
 	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/i18n"
 )
 
 // AlumniPekerjaanResponse wraps the response for alumni by status pekerjaan
@@ -15,18 +17,24 @@ type AlumniPekerjaanResponse struct {
 }
 
 // GetAlumniByStatusPekerjaan retrieves alumni by job status
+// @Summary Alumni berdasarkan status pekerjaan
+// @Description Mengambil alumni dengan status pekerjaan tertentu. count berisi jumlah alumni yang bekerja lebih dari 1 tahun.
+// @Tags Alumni Pekerjaan
+// @Produce json
+// @Param status path string true "Status pekerjaan (aktif, selesai, resigned)"
+// @Success 200 {object} object{success=bool,message=string,count=int,data=[]models.AlumniPekerjaan}
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni-pekerjaan/status/{status} [get]
 func GetAlumniByStatusPekerjaan(c *fiber.Ctx) error {
 	status := c.Params("status")
 	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni-pekerjaan/status dengan status: %s", username, status)
+	slog.DebugContext(c.UserContext(), "get alumni by status pekerjaan", "username", username, "status", status)
 
-	response, err := repository.GetAlumniByStatusPekerjaan(status)
+	response, err := repository.GetAlumniByStatusPekerjaan(c.UserContext(), status)
 	if err != nil {
-		log.Printf("Error mengambil data alumni: %v", err)
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"error":   "Gagal mengambil data alumni berdasarkan status pekerjaan: " + err.Error(),
-		})
+		slog.ErrorContext(c.UserContext(), "gagal mengambil data alumni", "error", err)
+		return apperror.Internal("alumni.by_status_failed", err)
 	}
 
 	if len(response) == 0 {
@@ -34,7 +42,7 @@ func GetAlumniByStatusPekerjaan(c *fiber.Ctx) error {
 			"success": false,
 			"count":   0,
 			"data":    []models.AlumniPekerjaan{},
-			"message": "Tidak ada data alumni dengan status pekerjaan " + status,
+			"message": i18n.Msg(c, "alumni.by_status_empty", status),
 		})
 	}
 
@@ -44,21 +52,26 @@ func GetAlumniByStatusPekerjaan(c *fiber.Ctx) error {
 		"success": true,
 		"count":   totalCount,
 		"data":    response,
-		"message": "Data alumni dengan status pekerjaan " + status + " berhasil diambil",
+		"message": i18n.Msg(c, "alumni.by_status_fetched", status),
 	})
 }
 
 // GetAlumniWithLongTermJobs retrieves alumni with active jobs lasting more than 1 year
+// @Summary Alumni dengan pekerjaan lebih dari 1 tahun
+// @Description Mengambil alumni yang pekerjaan aktifnya sudah berjalan lebih dari 1 tahun. count berisi jumlah alumni tersebut.
+// @Tags Alumni Pekerjaan
+// @Produce json
+// @Success 200 {object} object{success=bool,message=string,count=int,data=[]models.AlumniPekerjaan}
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /alumni-pekerjaan/long-term [get]
 func GetAlumniWithLongTermJobs(c *fiber.Ctx) error {
 	username := c.Locals("username").(string)
-	log.Printf("User %s mengakses GET /api/alumni-pekerjaan/long-term", username)
+	slog.DebugContext(c.UserContext(), "get alumni pekerjaan long-term", "username", username)
 
-	response, err := repository.GetAlumniWithLongTermJobs()
+	response, err := repository.GetAlumniWithLongTermJobs(c.UserContext())
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{
-			"success": false,
-			"error":   "Gagal mengambil data alumni dengan pekerjaan lebih dari 1 tahun",
-		})
+		return apperror.Internal("alumni.long_employment_failed", err)
 	}
 
 	if len(response) == 0 {
@@ -66,7 +79,7 @@ func GetAlumniWithLongTermJobs(c *fiber.Ctx) error {
 			"success": false,
 			"count":   0,
 			"data":    []models.AlumniPekerjaan{},
-			"message": "Tidak ada data alumni dengan pekerjaan aktif lebih dari 1 tahun",
+			"message": i18n.Msg(c, "alumni.long_employment_empty"),
 		})
 	}
 
@@ -76,6 +89,6 @@ func GetAlumniWithLongTermJobs(c *fiber.Ctx) error {
 		"success": true,
 		"count":   totalCount,
 		"data":    response,
-		"message": "Data alumni dengan pekerjaan aktif lebih dari 1 tahun berhasil diambil",
+		"message": i18n.Msg(c, "alumni.long_employment_fetched"),
 	})
 }
\ No newline at end of file
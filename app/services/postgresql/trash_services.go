@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"text/tabwriter"
+	"time"
+
+	"alumniproject/app/models/postgresql"
+	"alumniproject/app/repository/postgresql"
+	"alumniproject/config"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/bulk"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/scheduler"
+	"github.com/gofiber/fiber/v2"
+)
+
+// PurgeTrash menghapus permanen alumni & pekerjaan yang sudah terlalu lama di trash
+func PurgeTrash(ctx context.Context, retentionDays int, dryRun bool) (*models.PurgeReport, error) {
+	if retentionDays < 0 {
+		return nil, fmt.Errorf("retensi tidak boleh negatif")
+	}
+
+	cfg := config.TrashConfig{RetentionDays: retentionDays}
+	cutoff := cfg.Cutoff(time.Now())
+
+	tables, err := repository.PurgeTrash(ctx, cutoff, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("gagal purge trash: %v", err)
+	}
+
+	return &models.PurgeReport{
+		DryRun:        dryRun,
+		RetentionDays: retentionDays,
+		Cutoff:        cutoff,
+		Tables:        tables,
+	}, nil
+}
+
+// WritePurgeReport menulis laporan purge dalam bentuk tabel (dipakai CLI)
+func WritePurgeReport(w io.Writer, r *models.PurgeReport) {
+	mode := "PURGE"
+	if r.DryRun {
+		mode = "DRY-RUN"
+	}
+	fmt.Fprintf(w, "%s trash (retensi %d hari, cutoff %s)\n", mode, r.RetentionDays, r.Cutoff.Format(time.RFC3339))
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TABLE\tPURGED\tCASCADED\tSKIPPED")
+	for _, t := range r.Tables {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", t.Table, t.Purged, t.Cascaded, t.Skipped)
+	}
+	tw.Flush()
+}
+
+// StartTrashPurgeScheduler menjalankan purge trash berkala sesuai config.
+// RetentionDays <= 0 -> scheduler tidak dijalankan.
+func StartTrashPurgeScheduler(ctx context.Context, cfg config.TrashConfig) {
+	if cfg.RetentionDays <= 0 {
+		slog.Info("purge trash otomatis nonaktif (TRASH_RETENTION_DAYS <= 0)")
+		return
+	}
+
+	scheduler.Every(ctx, "purge-trash", cfg.Interval, func(ctx context.Context) {
+		report, err := PurgeTrash(ctx, cfg.RetentionDays, cfg.DryRun)
+		if err != nil {
+			slog.ErrorContext(ctx, "purge trash gagal", "error", err)
+			return
+		}
+		for _, t := range report.Tables {
+			slog.InfoContext(ctx, "purge trash", "dry_run", report.DryRun, "table", t.Table,
+				"purged", t.Purged, "cascaded", t.Cascaded, "skipped", t.Skipped)
+		}
+	})
+}
+
+// parseTimeParam menerima tanggal (YYYY-MM-DD) atau timestamp RFC3339, kosong -> nil
+func parseTimeParam(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// parseTrashFilter membaca body TrashBatchRequest.
+// Request tanpa kriteria apa pun ditolak agar trash tidak terhapus/ter-restore semua tanpa sengaja.
+func parseTrashFilter(c *fiber.Ctx) (models.TrashFilter, error) {
+	var req models.TrashBatchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return models.TrashFilter{}, apperror.Validation("request.invalid_body")
+	}
+
+	before, err := parseTimeParam(req.DeletedBefore)
+	if err != nil {
+		return models.TrashFilter{}, apperror.Validation("request.datetime_format").WithArgs("deleted_before")
+	}
+
+	f := models.TrashFilter{
+		IDs:           req.IDs,
+		AlumniID:      req.AlumniID,
+		DeletedBy:     req.DeletedBy,
+		DeletedBefore: before,
+	}
+	if len(f.IDs) > bulk.MaxItems {
+		return f, apperror.Validation("trash.max_ids").WithArgs(bulk.MaxItems)
+	}
+	if !req.All && len(f.IDs) == 0 && f.AlumniID == nil && f.DeletedBy == nil && f.DeletedBefore == nil {
+		return f, apperror.Validation("trash.criteria_required")
+	}
+	return f, nil
+}
+
+// RestoreTrashPekerjaanService -> restore banyak pekerjaan sekaligus dari trash
+// @Summary Restore banyak pekerjaan dari trash
+// @Description Mengembalikan pekerjaan di trash berdasarkan daftar ID atau filter (alumni_id, deleted_by, deleted_before). Non-admin hanya bisa restore data miliknya.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param body body models.TrashBatchRequest true "Daftar ID atau filter data trash"
+// @Success 200 {object} object{success=bool,data=models.TrashBatchResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/trash/restore [post]
+func RestoreTrashPekerjaanService(c *fiber.Ctx) error {
+	return runTrashBatch(c, "restore", audit.ActionRestore, repository.RestorePekerjaanBatch)
+}
+
+// PurgeTrashPekerjaanService -> hapus permanen banyak pekerjaan sekaligus dari trash
+// @Summary Hapus permanen banyak pekerjaan dari trash
+// @Description Menghapus permanen pekerjaan di trash berdasarkan daftar ID atau filter (alumni_id, deleted_by, deleted_before). Gunakan all=true untuk mengosongkan trash. Non-admin hanya bisa menghapus data miliknya.
+// @Tags Pekerjaan
+// @Accept json
+// @Produce json
+// @Param body body models.TrashBatchRequest true "Daftar ID atau filter data trash"
+// @Success 200 {object} object{success=bool,data=models.TrashBatchResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /pekerjaan/trash/purge [post]
+func PurgeTrashPekerjaanService(c *fiber.Ctx) error {
+	return runTrashBatch(c, "purge", audit.ActionHardDelete, repository.PurgePekerjaanBatch)
+}
+
+func runTrashBatch(c *fiber.Ctx, action, auditAction string, run func(context.Context, models.TrashFilter, int, string) ([]int64, error)) error {
+	userID := c.Locals("user_id").(int)
+	role := c.Locals("role").(string)
+
+	f, err := parseTrashFilter(c)
+	if err != nil {
+		return apperror.Invalid(err)
+	}
+
+	ids, err := run(c.UserContext(), f, userID, role)
+	if err != nil {
+		return apperror.Internal("trash.process_failed", err)
+	}
+	for _, id := range ids {
+		recordAudit(c, auditAction, audit.EntityPekerjaan, id, fiber.Map{"in_trash": true}, fiber.Map{"in_trash": auditAction == audit.ActionHardDelete})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"data": models.TrashBatchResponse{
+			Action:   action,
+			Affected: len(ids),
+			IDs:      ids,
+		},
+	})
+}
@@ -1,45 +1,143 @@
 package service
 
 import (
+	"strconv"
+	"strings"
+	"time"
 	// "errors"
 
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/audit"
+	"alumniproject/utils/filter"
 	"github.com/gofiber/fiber/v2"
 	"alumniproject/app/models/postgresql"
 	"alumniproject/app/repository/postgresql"
+	"alumniproject/utils/i18n"
 	"alumniproject/utils/postgresql"
+	"alumniproject/utils/validate"
 )
 
 // Login handles user authentication and token generation
+// @Summary Login user
+// @Description Autentikasi dengan username/email dan password, lalu mengembalikan token JWT
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param body body models.LoginRequest true "Data login user"
+// @Success 200 {object} object{success=bool,message=string,data=models.LoginResponse}
+// @Failure 400 {object} apperror.Problem "Field tidak valid, detail per field di errors"
+// @Failure 401 {object} apperror.Problem "Username atau password salah"
+// @Failure 500 {object} apperror.Problem
+// @Router /login [post]
 func Login(c *fiber.Ctx) error {
 	var req models.LoginRequest
-	if err := c.BodyParser(&req); err != nil {
-		return c.Status(400).JSON(fiber.Map{"error": "Request body tidak valid"})
+	if err := validate.Body(c, &req); err != nil {
+		return err
 	}
 
-	if req.Username == "" || req.Password == "" {
-		return c.Status(400).JSON(fiber.Map{"error": "Username dan password harus diisi"})
-	}
-
-	user, passwordHash, err := repository.GetUserByUsernameOrEmail(req.Username)
+	user, passwordHash, err := repository.GetUserByUsernameOrEmail(c.UserContext(), req.Username)
 	if err != nil {
-		return c.Status(401).JSON(fiber.Map{"error": "Username atau password salah"})
+		return apperror.Unauthorized("auth.invalid_credentials")
 	}
 
 	if !postgresutils.CheckPassword(req.Password, passwordHash) {
-		return c.Status(401).JSON(fiber.Map{"error": "Username atau password salah"})
+		return apperror.Unauthorized("auth.invalid_credentials")
 	}
 
 	token, err := postgresutils.GenerateToken(user)
 	if err != nil {
-		return c.Status(500).JSON(fiber.Map{"error": "Gagal generate token"})
+		return apperror.Internal("auth.token_failed", err)
 	}
 
+	// Login belum melewati AuthRequired, jadi actor audit diisi manual
+	c.Locals("user_id", user.ID)
+	recordAudit(c, audit.ActionLogin, audit.EntityUser, user.ID, nil, fiber.Map{"last_login": time.Now()})
+
 	return c.JSON(fiber.Map{
 		"success": true,
-		"message": "Login berhasil",
+		"message": i18n.Msg(c, "auth.login_success"),
 		"data": fiber.Map{
 			"user":  user,
 			"token": token,
 		},
 	})
 }
+
+// userSortColumns -> kolom user yang boleh dipakai untuk sorting
+var userSortColumns = map[string]bool{
+	"id": true, "username": true, "email": true, "role": true, "created_at": true,
+}
+
+// userFilterFields -> field user yang boleh dipakai di ?filter=
+var userFilterFields = filter.Schema{
+	"id":         {Kind: filter.Int, Column: "id"},
+	"username":   {Kind: filter.String, Column: "username"},
+	"email":      {Kind: filter.String, Column: "email"},
+	"role":       {Kind: filter.String, Column: "role"},
+	"created_at": {Kind: filter.Time, Column: "created_at"},
+}
+
+// GetUsersService -> GET /users (admin), pagination, sorting, search, dan ?filter=
+// @Summary Menampilkan daftar user
+// @Description Mengambil daftar user dengan pagination, sorting, search, dan filter (khusus admin)
+// @Tags Users
+// @Produce json
+// @Param page query int false "Nomor halaman (default: 1)"
+// @Param limit query int false "Jumlah data per halaman (default: 10)"
+// @Param sortBy query string false "Kolom untuk sorting (id, username, email, role, created_at)"
+// @Param order query string false "Urutan sort asc/desc (default: asc)"
+// @Param search query string false "Cari berdasarkan username atau email"
+// @Param filter query string false "Filter field:operator:nilai, mis. role:eq:admin"
+// @Success 200 {object} models.UserResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /users [get]
+func GetUsersService(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	sortBy := c.Query("sortBy", "id")
+	if !userSortColumns[sortBy] {
+		sortBy = "id"
+	}
+	order := strings.ToLower(c.Query("order", "asc"))
+	if order != "desc" {
+		order = "asc"
+	}
+	search := c.Query("search", "")
+
+	f, err := filter.Parse(c.Query("filter"), userFilterFields)
+	if err != nil {
+		return apperror.Invalid(err).WithCode(apperror.CodeInvalidFilter)
+	}
+
+	users, err := repository.GetUsersRepo(c.UserContext(), search, sortBy, order, limit, (page-1)*limit, f)
+	if err != nil {
+		return apperror.Internal("user.fetch_failed", err)
+	}
+	total, err := repository.CountUsersRepo(c.UserContext(), search, f)
+	if err != nil {
+		return apperror.Internal("user.count_failed", err)
+	}
+
+	return c.JSON(&models.UserResponse{
+		Data: users,
+		Meta: &models.MetaInfo{
+			Page:   page,
+			Limit:  limit,
+			Total:  total,
+			Pages:  (total + limit - 1) / limit,
+			SortBy: sortBy,
+			Order:  order,
+			Search: search,
+			Filter: f.String(),
+		},
+	})
+}
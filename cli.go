@@ -0,0 +1,86 @@
+package main
+
+import (
+    "context"
+    "flag"
+    "fmt"
+    "os"
+    "os/signal"
+    "syscall"
+
+    "alumniproject/config"
+    "alumniproject/database/mongodb"
+    "alumniproject/database/postgresql"
+    mongoService "alumniproject/app/services/mongodb"
+    pgService "alumniproject/app/services/postgresql"
+)
+
+// runCommand menjalankan sub-command CLI (contoh: `go run . purge-trash -dry-run`) lalu exit.
+// Jika args bukan sub-command, tidak melakukan apa-apa sehingga server dijalankan seperti biasa.
+func runCommand(dbType string, args []string) {
+    if len(args) == 0 {
+        return
+    }
+
+    switch args[0] {
+    case "purge-trash":
+        os.Exit(purgeTrashCommand(dbType, args[1:]))
+    }
+}
+
+// purgeTrashCommand -> hapus permanen data trash sekali jalan lalu cetak laporan per tabel/collection
+func purgeTrashCommand(dbType string, args []string) int {
+    cfg := config.LoadTrashConfig()
+
+    fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+    dryRun := fs.Bool("dry-run", false, "hanya hitung data yang akan dihapus, tanpa menghapus")
+    retention := fs.Int("retention-days", cfg.RetentionDays, "hapus data yang sudah di trash lebih dari N hari (default dari TRASH_RETENTION_DAYS)")
+    if err := fs.Parse(args); err != nil {
+        return 2
+    }
+
+    // Retensi 0 berarti seluruh trash dihapus, jadi harus diminta secara eksplisit
+    explicit := false
+    fs.Visit(func(f *flag.Flag) {
+        if f.Name == "retention-days" {
+            explicit = true
+        }
+    })
+    if *retention < 0 {
+        fmt.Fprintln(os.Stderr, "❌ -retention-days / TRASH_RETENTION_DAYS tidak boleh negatif")
+        return 2
+    }
+    if *retention == 0 && !explicit {
+        fmt.Fprintln(os.Stderr, "❌ Set -retention-days atau TRASH_RETENTION_DAYS (gunakan -retention-days 0 untuk mengosongkan seluruh trash)")
+        return 2
+    }
+
+    // Ctrl+C / SIGTERM membatalkan query yang sedang berjalan
+    ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+    defer stop()
+
+    switch dbType {
+    case "mongodb":
+        database.ConnectMongo(config.LoadDatabaseConfig())
+        report, err := mongoService.PurgeTrash(ctx, *retention, *dryRun)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, "❌", err)
+            return 1
+        }
+        mongoService.WritePurgeReport(os.Stdout, report)
+
+    case "postgres":
+        postgresql.ConnectPostgres(config.LoadDatabaseConfig())
+        report, err := pgService.PurgeTrash(ctx, *retention, *dryRun)
+        if err != nil {
+            fmt.Fprintln(os.Stderr, "❌", err)
+            return 1
+        }
+        pgService.WritePurgeReport(os.Stdout, report)
+
+    default:
+        fmt.Fprintf(os.Stderr, "❌ Unknown DB_TYPE: %s\n", dbType)
+        return 1
+    }
+    return 0
+}
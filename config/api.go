@@ -0,0 +1,62 @@
+package config
+
+import (
+    "log"
+    "os"
+    "time"
+
+    "alumniproject/utils/apiversion"
+)
+
+// APIConfig -> pengaturan versi API
+type APIConfig struct {
+    Alias apiversion.Alias        // route tanpa versi di /api
+    V1    *apiversion.Deprecation // nil -> /api/v1 masih aktif
+}
+
+// defaultAliasDeprecatedSince -> tanggal /api/v1 dirilis, default sejak kapan /api tanpa versi deprecated
+var defaultAliasDeprecatedSince = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// LoadAPIConfig membaca pengaturan deprecation (tanggal dalam format YYYY-MM-DD):
+//   - API_ALIAS_DEPRECATED_SINCE, API_ALIAS_SUNSET -> alias /api, yang selalu melayani /api/v1 dan
+//     ditandai deprecated (default sejak tanggal rilis /api/v1)
+//   - API_V1_DEPRECATED_SINCE, API_V1_SUNSET -> /api/v1, hanya ditandai deprecated jika
+//     API_V1_DEPRECATED_SINCE diisi (mis. setelah /api/v2 dirilis)
+//   - API_DEPRECATION_LINK -> dokumen migrasi untuk keduanya
+func LoadAPIConfig() APIConfig {
+    link := os.Getenv("API_DEPRECATION_LINK")
+
+    alias := &apiversion.Deprecation{
+        Since:  envDate("API_ALIAS_DEPRECATED_SINCE", defaultAliasDeprecatedSince),
+        Sunset: envDate("API_ALIAS_SUNSET", time.Time{}),
+        Link:   link,
+    }
+
+    var v1 *apiversion.Deprecation
+    if since := envDate("API_V1_DEPRECATED_SINCE", time.Time{}); !since.IsZero() {
+        v1 = &apiversion.Deprecation{
+            Since:  since,
+            Sunset: envDate("API_V1_SUNSET", time.Time{}),
+            Link:   link,
+        }
+    }
+
+    return APIConfig{
+        Alias: apiversion.Alias{Version: apiversion.V1, Deprecation: alias},
+        V1:    v1,
+    }
+}
+
+// envDate membaca tanggal YYYY-MM-DD dari env key, kosong atau tidak valid -> def
+func envDate(key string, def time.Time) time.Time {
+    v := os.Getenv(key)
+    if v == "" {
+        return def
+    }
+    t, err := time.Parse("2006-01-02", v)
+    if err != nil {
+        log.Printf("⚠️ %s tidak valid (%q), dipakai nilai default", key, v)
+        return def
+    }
+    return t
+}
@@ -1,12 +1,25 @@
 package config
 
-import "github.com/gofiber/fiber/v2"
+import (
+    "context"
 
-func SetupApp() *fiber.App {
+    "alumniproject/utils/apperror"
+    "alumniproject/utils/deadline"
+    "alumniproject/utils/logger"
+    "alumniproject/utils/metrics"
+    "alumniproject/utils/tracing"
+    "github.com/gofiber/fiber/v2"
+)
+
+// SetupApp membuat app Fiber. Semua error dari handler & middleware ditulis sebagai
+// problem+json (RFC 7807) oleh apperror.Handler sesuai status & kode error-nya.
+// Setiap request diberi request ID, dibuatkan span trace, dicatat di access log JSON, dan dihitung
+// di metrik Prometheus.
+// c.UserContext() setiap request dibatasi cfg.RequestTimeout dan ikut dibatalkan saat base dibatalkan.
+func SetupApp(base context.Context, cfg ServerConfig) *fiber.App {
     app := fiber.New(fiber.Config{
-        ErrorHandler: func(c *fiber.Ctx, err error) error {
-            return c.Status(500).JSON(fiber.Map{"error": err.Error()})
-        },
+        ErrorHandler: apperror.Handler,
     })
+    app.Use(logger.RequestID(), tracing.Middleware(), metrics.Middleware(), logger.AccessLog(), deadline.New(base, cfg.RequestTimeout))
     return app
-}
\ No newline at end of file
+}
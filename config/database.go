@@ -0,0 +1,92 @@
+package config
+
+import (
+    "log"
+    "os"
+    "strconv"
+    "time"
+)
+
+// DatabaseConfig -> pengaturan connection pool & batas waktu query, berlaku untuk PostgreSQL dan MongoDB
+type DatabaseConfig struct {
+    MaxOpenConns    int           // koneksi maksimum di pool (MongoDB: maxPoolSize), 0 = tanpa batas
+    MaxIdleConns    int           // koneksi idle yang disimpan (PostgreSQL saja)
+    ConnMaxLifetime time.Duration // umur maksimum koneksi sebelum diganti (PostgreSQL saja), 0 = selamanya
+    ConnMaxIdleTime time.Duration // koneksi idle lebih lama dari ini ditutup, 0 = tidak pernah
+    QueryTimeout    time.Duration // batas waktu satu query repository
+    TxTimeout       time.Duration // batas waktu transaksi / operasi banyak query (bulk, cascade, batch trash, laporan)
+}
+
+// LoadDatabaseConfig membaca DB_MAX_OPEN_CONNS, DB_MAX_IDLE_CONNS, DB_CONN_MAX_LIFETIME,
+// DB_CONN_MAX_IDLE_TIME, DB_QUERY_TIMEOUT, dan DB_TX_TIMEOUT
+func LoadDatabaseConfig() DatabaseConfig {
+    cfg := DatabaseConfig{
+        MaxOpenConns:    25,
+        MaxIdleConns:    10,
+        ConnMaxLifetime: 30 * time.Minute,
+        ConnMaxIdleTime: 5 * time.Minute,
+        QueryTimeout:    5 * time.Second,
+        TxTimeout:       30 * time.Second,
+    }
+
+    if v := os.Getenv("DB_MAX_OPEN_CONNS"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 0 {
+            log.Printf("⚠️ DB_MAX_OPEN_CONNS tidak valid (%q), pakai default %d", v, cfg.MaxOpenConns)
+        } else {
+            cfg.MaxOpenConns = n
+        }
+    }
+
+    if v := os.Getenv("DB_MAX_IDLE_CONNS"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 0 {
+            log.Printf("⚠️ DB_MAX_IDLE_CONNS tidak valid (%q), pakai default %d", v, cfg.MaxIdleConns)
+        } else {
+            cfg.MaxIdleConns = n
+        }
+    }
+
+    if v := os.Getenv("DB_CONN_MAX_LIFETIME"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d < 0 {
+            log.Printf("⚠️ DB_CONN_MAX_LIFETIME tidak valid (%q), pakai default %s", v, cfg.ConnMaxLifetime)
+        } else {
+            cfg.ConnMaxLifetime = d
+        }
+    }
+
+    if v := os.Getenv("DB_CONN_MAX_IDLE_TIME"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d < 0 {
+            log.Printf("⚠️ DB_CONN_MAX_IDLE_TIME tidak valid (%q), pakai default %s", v, cfg.ConnMaxIdleTime)
+        } else {
+            cfg.ConnMaxIdleTime = d
+        }
+    }
+
+    if v := os.Getenv("DB_QUERY_TIMEOUT"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ DB_QUERY_TIMEOUT tidak valid (%q), pakai default %s", v, cfg.QueryTimeout)
+        } else {
+            cfg.QueryTimeout = d
+        }
+    }
+
+    if v := os.Getenv("DB_TX_TIMEOUT"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ DB_TX_TIMEOUT tidak valid (%q), pakai default %s", v, cfg.TxTimeout)
+        } else {
+            cfg.TxTimeout = d
+        }
+    }
+
+    // Pool idle tidak boleh lebih besar dari batas koneksi
+    if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
+        cfg.MaxIdleConns = cfg.MaxOpenConns
+    }
+
+    return cfg
+}
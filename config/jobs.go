@@ -0,0 +1,59 @@
+package config
+
+import (
+    "log"
+    "os"
+    "strconv"
+    "time"
+)
+
+// JobsConfig -> pengaturan antrian job latar belakang (import, export, purge, laporan)
+type JobsConfig struct {
+    Workers      int           // jumlah worker paralel, <= 0 berarti worker tidak dijalankan
+    PollInterval time.Duration // jeda cek job baru saat antrian kosong
+    MaxAttempts  int           // batas percobaan sebelum job dinyatakan gagal
+    ResultDir    string        // folder file hasil job
+}
+
+// LoadJobsConfig membaca JOB_WORKERS, JOB_POLL_INTERVAL, JOB_MAX_ATTEMPTS, dan JOB_RESULT_DIR
+func LoadJobsConfig() JobsConfig {
+    cfg := JobsConfig{
+        Workers:      2,
+        PollInterval: 2 * time.Second,
+        MaxAttempts:  3,
+        ResultDir:    "./uploads/jobs",
+    }
+
+    if v := os.Getenv("JOB_WORKERS"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil {
+            log.Printf("⚠️ JOB_WORKERS tidak valid (%q), pakai default %d", v, cfg.Workers)
+        } else {
+            cfg.Workers = n
+        }
+    }
+
+    if v := os.Getenv("JOB_POLL_INTERVAL"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ JOB_POLL_INTERVAL tidak valid (%q), pakai default %s", v, cfg.PollInterval)
+        } else {
+            cfg.PollInterval = d
+        }
+    }
+
+    if v := os.Getenv("JOB_MAX_ATTEMPTS"); v != "" {
+        n, err := strconv.Atoi(v)
+        if err != nil || n < 1 {
+            log.Printf("⚠️ JOB_MAX_ATTEMPTS tidak valid (%q), pakai default %d", v, cfg.MaxAttempts)
+        } else {
+            cfg.MaxAttempts = n
+        }
+    }
+
+    if v := os.Getenv("JOB_RESULT_DIR"); v != "" {
+        cfg.ResultDir = v
+    }
+
+    return cfg
+}
@@ -1,8 +1,18 @@
 package config
 
-import "log"
-        
+import (
+    "log/slog"
+    "os"
 
+    "alumniproject/utils/logger"
+)
+
+// SetupLogger memasang logger JSON slog sebagai logger default (termasuk package log standar).
+// Level diatur lewat LOG_LEVEL: debug, info (default), warn, atau error.
 func SetupLogger() {
-    log.SetFlags(log.LstdFlags | log.Lshortfile)
-}
\ No newline at end of file
+    level, err := logger.ParseLevel(os.Getenv("LOG_LEVEL"))
+    slog.SetDefault(slog.New(logger.New(os.Stdout, level)))
+    if err != nil {
+        slog.Warn("LOG_LEVEL tidak valid, pakai default info", "value", os.Getenv("LOG_LEVEL"))
+    }
+}
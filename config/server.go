@@ -0,0 +1,43 @@
+package config
+
+import (
+    "log"
+    "os"
+    "time"
+)
+
+// ServerConfig -> pengaturan server HTTP
+type ServerConfig struct {
+    Addr            string        // alamat listen
+    ShutdownTimeout time.Duration // batas waktu menunggu request, job, dan scheduler selesai saat berhenti
+    RequestTimeout  time.Duration // batas waktu satu request, diteruskan ke query lewat c.UserContext()
+}
+
+// LoadServerConfig membaca SHUTDOWN_TIMEOUT dan REQUEST_TIMEOUT
+func LoadServerConfig() ServerConfig {
+    cfg := ServerConfig{
+        Addr:            ":3000",
+        ShutdownTimeout: 15 * time.Second,
+        RequestTimeout:  30 * time.Second,
+    }
+
+    if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ SHUTDOWN_TIMEOUT tidak valid (%q), pakai default %s", v, cfg.ShutdownTimeout)
+        } else {
+            cfg.ShutdownTimeout = d
+        }
+    }
+
+    if v := os.Getenv("REQUEST_TIMEOUT"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ REQUEST_TIMEOUT tidak valid (%q), pakai default %s", v, cfg.RequestTimeout)
+        } else {
+            cfg.RequestTimeout = d
+        }
+    }
+
+    return cfg
+}
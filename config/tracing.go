@@ -0,0 +1,62 @@
+package config
+
+import (
+    "context"
+    "log"
+    "log/slog"
+    "os"
+    "strconv"
+    "strings"
+
+    "alumniproject/utils/tracing"
+)
+
+// TracingConfig -> pengaturan tracing OpenTelemetry
+type TracingConfig struct {
+    Exporter    string  // none (default), otlp, atau stdout
+    ServiceName string  // nama service di backend tracing
+    SampleRatio float64 // porsi trace yang direkam, 0..1
+}
+
+// LoadTracingConfig membaca TRACING_EXPORTER, OTEL_SERVICE_NAME, dan TRACING_SAMPLE_RATIO.
+// Tujuan OTLP diatur variabel standar OpenTelemetry, mis. OTEL_EXPORTER_OTLP_ENDPOINT.
+func LoadTracingConfig() TracingConfig {
+    cfg := TracingConfig{
+        Exporter:    tracing.ExporterNone,
+        ServiceName: "alumniproject",
+        SampleRatio: 1,
+    }
+
+    if v := strings.ToLower(strings.TrimSpace(os.Getenv("TRACING_EXPORTER"))); v != "" {
+        cfg.Exporter = v
+    }
+
+    if v := os.Getenv("OTEL_SERVICE_NAME"); v != "" {
+        cfg.ServiceName = v
+    }
+
+    if v := os.Getenv("TRACING_SAMPLE_RATIO"); v != "" {
+        ratio, err := strconv.ParseFloat(v, 64)
+        if err != nil || ratio < 0 || ratio > 1 {
+            log.Printf("⚠️ TRACING_SAMPLE_RATIO tidak valid (%q), pakai default %g", v, cfg.SampleRatio)
+        } else {
+            cfg.SampleRatio = ratio
+        }
+    }
+
+    return cfg
+}
+
+// SetupTracing memasang tracer provider global sesuai cfg. Jika exporter gagal dibuat, tracing
+// dimatikan tanpa menghentikan server. Fungsi yang dikembalikan dipanggil saat shutdown.
+func SetupTracing(ctx context.Context, cfg TracingConfig) func(context.Context) error {
+    shutdown, err := tracing.Setup(ctx, cfg.Exporter, cfg.ServiceName, cfg.SampleRatio)
+    if err != nil {
+        slog.Warn("tracing tidak aktif", "exporter", cfg.Exporter, "error", err)
+        return func(context.Context) error { return nil }
+    }
+    if cfg.Exporter != tracing.ExporterNone {
+        slog.Info("tracing aktif", "exporter", cfg.Exporter, "service", cfg.ServiceName, "sample_ratio", cfg.SampleRatio)
+    }
+    return shutdown
+}
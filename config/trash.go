@@ -0,0 +1,57 @@
+package config
+
+import (
+    "log"
+    "os"
+    "strconv"
+    "time"
+)
+
+// TrashConfig -> pengaturan retensi data yang sudah di-soft delete
+type TrashConfig struct {
+    RetentionDays int           // umur maksimum data di trash, <= 0 berarti purge otomatis mati
+    Interval      time.Duration // jeda antar purge otomatis
+    DryRun        bool          // purge otomatis hanya menghitung, tidak menghapus
+}
+
+// LoadTrashConfig membaca TRASH_RETENTION_DAYS, TRASH_PURGE_INTERVAL, dan TRASH_PURGE_DRY_RUN.
+// Purge otomatis menghapus data permanen, jadi default-nya mati sampai TRASH_RETENTION_DAYS di-set.
+func LoadTrashConfig() TrashConfig {
+    cfg := TrashConfig{
+        Interval: 24 * time.Hour,
+    }
+
+    if v := os.Getenv("TRASH_RETENTION_DAYS"); v != "" {
+        days, err := strconv.Atoi(v)
+        if err != nil {
+            log.Printf("⚠️ TRASH_RETENTION_DAYS tidak valid (%q), pakai default %d hari", v, cfg.RetentionDays)
+        } else {
+            cfg.RetentionDays = days
+        }
+    }
+
+    if v := os.Getenv("TRASH_PURGE_INTERVAL"); v != "" {
+        d, err := time.ParseDuration(v)
+        if err != nil || d <= 0 {
+            log.Printf("⚠️ TRASH_PURGE_INTERVAL tidak valid (%q), pakai default %s", v, cfg.Interval)
+        } else {
+            cfg.Interval = d
+        }
+    }
+
+    if v := os.Getenv("TRASH_PURGE_DRY_RUN"); v != "" {
+        dry, err := strconv.ParseBool(v)
+        if err != nil {
+            log.Printf("⚠️ TRASH_PURGE_DRY_RUN tidak valid (%q), diabaikan", v)
+        } else {
+            cfg.DryRun = dry
+        }
+    }
+
+    return cfg
+}
+
+// Cutoff -> data yang deleted_at-nya sebelum waktu ini boleh dihapus permanen
+func (t TrashConfig) Cutoff(now time.Time) time.Time {
+    return now.AddDate(0, 0, -t.RetentionDays)
+}
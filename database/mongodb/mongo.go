@@ -2,31 +2,49 @@ package database
 
 import (
     "context"
+    "fmt"
     "log"
+    "log/slog"
     "os"
     "time"
 
 	"go.mongodb.org/mongo-driver/bson"
     "go.mongodb.org/mongo-driver/mongo"
     "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/config"
 )
 
 var (
     DB                  *mongo.Database
     MongoClient         *mongo.Client
     PekerjaanCollection *mongo.Collection
+    AlumniCollection    *mongo.Collection
 	UsersCollection     *mongo.Collection // ✅ Tambahan untuk koleksi user
 	CountersCollection  *mongo.Collection // ✅ Tambahan untuk koleksi counters (auto-increment ID)
+    AuditCollection     *mongo.Collection // ✅ Audit log semua perubahan data
+    AlumniHistoryCollection *mongo.Collection // ✅ Snapshot alumni sebelum setiap update
+    JobsCollection      *mongo.Collection // ✅ Antrian job latar belakang
     
 )
 
-func ConnectMongo() {
+// queryTimeout -> batas waktu satu query repository, diatur lewat DB_QUERY_TIMEOUT
+var queryTimeout = 5 * time.Second
+
+// txTimeout -> batas waktu transaksi / operasi banyak query, diatur lewat DB_TX_TIMEOUT
+var txTimeout = 30 * time.Second
+
+func ConnectMongo(cfg config.DatabaseConfig) {
     mongoURI := os.Getenv("MONGO_URI")
     if mongoURI == "" {
         mongoURI = "mongodb://localhost:27017"
     }
 
-    clientOptions := options.Client().ApplyURI(mongoURI)
+    clientOptions := options.Client().ApplyURI(mongoURI).SetMonitor(commandMonitor()).
+        SetMaxPoolSize(uint64(cfg.MaxOpenConns)).
+        SetMaxConnIdleTime(cfg.ConnMaxIdleTime)
+    queryTimeout = cfg.QueryTimeout
+    txTimeout = cfg.TxTimeout
     client, err := mongo.Connect(context.Background(), clientOptions)
     if err != nil {
         log.Fatal("Mongo connection failed:", err)
@@ -43,21 +61,150 @@ func ConnectMongo() {
     MongoClient = client
     DB = client.Database("alumni") // Sesuaikan nama database
     PekerjaanCollection = DB.Collection("pekerjaan")
+    AlumniCollection = DB.Collection("alumni")
     UsersCollection = DB.Collection("users")
     CountersCollection = DB.Collection("counters")
+    AuditCollection = DB.Collection("audit_log")
+    AlumniHistoryCollection = DB.Collection("alumni_history")
+    JobsCollection = DB.Collection("jobs")
+
+    // Setiap langkah inisialisasi punya batas waktunya sendiri, supaya backfill data lama yang lambat
+    // tidak menghabiskan waktu pembuatan index sesudahnya
+    initStep(initTimeout, func(ctx context.Context) {
+        // ✅ Fix: Set counter ke 10 explicit (tanpa inc, hindari conflict)
+        filter := bson.M{"_id": "pekerjaan_id"}
+        update := bson.M{"$set": bson.M{"seq": 10}}
+        opts := options.Update().SetUpsert(true)
+        _, err := CountersCollection.UpdateOne(ctx, filter, update, opts)
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal set initial counter: %v", err)
+        } else {
+            log.Printf("✅ Counter pekerjaan di-set ke 10 (next ID: 11)")
+        }
+    })
+
+    // ✅ Backfill field version (optimistic locking) untuk dokumen lama
+    initStep(backfillTimeout, func(ctx context.Context) {
+        for _, name := range []string{"pekerjaan", "alumni", "files", "fotos"} {
+            _, err := DB.Collection(name).UpdateMany(ctx,
+                bson.M{"version": bson.M{"$exists": false}},
+                bson.M{"$set": bson.M{"version": 1}},
+            )
+            if err != nil {
+                log.Printf("⚠️ Warning: Gagal set version awal untuk %s: %v", name, err)
+            }
+        }
+    })
+
+    // ✅ Kunci angka alumni (alumni_id) untuk relasi pekerjaan.alumni_id
+    initStep(backfillTimeout, backfillAlumniID)
+
+    // ✅ Pemilik (created_by) alumni lama, dipakai filter PATCH/DELETE/restore non-admin
+    initStep(backfillTimeout, backfillAlumniCreatedBy)
+
+    initStep(initTimeout, func(ctx context.Context) {
+        // ✅ Index audit log untuk filter per entity dan urutan waktu
+        _, err := AuditCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+            {Keys: bson.D{{Key: "entity", Value: 1}, {Key: "entity_id", Value: 1}}},
+            {Keys: bson.D{{Key: "created_at", Value: -1}}},
+        })
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal membuat index audit_log: %v", err)
+        }
+
+        // ✅ Index riwayat alumni: satu snapshot per versi, dicari per rentang waktu
+        _, err = AlumniHistoryCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+            {Keys: bson.D{{Key: "alumni_id", Value: 1}, {Key: "version", Value: 1}}, Options: options.Index().SetUnique(true)},
+            {Keys: bson.D{{Key: "alumni_id", Value: 1}, {Key: "valid_from", Value: 1}, {Key: "valid_to", Value: 1}}},
+        })
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal membuat index alumni_history: %v", err)
+        }
+
+        // ✅ Index antrian job: worker mencari job queued yang sudah waktunya
+        _, err = JobsCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+            Keys: bson.D{{Key: "status", Value: 1}, {Key: "run_after", Value: 1}, {Key: "created_at", Value: 1}},
+        })
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal membuat index jobs: %v", err)
+        }
+    })
+
+    // ✅ Text index untuk pencarian gabungan; gagal -> /search dinonaktifkan (503), bukan error saat dipakai
+    initStep(backfillTimeout, func(ctx context.Context) {
+        if err := createSearchIndexes(ctx); err != nil {
+            slog.Warn("text index pencarian gagal dibuat, endpoint /search dinonaktifkan (perbaiki lalu restart)", "error", err)
+            return
+        }
+        searchEnabled = true
+    })
+
+    log.Println("✅ MongoDB Connected - All Collections Ready!")
+}
 
-    // ✅ Fix: Set counter ke 10 explicit (tanpa inc, hindari conflict)
-	ctxInit, cancelInit := context.WithTimeout(context.Background(), 5*time.Second)
-    defer cancelInit()
-    filter := bson.M{"_id": "pekerjaan_id"}
-    update := bson.M{"$set": bson.M{"seq": 10}}
-    opts := options.Update().SetUpsert(true)
-    _, err = CountersCollection.UpdateOne(ctxInit, filter, update, opts)
+// Batas waktu langkah inisialisasi saat start: initTimeout untuk langkah ringan (counter, index kecil),
+// backfillTimeout untuk langkah yang sebanding dengan jumlah data (backfill, text index)
+const (
+    initTimeout     = 30 * time.Second
+    backfillTimeout = 5 * time.Minute
+)
+
+// initStep menjalankan satu langkah inisialisasi dengan context dan batas waktunya sendiri
+func initStep(timeout time.Duration, fn func(ctx context.Context)) {
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+    fn(ctx)
+}
+
+// searchEnabled -> text index alumni_search & pekerjaan_search siap dipakai
+var searchEnabled bool
+
+// SearchEnabled -> false jika text index pencarian gagal dibuat, endpoint /search membalas 503
+func SearchEnabled() bool {
+    return searchEnabled
+}
+
+// createSearchIndexes membuat text index pencarian gabungan (satu text index per koleksi)
+func createSearchIndexes(ctx context.Context) error {
+    _, err := AlumniCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+        Keys: bson.D{{Key: "nama", Value: "text"}, {Key: "nim", Value: "text"}, {Key: "jurusan", Value: "text"}},
+        Options: options.Index().SetName("alumni_search").SetDefaultLanguage("none").
+            SetWeights(bson.D{{Key: "nama", Value: 10}, {Key: "nim", Value: 10}, {Key: "jurusan", Value: 3}}),
+    })
     if err != nil {
-        log.Printf("⚠️ Warning: Gagal set initial counter: %v", err)
-    } else {
-        log.Printf("✅ Counter pekerjaan di-set ke 10 (next ID: 11)")
+        return fmt.Errorf("text index alumni: %w", err)
     }
+    _, err = PekerjaanCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+        Keys: bson.D{
+            {Key: "nama_perusahaan", Value: "text"}, {Key: "posisi_jabatan", Value: "text"},
+            {Key: "bidang_industri", Value: "text"}, {Key: "deskripsi_pekerjaan", Value: "text"},
+        },
+        Options: options.Index().SetName("pekerjaan_search").SetDefaultLanguage("none").
+            SetWeights(bson.D{
+                {Key: "nama_perusahaan", Value: 10}, {Key: "posisi_jabatan", Value: 10},
+                {Key: "bidang_industri", Value: 3}, {Key: "deskripsi_pekerjaan", Value: 1},
+            }),
+    })
+    if err != nil {
+        return fmt.Errorf("text index pekerjaan: %w", err)
+    }
+    return nil
+}
 
-    log.Println("✅ MongoDB Connected - All Collections Ready!")
+// WithQueryTimeout -> context turunan ctx dengan batas waktu satu query (DB_QUERY_TIMEOUT)
+func WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+    return context.WithTimeout(ctx, queryTimeout)
+}
+
+// WithTxTimeout -> context turunan ctx dengan batas waktu satu transaksi atau operasi banyak query (DB_TX_TIMEOUT)
+func WithTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+    return context.WithTimeout(ctx, txTimeout)
+}
+
+// Disconnect menutup koneksi MongoDB, dipanggil saat server berhenti
+func Disconnect(ctx context.Context) error {
+    if MongoClient == nil {
+        return nil
+    }
+    return MongoClient.Disconnect(ctx)
 }
\ No newline at end of file
@@ -0,0 +1,61 @@
+package database
+
+import (
+    "context"
+    "errors"
+    "sync"
+
+    "go.mongodb.org/mongo-driver/event"
+    "go.mongodb.org/mongo-driver/mongo/readpref"
+    "go.opentelemetry.io/otel/attribute"
+    semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+    "go.opentelemetry.io/otel/trace"
+
+    "alumniproject/utils/metrics"
+    "alumniproject/utils/tracing"
+)
+
+// commandMonitor mencatat durasi setiap perintah MongoDB (find, insert, aggregate, ...) ke metrik dan
+// membuat span per perintah, anak dari span request di context operasi
+func commandMonitor() *event.CommandMonitor {
+    // span yang sedang berjalan per RequestID perintah, ditutup saat Succeeded/Failed
+    var spans sync.Map
+    end := func(requestID int64, err error) {
+        if span, ok := spans.LoadAndDelete(requestID); ok {
+            tracing.EndSpan(span.(trace.Span), err)
+        }
+    }
+
+    return &event.CommandMonitor{
+        Started: func(ctx context.Context, e *event.CommandStartedEvent) {
+            attrs := []attribute.KeyValue{
+                semconv.DBSystemNameMongoDB,
+                semconv.DBNamespace(e.DatabaseName),
+                semconv.DBOperationName(e.CommandName),
+            }
+            // Elemen pertama perintah berisi nama collection, mis. {find: "alumni", ...}
+            if first, err := e.Command.IndexErr(0); err == nil {
+                if coll, ok := first.Value().StringValueOK(); ok {
+                    attrs = append(attrs, semconv.DBCollectionName(coll))
+                }
+            }
+            _, span := tracing.Tracer().Start(ctx, e.CommandName, trace.WithSpanKind(trace.SpanKindClient),
+                trace.WithAttributes(attrs...))
+            spans.Store(e.RequestID, span)
+        },
+        Succeeded: func(_ context.Context, e *event.CommandSucceededEvent) {
+            metrics.ObserveQuery("mongodb", e.CommandName, e.Duration, nil)
+            end(e.RequestID, nil)
+        },
+        Failed: func(_ context.Context, e *event.CommandFailedEvent) {
+            err := errors.New(e.Failure)
+            metrics.ObserveQuery("mongodb", e.CommandName, e.Duration, err)
+            end(e.RequestID, err)
+        },
+    }
+}
+
+// Ping -> cek koneksi MongoDB ke primary, dipakai endpoint readiness
+func Ping(ctx context.Context) error {
+    return MongoClient.Ping(ctx, readpref.Primary())
+}
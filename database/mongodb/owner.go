@@ -0,0 +1,78 @@
+package database
+
+import (
+    "context"
+    "log"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/bson/primitive"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+
+    "alumniproject/utils/audit"
+)
+
+// backfillAlumniCreatedBy mengisi created_by pada dokumen alumni lama yang belum punya field itu.
+// Pemilik diambil dari entri audit "create" alumni tersebut lewat satu aggregation ($lookup ke audit_log),
+// lalu disimpan dengan satu bulk write. Tanpa jejak audit (mis. dibuat sebelum audit log ada) created_by
+// di-set 0, artinya tidak dimiliki user mana pun dan hanya admin yang bisa mengubah, menghapus, atau
+// me-restore-nya; jumlahnya dicatat di log supaya admin bisa menetapkan pemiliknya secara manual.
+func backfillAlumniCreatedBy(ctx context.Context) {
+    cursor, err := AlumniCollection.Aggregate(ctx, mongo.Pipeline{
+        {{Key: "$match", Value: bson.M{"created_by": bson.M{"$exists": false}}}},
+        {{Key: "$lookup", Value: bson.M{
+            "from": AuditCollection.Name(),
+            "let":  bson.M{"id": bson.M{"$toString": "$_id"}},
+            "pipeline": bson.A{
+                bson.M{"$match": bson.M{
+                    "entity":   audit.EntityAlumni,
+                    "action":   audit.ActionCreate,
+                    "actor_id": bson.M{"$ne": nil},
+                    "$expr":    bson.M{"$eq": bson.A{"$entity_id", "$$id"}},
+                }},
+                bson.M{"$sort": bson.M{"created_at": 1}},
+                bson.M{"$limit": 1},
+            },
+            "as": "created",
+        }}},
+        {{Key: "$project", Value: bson.M{
+            "owner": bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$created.actor_id", 0}}, 0}},
+        }}},
+    })
+    if err != nil {
+        log.Printf("⚠️ Warning: Gagal membaca alumni tanpa created_by: %v", err)
+        return
+    }
+    var legacy []struct {
+        ID    primitive.ObjectID `bson:"_id"`
+        Owner int                `bson:"owner"`
+    }
+    if err := cursor.All(ctx, &legacy); err != nil {
+        log.Printf("⚠️ Warning: Gagal membaca alumni tanpa created_by: %v", err)
+        return
+    }
+    if len(legacy) == 0 {
+        return
+    }
+
+    writes := make([]mongo.WriteModel, len(legacy))
+    orphaned := 0
+    for i, a := range legacy {
+        if a.Owner == 0 {
+            orphaned++
+        }
+        writes[i] = mongo.NewUpdateOneModel().
+            SetFilter(bson.M{"_id": a.ID, "created_by": bson.M{"$exists": false}}).
+            SetUpdate(bson.M{"$set": bson.M{"created_by": a.Owner}})
+    }
+    res, err := AlumniCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+    if err != nil {
+        log.Printf("⚠️ Warning: Gagal set created_by: %v", err)
+        return
+    }
+
+    log.Printf("✅ created_by diisi untuk %d alumni lama (%d dari audit log)", res.ModifiedCount, len(legacy)-orphaned)
+    if orphaned > 0 {
+        log.Printf("⚠️ %d alumni lama tanpa jejak audit, created_by = 0 (hanya admin yang bisa mengubahnya)", orphaned)
+    }
+}
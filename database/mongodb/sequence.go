@@ -0,0 +1,81 @@
+package database
+
+import (
+    "context"
+    "log"
+
+    "go.mongodb.org/mongo-driver/bson"
+    "go.mongodb.org/mongo-driver/mongo"
+    "go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AlumniSequence -> nama counter untuk alumni_id (kunci angka alumni yang dirujuk pekerjaan.alumni_id)
+const AlumniSequence = "alumni_id"
+
+// NextSequence menaikkan counter name secara atomik lalu mengembalikan nilai barunya (mulai dari 1)
+func NextSequence(ctx context.Context, name string) (int, error) {
+    var counter struct {
+        Seq int `bson:"seq"`
+    }
+    err := CountersCollection.FindOneAndUpdate(ctx,
+        bson.M{"_id": name},
+        bson.M{"$inc": bson.M{"seq": 1}},
+        options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+    ).Decode(&counter)
+    return counter.Seq, err
+}
+
+// backfillAlumniID memberi alumni_id pada dokumen alumni lama (urut _id = urut pembuatan),
+// lalu memasang unique index supaya pekerjaan bisa dihubungkan ke alumni lewat alumni_id.
+//
+// Sebelumnya alumni tidak punya kunci angka, jadi alumni_id pada pekerjaan lama tidak merujuk
+// alumni mana pun. Nomor baru ini tidak dipakai untuk menebak pemilik pekerjaan lama: pekerjaan
+// tersebut tidak punya alumni_linked sehingga tidak ikut terhapus/di-purge bersama alumni.
+func backfillAlumniID(ctx context.Context) {
+    cursor, err := AlumniCollection.Find(ctx,
+        bson.M{"alumni_id": bson.M{"$exists": false}},
+        options.Find().SetSort(bson.M{"_id": 1}).SetProjection(bson.M{"_id": 1}),
+    )
+    if err != nil {
+        log.Printf("⚠️ Warning: Gagal membaca alumni tanpa alumni_id: %v", err)
+        return
+    }
+    var legacy []struct {
+        ID interface{} `bson:"_id"`
+    }
+    if err := cursor.All(ctx, &legacy); err != nil {
+        log.Printf("⚠️ Warning: Gagal membaca alumni tanpa alumni_id: %v", err)
+        return
+    }
+
+    for _, a := range legacy {
+        seq, err := NextSequence(ctx, AlumniSequence)
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal mengambil alumni_id baru: %v", err)
+            return
+        }
+        _, err = AlumniCollection.UpdateOne(ctx,
+            bson.M{"_id": a.ID, "alumni_id": bson.M{"$exists": false}},
+            bson.M{"$set": bson.M{"alumni_id": seq}},
+        )
+        if err != nil {
+            log.Printf("⚠️ Warning: Gagal set alumni_id: %v", err)
+            return
+        }
+    }
+    if len(legacy) > 0 {
+        log.Printf("✅ alumni_id diisi untuk %d alumni lama", len(legacy))
+        unlinked, err := PekerjaanCollection.CountDocuments(ctx, bson.M{"alumni_linked": bson.M{"$ne": true}})
+        if err == nil && unlinked > 0 {
+            log.Printf("⚠️ %d pekerjaan lama tidak terhubung ke alumni dan tidak ikut terhapus bersama alumni", unlinked)
+        }
+    }
+
+    _, err = AlumniCollection.Indexes().CreateOne(ctx, mongo.IndexModel{
+        Keys:    bson.D{{Key: "alumni_id", Value: 1}},
+        Options: options.Index().SetUnique(true).SetName("alumni_id_unique"),
+    })
+    if err != nil {
+        log.Printf("⚠️ Warning: Gagal membuat index alumni_id: %v", err)
+    }
+}
@@ -1,26 +1,66 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"log"
+	"log/slog"
+	"time"
 
-	_ "github.com/lib/pq"
+	"alumniproject/config"
 )
 
 var DB *sql.DB
 
-func ConnectPostgres() {
+// queryTimeout -> batas waktu satu query repository, diatur lewat DB_QUERY_TIMEOUT
+var queryTimeout = 5 * time.Second
+
+// txTimeout -> batas waktu transaksi / operasi banyak query, diatur lewat DB_TX_TIMEOUT
+var txTimeout = 30 * time.Second
+
+func ConnectPostgres(cfg config.DatabaseConfig) {
 	var err error
 	dsn := "host=localhost user=postgres password=2255 dbname=alumnidb port=5432 sslmode=disable"
-	DB, err = sql.Open("postgres", dsn)
+	DB, err = sql.Open(driverName, dsn)
 	if err != nil {
 		log.Fatal("Gagal koneksi ke database:", err)
 	}
+	DB.SetMaxOpenConns(cfg.MaxOpenConns)
+	DB.SetMaxIdleConns(cfg.MaxIdleConns)
+	DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	DB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+	queryTimeout = cfg.QueryTimeout
+	txTimeout = cfg.TxTimeout
+
 	if err = DB.Ping(); err != nil {
 		log.Fatal("Gagal ping database:", err)
 	}
-	fmt.Println("Berhasil terhubung ke database PostgreSQL")
+	slog.Info("berhasil terhubung ke database PostgreSQL")
+
+	Migrate()
+}
+
+// WithQueryTimeout -> context turunan ctx dengan batas waktu satu query (DB_QUERY_TIMEOUT)
+func WithQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, queryTimeout)
+}
+
+// WithTxTimeout -> context turunan ctx dengan batas waktu satu transaksi atau operasi banyak query (DB_TX_TIMEOUT)
+func WithTxTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, txTimeout)
+}
+
+// Ping -> cek koneksi PostgreSQL, dipakai endpoint readiness
+func Ping(ctx context.Context) error {
+	return DB.PingContext(ctx)
+}
+
+// Close menutup pool koneksi PostgreSQL, dipanggil saat server berhenti
+func Close() error {
+	if DB == nil {
+		return nil
+	}
+	return DB.Close()
 }
 
 // package database
@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"time"
+
+	"alumniproject/utils/metrics"
+	"alumniproject/utils/tracing"
+
+	"github.com/lib/pq"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// driverName -> driver lib/pq yang dibungkus agar setiap query tercatat di metrik dan trace
+const driverName = "postgres-instrumented"
+
+func init() {
+	sql.Register(driverName, instrumentedDriver{&pq.Driver{}})
+}
+
+// instrumentedDriver membungkus koneksi driver asli. Query & Exec (termasuk di dalam transaksi)
+// diukur durasinya dan dibuatkan span; fitur lain diteruskan apa adanya.
+type instrumentedDriver struct {
+	driver.Driver
+}
+
+func (d instrumentedDriver) Open(name string) (driver.Conn, error) {
+	cn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{cn}, nil
+}
+
+type instrumentedConn struct {
+	driver.Conn
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	q, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	done := observe(ctx, query)
+	rows, err := q.QueryContext(ctx, query, args)
+	done(err)
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	e, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	done := observe(ctx, query)
+	res, err := e.ExecContext(ctx, query, args)
+	done(err)
+	return res, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	if r, ok := c.Conn.(driver.SessionResetter); ok {
+		return r.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	if v, ok := c.Conn.(driver.Validator); ok {
+		return v.IsValid()
+	}
+	return true
+}
+
+// observe memulai span query (anak dari span request di ctx) dan mengembalikan fungsi penutupnya, yang
+// juga mencatat durasi query dengan label jenis perintah SQL (SELECT, INSERT, ...)
+func observe(ctx context.Context, query string) func(error) {
+	op := operation(query)
+	_, span := tracing.Tracer().Start(ctx, op, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemNamePostgreSQL,
+			semconv.DBOperationName(op),
+			semconv.DBQueryText(query),
+		))
+	start := time.Now()
+	return func(err error) {
+		metrics.ObserveQuery("postgresql", op, time.Since(start), err)
+		tracing.EndSpan(span, err)
+	}
+}
+
+// operation -> kata pertama query dalam huruf besar, "OTHER" untuk query kosong
+func operation(query string) string {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return "OTHER"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,138 @@
+package postgresql
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+// migrations -> perubahan skema yang dijalankan setiap start, harus idempotent
+var migrations = []string{
+	// Optimistic locking: versi dinaikkan setiap kali data berubah
+	`ALTER TABLE alumni ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1`,
+	`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1`,
+	// Siapa yang memindahkan pekerjaan ke trash (untuk filter operasi trash)
+	`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS deleted_by INT`,
+	// Cascade soft delete alumni -> pekerjaan, ditandai dengan ID batch yang sama
+	`ALTER TABLE alumni ADD COLUMN IF NOT EXISTS deletion_batch TEXT`,
+	`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS deletion_batch TEXT`,
+	`CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_deletion_batch ON pekerjaan_alumni (deletion_batch)`,
+	// Audit log semua perubahan data
+	`CREATE TABLE IF NOT EXISTS audit_log (
+		id         BIGSERIAL PRIMARY KEY,
+		actor_id   INT,
+		action     TEXT NOT NULL,
+		entity     TEXT NOT NULL,
+		entity_id  TEXT NOT NULL,
+		ip         TEXT,
+		diff       JSONB NOT NULL DEFAULT '{}',
+		created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity, entity_id)`,
+	`CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log (created_at DESC)`,
+	// Snapshot alumni sebelum setiap update (riwayat & tampilan per tanggal)
+	`CREATE TABLE IF NOT EXISTS alumni_history (
+		id          BIGSERIAL PRIMARY KEY,
+		alumni_id   INT NOT NULL,
+		version     INT NOT NULL,
+		nim         TEXT,
+		nama        TEXT,
+		jurusan     TEXT,
+		angkatan    INT,
+		tahun_lulus INT,
+		email       TEXT,
+		no_telepon  TEXT,
+		alamat      TEXT,
+		valid_from  TIMESTAMPTZ NOT NULL,
+		valid_to    TIMESTAMPTZ NOT NULL,
+		changed_by  INT,
+		UNIQUE (alumni_id, version)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_alumni_history_valid ON alumni_history (alumni_id, valid_from, valid_to)`,
+	// Antrian job latar belakang (import, export, purge, laporan)
+	`CREATE TABLE IF NOT EXISTS jobs (
+		id           TEXT PRIMARY KEY,
+		type         TEXT NOT NULL,
+		status       TEXT NOT NULL,
+		payload      JSONB NOT NULL DEFAULT '{}',
+		attempts     INT NOT NULL DEFAULT 0,
+		max_attempts INT NOT NULL DEFAULT 1,
+		progress     INT NOT NULL DEFAULT 0,
+		message      TEXT NOT NULL DEFAULT '',
+		error        TEXT NOT NULL DEFAULT '',
+		result_path  TEXT NOT NULL DEFAULT '',
+		result_name  TEXT NOT NULL DEFAULT '',
+		result_type  TEXT NOT NULL DEFAULT '',
+		created_by   INT NOT NULL,
+		run_after    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		updated_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		started_at   TIMESTAMPTZ,
+		finished_at  TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_jobs_queue ON jobs (status, run_after, created_at)`,
+}
+
+// searchMigrations -> skema pencarian gabungan: full-text (tsvector) + kemiripan trigram untuk salah ketik.
+// Dipisah dari migrations karena CREATE EXTENSION butuh hak yang belum tentu dimiliki user aplikasi,
+// dan kolom GENERATED ... STORED menulis ulang tabel. Jika gagal, server tetap jalan tanpa /search.
+var searchMigrations = []string{
+	`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+	`ALTER TABLE alumni ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', COALESCE(nama, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(nim, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(jurusan, '')), 'B')
+	) STORED`,
+	`ALTER TABLE pekerjaan_alumni ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('simple', COALESCE(nama_perusahaan, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(posisi_jabatan, '')), 'A') ||
+		setweight(to_tsvector('simple', COALESCE(bidang_industri, '')), 'B') ||
+		setweight(to_tsvector('simple', COALESCE(deskripsi_pekerjaan, '')), 'C')
+	) STORED`,
+	`CREATE INDEX IF NOT EXISTS idx_alumni_search ON alumni USING GIN (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_search ON pekerjaan_alumni USING GIN (search_vector)`,
+	`CREATE INDEX IF NOT EXISTS idx_alumni_nama_trgm ON alumni USING GIN (nama gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_alumni_nim_trgm ON alumni USING GIN (nim gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_perusahaan_trgm ON pekerjaan_alumni USING GIN (nama_perusahaan gin_trgm_ops)`,
+	`CREATE INDEX IF NOT EXISTS idx_pekerjaan_alumni_posisi_trgm ON pekerjaan_alumni USING GIN (posisi_jabatan gin_trgm_ops)`,
+}
+
+// searchEnabled -> skema pencarian (searchMigrations) siap dipakai
+var searchEnabled bool
+
+// SearchEnabled -> false jika migrasi pencarian gagal, endpoint /search membalas 503
+func SearchEnabled() bool {
+	return searchEnabled
+}
+
+// Migrate menjalankan semua migrasi skema secara berurutan, lalu migrasi pencarian yang boleh gagal
+func Migrate() {
+	for i, stmt := range migrations {
+		if _, err := DB.Exec(stmt); err != nil {
+			log.Fatalf("Gagal menjalankan migrasi #%d: %v", i+1, err)
+		}
+	}
+	slog.Info("migrasi database PostgreSQL selesai")
+
+	if err := migrateSearch(); err != nil {
+		slog.Warn("migrasi pencarian gagal, endpoint /search dinonaktifkan (pasang ekstensi pg_trgm dengan user yang berhak lalu restart)", "error", err)
+		return
+	}
+	searchEnabled = true
+}
+
+// migrateSearch menjalankan searchMigrations dalam satu transaksi supaya tidak tersisa skema setengah jadi
+func migrateSearch() error {
+	tx, err := DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for i, stmt := range searchMigrations {
+		if _, err := tx.Exec(stmt); err != nil {
+			return fmt.Errorf("migrasi pencarian #%d: %w", i+1, err)
+		}
+	}
+	return tx.Commit()
+}
@@ -1,1510 +0,0 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
-package docs
-
-import "github.com/swaggo/swag"
-
-const docTemplate = `{
-    "schemes": {{ marshal .Schemes }},
-    "swagger": "2.0",
-    "info": {
-        "description": "{{escape .Description}}",
-        "title": "{{.Title}}",
-        "contact": {},
-        "version": "{{.Version}}"
-    },
-    "host": "{{.Host}}",
-    "basePath": "{{.BasePath}}",
-    "paths": {
-        "/api/foto": {
-            "get": {
-                "description": "Mengambil seluruh data foto yang tersimpan di MongoDB dengan opsi filter, search, pagination, dan sorting",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Foto"
-                ],
-                "summary": "Menampilkan semua foto",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cari berdasarkan nama file atau nama asli foto",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kolom untuk sorting (default: uploaded_at)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan sort (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter berdasarkan tipe file (contoh: image/jpeg, image/png)",
-                        "name": "file_type",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.FileResponse"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/foto/upload": {
-            "post": {
-                "description": "Mengunggah file foto ke server dan menyimpannya di MongoDB",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Foto"
-                ],
-                "summary": "Upload foto baru",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "File foto (jpeg/jpg/png, max 1MB)",
-                        "name": "foto",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kategori foto (contoh: profil, dokumen, event)",
-                        "name": "kategori",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Deskripsi singkat foto",
-                        "name": "deskripsi",
-                        "in": "formData"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID pengguna yang mengunggah (opsional)",
-                        "name": "uploader_id",
-                        "in": "formData"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.FileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/foto/{id}": {
-            "get": {
-                "description": "Mengambil detail satu foto berdasarkan ID dan bisa menentukan format respon",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Foto"
-                ],
-                "summary": "Menampilkan foto berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID foto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Tampilkan metadata tambahan (true/false)",
-                        "name": "include_metadata",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Mode tampilan (contoh: thumbnail/full)",
-                        "name": "view_mode",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.FileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Menghapus file foto dari server dan database berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Foto"
-                ],
-                "summary": "Menghapus foto",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID foto",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Hapus permanen (true) atau soft delete (false)",
-                        "name": "permanent",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Alasan penghapusan foto (opsional)",
-                        "name": "reason",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "ID admin yang menghapus (opsional)",
-                        "name": "admin_id",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/login": {
-            "post": {
-                "description": "Melakukan autentikasi user berdasarkan username/email dan password, lalu mengembalikan token JWT",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Login user",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Bahasa respon (contoh: id atau en)",
-                        "name": "lang",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Login dengan mode remember (persistent)",
-                        "name": "remember",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Data login user",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/alumniproject_app_models_mongodb.LoginRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/alumniproject_app_models_mongodb.LoginResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Request body tidak valid atau kosong",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Username atau password salah",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Gagal generate token",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan": {
-            "get": {
-                "description": "Mengambil semua data pekerjaan dari MongoDB (dapat difilter dan diurutkan)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan semua data pekerjaan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cari berdasarkan nama perusahaan atau posisi",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutkan berdasarkan kolom (contoh: nama_perusahaan, tanggal_mulai_kerja)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan data (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah maksimum data (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/alumniproject_app_models_mongodb.Pekerjaan"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/alumni-pekerjaan": {
-            "get": {
-                "description": "Mengambil daftar alumni dan pekerjaan mereka (khusus admin). Dapat difilter dan diurutkan menggunakan parameter query.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan semua alumni beserta pekerjaan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cari alumni berdasarkan nama atau perusahaan",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kolom untuk sorting (contoh: nama, perusahaan)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan data (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/alumniproject_app_models_mongodb.AlumniPekerjaan"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/alumni/{alumni_id}": {
-            "get": {
-                "description": "Mengambil semua pekerjaan berdasarkan ID alumni (khusus admin). Dapat difilter dan diurutkan dengan query parameter.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan pekerjaan berdasarkan Alumni ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID Alumni",
-                        "name": "alumni_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Cari berdasarkan nama perusahaan atau posisi jabatan",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutkan berdasarkan kolom (contoh: nama_perusahaan, tanggal_mulai_kerja)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan data (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/alumniproject_app_models_mongodb.Pekerjaan"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/paginated": {
-            "get": {
-                "description": "Mengambil data pekerjaan dengan pagination, sorting, dan search",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan data pekerjaan dengan pagination",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 5)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kolom untuk sorting (default: created_at)",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan sort asc/desc (default: desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kata kunci pencarian",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Tampilkan hanya pekerjaan aktif",
-                        "name": "only_active",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/alumniproject_app_models_mongodb.PekerjaanResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/trash": {
-            "get": {
-                "description": "Menampilkan semua data pekerjaan yang sudah dihapus secara soft delete. Dapat difilter dengan parameter query.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan daftar pekerjaan yang dihapus (trash)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Nomor halaman (default: 1)",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Jumlah data per halaman (default: 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Kata kunci pencarian (nama perusahaan / posisi)",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan data (asc/desc)",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/alumniproject_app_models_mongodb.GetTrashPekerjaan"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/{id}": {
-            "get": {
-                "description": "Mengambil satu data pekerjaan berdasarkan ID dari MongoDB",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Menampilkan detail pekerjaan berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Tampilkan juga jika pekerjaan sudah dihapus (soft delete)",
-                        "name": "include_deleted",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/alumniproject_app_models_mongodb.Pekerjaan"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            },
-            "put": {
-                "description": "Mengubah data pekerjaan berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Update data pekerjaan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Kirim notifikasi ke alumni (true/false)",
-                        "name": "notify",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Data pekerjaan yang akan diupdate",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/alumniproject_app_models_mongodb.UpdatePekerjaanRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Menghapus pekerjaan dengan menandainya sebagai terhapus. Bisa diatur mode penghapusan dengan parameter query.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Hapus data pekerjaan (soft delete)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Hapus permanen (true) atau soft delete (false)",
-                        "name": "permanent",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Alasan penghapusan (opsional)",
-                        "name": "reason",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/{id}/hard": {
-            "delete": {
-                "description": "Menghapus data pekerjaan secara permanen dari database. Gunakan dengan hati-hati!",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Hapus permanen data pekerjaan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Konfirmasi hapus permanen (true untuk melanjutkan)",
-                        "name": "confirm",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Alasan admin menghapus data ini (opsional)",
-                        "name": "admin_reason",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/pekerjaan/{id}/restore": {
-            "post": {
-                "description": "Mengembalikan data pekerjaan yang sebelumnya dihapus (soft delete)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Pekerjaan"
-                ],
-                "summary": "Restore data pekerjaan",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "ID pekerjaan",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Kirim notifikasi ke user terkait (true/false)",
-                        "name": "notify",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/sertifikat": {
-            "get": {
-                "description": "Mengambil seluruh data sertifikat dari MongoDB, bisa difilter dengan query parameter",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sertifikat"
-                ],
-                "summary": "Menampilkan semua sertifikat",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Cari sertifikat berdasarkan nama file",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Batas jumlah data yang diambil (default 10)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutkan berdasarkan field (contoh: uploaded_at, file_name)",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Urutan data (asc / desc)",
-                        "name": "order",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.FileResponse"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/sertifikat/upload": {
-            "post": {
-                "description": "Mengunggah file sertifikat (PDF) ke server dan menyimpannya di MongoDB",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sertifikat"
-                ],
-                "summary": "Upload sertifikat baru (PDF)",
-                "parameters": [
-                    {
-                        "type": "file",
-                        "description": "File sertifikat (PDF, max 2MB)",
-                        "name": "sertifikat",
-                        "in": "formData",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.FileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/api/sertifikat/{id}": {
-            "get": {
-                "description": "Mengambil detail sertifikat dari MongoDB berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sertifikat"
-                ],
-                "summary": "Menampilkan sertifikat berdasarkan ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID sertifikat",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Tampilkan juga sertifikat yang sudah dihapus",
-                        "name": "include_deleted",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/models.FileResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Menghapus sertifikat dari server dan MongoDB berdasarkan ID",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Sertifikat"
-                ],
-                "summary": "Menghapus sertifikat",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "ID sertifikat",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Hapus permanen (true) atau hanya tandai sebagai deleted",
-                        "name": "force",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "alumniproject_app_models_mongodb.AlumniPekerjaan": {
-            "type": "object",
-            "properties": {
-                "angkatan": {
-                    "type": "integer"
-                },
-                "bidang_industri": {
-                    "type": "string"
-                },
-                "gaji_range": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "jurusan": {
-                    "type": "string"
-                },
-                "nama": {
-                    "type": "string"
-                },
-                "nama_perusahaan": {
-                    "type": "string"
-                },
-                "posisi_jabatan": {
-                    "type": "string"
-                },
-                "status_pekerjaan": {
-                    "type": "string"
-                },
-                "tanggal_mulai_kerja": {
-                    "type": "string"
-                },
-                "total_bekerja_lebih_1_tahun": {
-                    "type": "integer"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.GetTrashPekerjaan": {
-            "type": "object",
-            "properties": {
-                "_id": {
-                    "type": "string"
-                },
-                "alumni_id": {
-                    "type": "integer"
-                },
-                "bidang_industri": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "integer"
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "lokasi_kerja": {
-                    "type": "string"
-                },
-                "nama_perusahaan": {
-                    "type": "string"
-                },
-                "posisi_jabatan": {
-                    "type": "string"
-                },
-                "status_pekerjaan": {
-                    "type": "string"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.LoginRequest": {
-            "type": "object",
-            "properties": {
-                "password": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "type": "string"
-                },
-                "user": {
-                    "$ref": "#/definitions/alumniproject_app_models_mongodb.User"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.MetaInfo": {
-            "type": "object",
-            "properties": {
-                "limit": {
-                    "type": "integer"
-                },
-                "order": {
-                    "type": "string"
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "pages": {
-                    "type": "integer"
-                },
-                "search": {
-                    "type": "string"
-                },
-                "sortBy": {
-                    "type": "string"
-                },
-                "total": {
-                    "type": "integer"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.Pekerjaan": {
-            "type": "object",
-            "properties": {
-                "_id": {
-                    "type": "string"
-                },
-                "alumni_id": {
-                    "type": "integer"
-                },
-                "bidang_industri": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "created_by": {
-                    "type": "integer"
-                },
-                "deleted_at": {
-                    "type": "string"
-                },
-                "deskripsi_pekerjaan": {
-                    "type": "string"
-                },
-                "gaji_range": {
-                    "type": "string"
-                },
-                "lokasi_kerja": {
-                    "type": "string"
-                },
-                "nama_perusahaan": {
-                    "type": "string"
-                },
-                "posisi_jabatan": {
-                    "type": "string"
-                },
-                "status_pekerjaan": {
-                    "type": "string"
-                },
-                "tanggal_mulai_kerja": {
-                    "type": "string"
-                },
-                "tanggal_selesai_kerja": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.PekerjaanResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "description": "gunakan pointer slice",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/alumniproject_app_models_mongodb.Pekerjaan"
-                    }
-                },
-                "meta": {
-                    "$ref": "#/definitions/alumniproject_app_models_mongodb.MetaInfo"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.UpdatePekerjaanRequest": {
-            "type": "object",
-            "properties": {
-                "bidang_industri": {
-                    "type": "string"
-                },
-                "deskripsi_pekerjaan": {
-                    "type": "string"
-                },
-                "gaji_range": {
-                    "type": "string"
-                },
-                "lokasi_kerja": {
-                    "type": "string"
-                },
-                "nama_perusahaan": {
-                    "type": "string"
-                },
-                "posisi_jabatan": {
-                    "type": "string"
-                },
-                "status_pekerjaan": {
-                    "type": "string"
-                },
-                "tanggal_mulai_kerja": {
-                    "type": "string"
-                },
-                "tanggal_selesai_kerja": {
-                    "type": "string"
-                }
-            }
-        },
-        "alumniproject_app_models_mongodb.User": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "password": {
-                    "type": "string"
-                },
-                "role": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "models.FileResponse": {
-            "type": "object",
-            "properties": {
-                "file_name": {
-                    "type": "string"
-                },
-                "file_path": {
-                    "type": "string"
-                },
-                "file_size": {
-                    "type": "integer"
-                },
-                "file_type": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "original_name": {
-                    "type": "string"
-                },
-                "uploaded_at": {
-                    "type": "string"
-                }
-            }
-        }
-    }
-}`
-
-// SwaggerInfo holds exported Swagger Info so clients can modify it
-var SwaggerInfo = &swag.Spec{
-	Version:          "1.0",
-	Host:             "localhost:3000",
-	BasePath:         "/api/v1",
-	Schemes:          []string{"http"},
-	Title:            "Alumni API (MongoDB)",
-	Description:      "API untuk mengelola data alumni dan pekerjaan menggunakan MongoDB",
-	InfoInstanceName: "swagger",
-	SwaggerTemplate:  docTemplate,
-	LeftDelim:        "{{",
-	RightDelim:       "}}",
-}
-
-func init() {
-	swag.Register(SwaggerInfo.InstanceName(), SwaggerInfo)
-}
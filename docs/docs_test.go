@@ -0,0 +1,116 @@
+package docs_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/swaggo/swag"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"alumniproject/config"
+	db "alumniproject/database/mongodb"
+	"alumniproject/docs"
+	mongoRoutes "alumniproject/routes/mongodb"
+	pgRoutes "alumniproject/routes/postgresql"
+	"alumniproject/utils/apiversion"
+)
+
+var pathParam = regexp.MustCompile(`:(\w+)`)
+
+// TestSpecCoversRoutes memastikan setiap route yang didaftarkan ke Fiber tercantum di spesifikasi
+// OpenAPI backend yang sama, dan sebaliknya tidak ada operasi di spesifikasi tanpa route.
+func TestSpecCoversRoutes(t *testing.T) {
+	// Setup route MongoDB membuat repository dari database.DB; client tanpa koneksi sudah cukup
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://localhost:27017"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Disconnect(context.Background())
+	db.DB = client.Database("docs_test")
+
+	tests := []struct {
+		instance string
+		setup    func(*fiber.App, config.APIConfig)
+	}{
+		{docs.InstancePostgres, pgRoutes.SetupPostgresRoutes},
+		{docs.InstanceMongo, mongoRoutes.SetupMongoRoutes},
+	}
+	for _, tt := range tests {
+		t.Run(tt.instance, func(t *testing.T) {
+			app := fiber.New()
+			tt.setup(app, config.APIConfig{Alias: apiversion.Alias{Version: apiversion.V1}})
+			routes := registeredOperations(app)
+			spec := specOperations(t, tt.instance)
+
+			for _, op := range sortedKeys(routes) {
+				if !spec[op] {
+					t.Errorf("route %s belum ada di spesifikasi %s (jalankan go generate ./docs)", op, tt.instance)
+				}
+			}
+			for _, op := range sortedKeys(spec) {
+				if !routes[op] {
+					t.Errorf("spesifikasi %s memuat %s yang tidak terdaftar di Fiber", tt.instance, op)
+				}
+			}
+		})
+	}
+}
+
+// registeredOperations -> "METHOD /path" untuk setiap handler, path dalam format OpenAPI.
+// Route alias /api dicatat sebagai route /api/v1 yang dilayaninya.
+func registeredOperations(app *fiber.App) map[string]bool {
+	v1 := apiversion.Path(apiversion.V1, "")
+	ops := make(map[string]bool)
+	for _, r := range app.GetRoutes(true) {
+		// HEAD otomatis dibuat Fiber untuk setiap GET
+		if r.Method == http.MethodHead {
+			continue
+		}
+		path := strings.TrimSuffix(r.Path, "/")
+		if !strings.HasPrefix(path, v1+"/") {
+			path = v1 + strings.TrimPrefix(path, apiversion.Root)
+		}
+		path = pathParam.ReplaceAllString(path, "{$1}")
+		ops[r.Method+" "+path] = true
+	}
+	return ops
+}
+
+// specOperations -> "METHOD /path" untuk setiap operasi di spesifikasi instance
+func specOperations(t *testing.T, instance string) map[string]bool {
+	doc, err := swag.ReadDoc(instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		BasePath string                                `json:"basePath"`
+		Paths    map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(doc), &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	ops := make(map[string]bool)
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			ops[strings.ToUpper(method)+" "+strings.TrimSuffix(spec.BasePath, "/")+path] = true
+		}
+	}
+	return ops
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
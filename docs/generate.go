@@ -0,0 +1,16 @@
+// Package docs berisi spesifikasi OpenAPI hasil swag, satu instance per backend karena daftar
+// route PostgreSQL dan MongoDB berbeda. Info umum tiap instance ada di routes/<backend>/doc.go,
+// anotasi endpoint di handler app/services/<backend> dan endpoint /jobs bersama di utils/jobs/handler.
+//
+// Jalankan go generate ./docs setelah mengubah route atau anotasi handler; test di paket ini
+// gagal jika ada route Fiber yang belum tercantum di spesifikasi.
+package docs
+
+//go:generate swag init --parseDependency --instanceName postgresql --packageName docs -g doc.go -d ../routes/postgresql,../app/services/postgresql,../utils/jobs/handler -o .
+//go:generate swag init --parseDependency --instanceName mongodb --packageName docs -g doc.go -d ../routes/mongodb,../app/services/mongodb,../utils/jobs/handler -o .
+
+// Nama instance spesifikasi, dipakai saat mendaftarkan Swagger UI
+const (
+	InstancePostgres = "postgresql"
+	InstanceMongo    = "mongodb"
+)
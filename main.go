@@ -1,26 +1,30 @@
 package main
 
 import (
+    "context"
     "log"
     "os"
+    "os/signal"
+    "syscall"
+    "time"
 
+    mongoService "alumniproject/app/services/mongodb"
+    pgService "alumniproject/app/services/postgresql"
     "alumniproject/config"
     "alumniproject/database/mongodb"
     "alumniproject/database/postgresql"
+    "alumniproject/docs"
     mongoRoutes "alumniproject/routes/mongodb"
     pgRoutes "alumniproject/routes/postgresql"
+    "alumniproject/utils/health"
+    "alumniproject/utils/i18n"
+    "alumniproject/utils/metrics"
+    "alumniproject/utils/scheduler"
 
     "github.com/gofiber/fiber/v2"
     fiberSwagger "github.com/swaggo/fiber-swagger"
-    _ "alumniproject/docs" // hasil dari swag init (wajib ada)
 )
 
-// @title Alumni API (MongoDB)
-// @version 1.0
-// @description API untuk mengelola data alumni dan pekerjaan menggunakan MongoDB
-// @host localhost:3000
-// @BasePath /api/v1
-// @schemes http
 func main() {
     // Load environment variables
     config.LoadEnv()
@@ -29,49 +33,137 @@ func main() {
     // Setup logger
     config.SetupLogger()
 
-    // Setup Fiber app
-    app := config.SetupApp()
-
     // Tentukan DB_TYPE dari .env
     dbType := os.Getenv("DB_TYPE")
     if dbType == "" {
         dbType = "postgres"
     }
 
+    // Sub-command CLI (misal: purge-trash) dijalankan lalu keluar tanpa start server
+    runCommand(dbType, os.Args[1:])
+
+    serverCfg := config.LoadServerConfig()
+
+    // Tracing OpenTelemetry (TRACING_EXPORTER=otlp|stdout), dipasang sebelum koneksi database
+    shutdownTracing := config.SetupTracing(context.Background(), config.LoadTracingConfig())
+
+    // requestCtx induk context setiap request, dibatalkan jika request masih berjalan saat
+    // batas waktu shutdown habis agar query-nya ikut berhenti
+    requestCtx, abortRequests := context.WithCancel(context.Background())
+    defer abortRequests()
+
+    // Setup Fiber app
+    app := config.SetupApp(requestCtx, serverCfg)
+    dbCfg := config.LoadDatabaseConfig()
+    trashCfg := config.LoadTrashConfig()
+    jobsCfg := config.LoadJobsConfig()
+    apiCfg := config.LoadAPIConfig()
+
+    // ctx dibatalkan saat SIGTERM/SIGINT: scheduler & worker job berhenti, lalu server dimatikan
+    ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+    defer stop()
+
+    // Langkah shutdown yang berbeda per backend
+    var waitJobs, closeDB func(context.Context) error
+
     // Pilih database berdasarkan DB_TYPE
     switch dbType {
     case "mongodb":
         // ✅ MongoDB mode
-        database.ConnectMongo()
-        mongoRoutes.SetupMongoRoutes(app)
+        database.ConnectMongo(dbCfg)
+        mongoRoutes.SetupMongoRoutes(app, apiCfg)
         log.Println("✅ MongoDB Connected and Routes Registered")
+        mongoService.StartTrashPurgeScheduler(ctx, trashCfg)
+        mongoService.StartJobQueue(ctx, jobsCfg)
+        waitJobs, closeDB = mongoService.WaitJobQueue, database.Disconnect
+
+        health.Register(app, health.Check{Name: "mongodb", Ping: database.Ping})
 
-        // 👉 Swagger hanya aktif di MongoDB
-        app.Get("/swagger/*", fiberSwagger.WrapHandler)
-        log.Println("📘 Swagger UI aktif di: http://localhost:3000/swagger/index.html")
+        app.Get("/swagger/*", fiberSwagger.FiberWrapHandler(fiberSwagger.InstanceName(docs.InstanceMongo)))
 
     case "postgres":
-        // PostgreSQL mode tanpa Swagger
-        postgresql.ConnectPostgres()
-        pgRoutes.SetupPostgresRoutes(app)
-        log.Println("✅ PostgreSQL Connected and Routes Registered (tanpa Swagger)")
+        // ✅ PostgreSQL mode
+        postgresql.ConnectPostgres(dbCfg)
+        pgRoutes.SetupPostgresRoutes(app, apiCfg)
+        log.Println("✅ PostgreSQL Connected and Routes Registered")
+        pgService.StartTrashPurgeScheduler(ctx, trashCfg)
+        pgService.StartJobQueue(ctx, jobsCfg)
+        waitJobs = pgService.WaitJobQueue
+        closeDB = func(context.Context) error { return postgresql.Close() }
+
+        health.Register(app, health.Check{Name: "postgresql", Ping: postgresql.Ping})
+
+        app.Get("/swagger/*", fiberSwagger.FiberWrapHandler(fiberSwagger.InstanceName(docs.InstancePostgres)))
 
     default:
         log.Fatalf("❌ Unknown DB_TYPE: %s", dbType)
     }
 
+    // Metrik Prometheus untuk scrape & dashboard Grafana
+    app.Get(metrics.Path, metrics.Handler())
+
+    // Spesifikasi OpenAPI sesuai backend yang aktif
+    log.Println("📘 Swagger UI aktif di: http://localhost:3000/swagger/index.html")
+
     // Root endpoint
     app.Get("/", func(c *fiber.Ctx) error {
         return c.JSON(fiber.Map{
-            "message": "Selamat datang di API Alumni dan Pekerjaan",
+            "message": i18n.Msg(c, "welcome"),
             "db_used": dbType,
         })
     })
 
-    // Jalankan server
-    if err := app.Listen(":3000"); err != nil {
-        log.Fatalf("Failed to start server: %v", err)
+    // Jalankan server sampai gagal listen atau menerima sinyal berhenti
+    listenErr := make(chan error, 1)
+    go func() {
+        listenErr <- app.Listen(serverCfg.Addr)
+    }()
+
+    select {
+    case err := <-listenErr:
+        if err != nil {
+            log.Fatalf("Failed to start server: %v", err)
+        }
+    case <-ctx.Done():
+        // Sinyal kedua langsung mematikan proses tanpa menunggu
+        stop()
+        shutdown(app, serverCfg.ShutdownTimeout, abortRequests, waitJobs, closeDB)
+
+        // Kirim span yang masih di buffer sebelum proses keluar
+        flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+        defer cancelFlush()
+        if err := shutdownTracing(flushCtx); err != nil {
+            log.Printf("⚠️ Gagal mengirim sisa trace: %v", err)
+        }
+    }
+}
+
+// shutdown berhenti menerima koneksi baru, menunggu request yang sedang berjalan, worker job, dan
+// scheduler dalam satu batas waktu, lalu menutup koneksi database. Request yang belum selesai saat
+// batas waktu habis dibatalkan lewat abortRequests.
+func shutdown(app *fiber.App, timeout time.Duration, abortRequests context.CancelFunc, waitJobs, closeDB func(context.Context) error) {
+    log.Printf("🛑 Server berhenti, menunggu request & job selesai (maks %s)", timeout)
+    ctx, cancel := context.WithTimeout(context.Background(), timeout)
+    defer cancel()
+
+    if err := app.ShutdownWithContext(ctx); err != nil {
+        log.Printf("⚠️ Masih ada request yang belum selesai, dibatalkan: %v", err)
+        abortRequests()
+    }
+    if err := waitJobs(ctx); err != nil {
+        log.Printf("⚠️ Worker job belum berhenti: %v", err)
+    }
+    if err := scheduler.Wait(ctx); err != nil {
+        log.Printf("⚠️ Scheduler belum berhenti: %v", err)
+    }
+
+    // Database tetap ditutup walaupun batas waktu di atas sudah habis
+    closeCtx, cancelClose := context.WithTimeout(context.Background(), 5*time.Second)
+    defer cancelClose()
+    if err := closeDB(closeCtx); err != nil {
+        log.Printf("⚠️ Gagal menutup koneksi database: %v", err)
     }
+    log.Println("👋 Server berhenti")
 }
 
 
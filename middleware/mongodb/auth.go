@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"alumniproject/utils/apperror"
 	"alumniproject/utils/mongodb"
 )
 
@@ -14,23 +15,23 @@ func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{"error": "Token akses diperlukan"})
+			return apperror.Unauthorized("auth.token_required")
 		}
 
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{"error": "Format token tidak valid"})
+			return apperror.Unauthorized("auth.token_format")
 		}
 
 		claims, err := mongodbutils.ValidateToken(tokenParts[1])
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{"error": "Token tidak valid atau sudah expired"})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 
 		userIDStr := claims.UserID
 		userID, err := strconv.Atoi(userIDStr)
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{"error": "User ID tidak valid"})
+			return apperror.Unauthorized("auth.user_id_invalid")
 		}
 
 		c.Locals("user_id", userID)
@@ -46,7 +47,7 @@ func AdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role, ok := c.Locals("role").(string)
 		if !ok || role != "admin" {
-			return c.Status(403).JSON(fiber.Map{"error": "Akses ditolak. Hanya admin yang diizinkan."})
+			return apperror.Forbidden("auth.admin_only")
 		}
 		return c.Next()
 	}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"alumniproject/utils/apperror"
 	"alumniproject/utils/postgresql"
 	"strings"
 
@@ -11,15 +12,15 @@ func AuthRequired() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
-			return c.Status(401).JSON(fiber.Map{"error": "Token akses diperlukan"})
+			return apperror.Unauthorized("auth.token_required")
 		}
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			return c.Status(401).JSON(fiber.Map{"error": "Format token tidak valid"})
+			return apperror.Unauthorized("auth.token_format")
 		}
 		claims, err := postgresutils.ValidateToken(tokenParts[1])
 		if err != nil {
-			return c.Status(401).JSON(fiber.Map{"error": "Token tidak valid atau expired"})
+			return apperror.Unauthorized("auth.token_invalid")
 		}
 		c.Locals("user_id", claims.UserID)
 		c.Locals("username", claims.Username)
@@ -32,7 +33,7 @@ func AdminOnly() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		role := c.Locals("role").(string)
 		if role != "admin" {
-			return c.Status(403).JSON(fiber.Map{"error": "Akses ditolak. Hanya admin yang diizinkan"})
+			return apperror.Forbidden("auth.admin_only")
 		}
 		return c.Next()
 	}
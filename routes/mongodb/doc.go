@@ -0,0 +1,17 @@
+// Package routes mendaftarkan endpoint API untuk mode MongoDB (DB_TYPE=mongodb).
+//
+// Anotasi berikut adalah info umum spesifikasi OpenAPI instance "mongodb" yang disajikan di
+// /swagger; dokumen di folder docs dibuat ulang dengan go generate ./docs.
+//
+// @title Alumni API (MongoDB)
+// @version 1.0
+// @description API untuk mengelola data alumni dan pekerjaan menggunakan MongoDB. Path /api tanpa versi masih dilayani sebagai alias /api/v1 yang deprecated (header Deprecation, Sunset, Link).
+// @host localhost:3000
+// @BasePath /api/v1
+// @schemes http
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token JWT dari /api/v1/login, dikirim dengan format "Bearer <token>"
+package routes
@@ -5,11 +5,21 @@ import (
     service "alumniproject/app/services/mongodb"
     middleware "alumniproject/middleware/mongodb"
 	repo "alumniproject/app/repository/mongodb"
+    "alumniproject/config"
     db "alumniproject/database/mongodb"
+    "alumniproject/utils/apiversion"
 )
 
-func SetupMongoRoutes(app *fiber.App) {
-    api := app.Group("/api")
+// SetupMongoRoutes mengatur semua endpoint API untuk MongoDB di /api/v1, dengan alias /api
+// sesuai cfg. Versi baru ditambahkan sebagai apiversion.Version berikutnya.
+func SetupMongoRoutes(app *fiber.App, cfg config.APIConfig) {
+    apiversion.Mount(app, cfg.Alias,
+        apiversion.Version{Name: apiversion.V1, Register: registerV1, Deprecation: cfg.V1},
+    )
+}
+
+// registerV1 mendaftarkan endpoint API v1, path relatif terhadap prefix versi
+func registerV1(api fiber.Router) {
 
     // =============================
     // AUTH & LOGIN
@@ -21,19 +31,69 @@ func SetupMongoRoutes(app *fiber.App) {
     // =============================
     pekerjaan := api.Group("/pekerjaan")
 
+    // Path statis didaftarkan sebelum /:id, kalau tidak Fiber mencocokkannya sebagai :id
     pekerjaan.Get("/", middleware.AuthRequired(), service.GetAllPekerjaanService)
-    pekerjaan.Get("/:id", middleware.AuthRequired(), service.GetPekerjaanByID)
-    pekerjaan.Get("/alumni/:alumni_id", middleware.AuthRequired(), middleware.AdminOnly(), service.GetPekerjaanByAlumniID)
+    pekerjaan.Get("/export", middleware.AuthRequired(), service.ExportPekerjaanService)
     pekerjaan.Get("/paginated", middleware.AuthRequired(), service.GetPekerjaanPaginated)
     pekerjaan.Get("/alumni-pekerjaan", middleware.AuthRequired(), middleware.AdminOnly(), service.GetAllAlumniWithPekerjaan)
+    pekerjaan.Get("/trash", middleware.AuthRequired(), service.GetTrashPekerjaanService)
+    pekerjaan.Get("/:id", middleware.AuthRequired(), service.GetPekerjaanByID)
+    pekerjaan.Get("/alumni/:alumni_id", middleware.AuthRequired(), middleware.AdminOnly(), service.GetPekerjaanByAlumniID)
 
     pekerjaan.Post("/", middleware.AuthRequired(), service.CreatePekerjaanService)
+    pekerjaan.Post("/bulk", middleware.AuthRequired(), service.BulkCreatePekerjaanService)
+    pekerjaan.Patch("/bulk", middleware.AuthRequired(), service.BulkUpdatePekerjaanService)
     pekerjaan.Put("/:id", middleware.AuthRequired(), service.UpdatePekerjaanService)
+    pekerjaan.Patch("/:id", middleware.AuthRequired(), service.PatchPekerjaanService)
     pekerjaan.Delete("/:id", middleware.AuthRequired(), middleware.AdminOrOwner(), service.DeletePekerjaanService)
-    pekerjaan.Get("/trash", middleware.AuthRequired(), service.GetTrashPekerjaanService)
+    pekerjaan.Post("/trash/restore", middleware.AuthRequired(), service.RestoreTrashPekerjaanService)
+    pekerjaan.Post("/trash/purge", middleware.AuthRequired(), service.PurgeTrashPekerjaanService)
     pekerjaan.Post("/:id/restore", middleware.AuthRequired(), service.RestorePekerjaanService)
     pekerjaan.Delete("/:id/hard", middleware.AuthRequired(), middleware.AdminOnly(), service.HardDeletePekerjaanService)
 
+    // =============================
+    // ALUMNI ROUTES
+    // =============================
+    alumni := api.Group("/alumni")
+
+    alumni.Get("/", middleware.AuthRequired(), service.GetAlumniService)
+    alumni.Post("/import", middleware.AuthRequired(), service.ImportAlumniService)
+    alumni.Get("/export", middleware.AuthRequired(), service.ExportAlumniService)
+    alumni.Get("/:id", middleware.AuthRequired(), service.GetAlumniByIDService)
+    alumni.Get("/:id/history", middleware.AuthRequired(), service.GetAlumniHistoryService)
+    alumni.Post("/:id/revert", middleware.AuthRequired(), service.RevertAlumniService)
+    alumni.Patch("/:id", middleware.AuthRequired(), service.PatchAlumniService)
+    alumni.Delete("/:id", middleware.AuthRequired(), middleware.AdminOrOwner(), service.DeleteAlumniService)
+    alumni.Post("/:id/restore", middleware.AuthRequired(), service.RestoreAlumniService)
+
+    // =============================
+    // USERS
+    // =============================
+    api.Get("/users", middleware.AuthRequired(), middleware.AdminOnly(), service.GetUsersService)
+
+    // =============================
+    // SEARCH
+    // =============================
+    api.Get("/search", middleware.AuthRequired(), service.SearchService)
+
+    // =============================
+    // AUDIT LOG
+    // =============================
+    api.Get("/audit", middleware.AuthRequired(), middleware.AdminOnly(), service.GetAuditLogsService)
+
+    // =============================
+    // BACKGROUND JOBS
+    // =============================
+    jobs := api.Group("/jobs", middleware.AuthRequired())
+    jobs.Post("/", service.JobHandler.CreateJob)
+    jobs.Get("/:id", service.JobHandler.GetJob)
+    jobs.Get("/:id/result", service.JobHandler.GetJobResult)
+
+    // =============================
+    // REPORTS
+    // =============================
+    api.Get("/reports/tracer", middleware.AuthRequired(), middleware.AdminOnly(), service.GetTracerReportService)
+
     // UPLOAD FOTO & SERTIFIKAT
     // =============================
     fotoRepo := repo.NewFotoRepository(db.DB)
@@ -0,0 +1,17 @@
+// Package postgresql mendaftarkan endpoint API untuk mode PostgreSQL (DB_TYPE=postgres).
+//
+// Anotasi berikut adalah info umum spesifikasi OpenAPI instance "postgresql" yang disajikan di
+// /swagger; dokumen di folder docs dibuat ulang dengan go generate ./docs.
+//
+// @title Alumni API (PostgreSQL)
+// @version 1.0
+// @description API untuk mengelola data alumni dan pekerjaan menggunakan PostgreSQL. Path /api tanpa versi masih dilayani sebagai alias /api/v1 yang deprecated (header Deprecation, Sunset, Link).
+// @host localhost:3000
+// @BasePath /api/v1
+// @schemes http
+//
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Token JWT dari /api/v1/login, dikirim dengan format "Bearer <token>"
+package postgresql
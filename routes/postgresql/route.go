@@ -2,14 +2,22 @@ package postgresql
 
 import (
 	service "alumniproject/app/services/postgresql"
+	"alumniproject/config"
 	"alumniproject/middleware/postgresql"
+	"alumniproject/utils/apiversion"
 	"github.com/gofiber/fiber/v2"
 )
 
-// SetupPostgresRoutes mengatur semua endpoint API untuk PostgreSQL
-func SetupPostgresRoutes(app *fiber.App) {
-	api := app.Group("/api")
+// SetupPostgresRoutes mengatur semua endpoint API untuk PostgreSQL di /api/v1, dengan alias /api
+// sesuai cfg. Versi baru ditambahkan sebagai apiversion.Version berikutnya.
+func SetupPostgresRoutes(app *fiber.App, cfg config.APIConfig) {
+	apiversion.Mount(app, cfg.Alias,
+		apiversion.Version{Name: apiversion.V1, Register: registerV1, Deprecation: cfg.V1},
+	)
+}
 
+// registerV1 mendaftarkan endpoint API v1, path relatif terhadap prefix versi
+func registerV1(api fiber.Router) {
 	// --- Public route ---
 	api.Post("/login", service.Login)
 
@@ -20,29 +28,58 @@ func SetupPostgresRoutes(app *fiber.App) {
 	alumni := protected.Group("/alumni")
 	alumni.Get("/", service.GetAllAlumni)
 	alumni.Get("/all", service.GetAlumniService)
+	alumni.Post("/import", service.ImportAlumniService)
+	alumni.Get("/export", service.ExportAlumniService)
 	alumni.Get("/:id", service.GetAlumniByIDService)
+	alumni.Get("/:id/history", service.GetAlumniHistoryService)
+	alumni.Post("/:id/revert", service.RevertAlumniService)
 	alumni.Post("/", service.CreateAlumniService)
 	alumni.Put("/:id", service.UpdateAlumniService)
+	alumni.Patch("/:id", service.PatchAlumniService)
 	alumni.Delete("/:id", service.DeleteAlumniService)
 	alumni.Put("/restore/:id", service.RestoreAlumniService)
 
 	// === PEKERJAAN ROUTES ===
 	pekerjaan := protected.Group("/pekerjaan")
 	pekerjaan.Get("/trash", service.GetTrashPekerjaanService)
+	pekerjaan.Get("/export", service.ExportPekerjaanService)
+	pekerjaan.Post("/trash/restore", service.RestoreTrashPekerjaanService)
+	pekerjaan.Post("/trash/purge", service.PurgeTrashPekerjaanService)
 	pekerjaan.Get("/", service.GetAllPekerjaanService)
 	pekerjaan.Get("/:id", service.GetPekerjaanByID)
 	pekerjaan.Get("/alumni/:alumni_id", middleware.AdminOnly(), service.GetPekerjaanByAlumniID)
 	pekerjaan.Post("/", service.CreatePekerjaanService)
+	pekerjaan.Post("/bulk", service.BulkCreatePekerjaanService)
+	pekerjaan.Patch("/bulk", service.BulkUpdatePekerjaanService)
 	pekerjaan.Delete("/:id", service.DeletePekerjaanService)
 	pekerjaan.Delete("/hard-delete/:id", service.HardDeletePekerjaanService)
 	pekerjaan.Put("/restore/:id", service.RestorePekerjaanService)
 	pekerjaan.Put("/:id", service.UpdatePekerjaanService)
+	pekerjaan.Patch("/:id", service.PatchPekerjaanService)
 
 	// === ALUMNI + PEKERJAAN COMBINED ===
 	alumniPekerjaan := protected.Group("/alumni-pekerjaan")
 	alumniPekerjaan.Get("/", service.GetAllAlumniWithPekerjaan)
 	alumniPekerjaan.Get("/long-term", service.GetAlumniWithLongTermJobs)
 	alumniPekerjaan.Get("/status/:status", service.GetAlumniByStatusPekerjaan)
+
+	// === USERS ===
+	protected.Get("/users", middleware.AdminOnly(), service.GetUsersService)
+
+	// === SEARCH ===
+	protected.Get("/search", service.SearchService)
+
+	// === AUDIT LOG ===
+	protected.Get("/audit", middleware.AdminOnly(), service.GetAuditLogsService)
+
+	// === BACKGROUND JOBS ===
+	jobs := protected.Group("/jobs")
+	jobs.Post("/", service.JobHandler.CreateJob)
+	jobs.Get("/:id", service.JobHandler.GetJob)
+	jobs.Get("/:id/result", service.JobHandler.GetJobResult)
+
+	// === REPORTS ===
+	protected.Get("/reports/tracer", middleware.AdminOnly(), service.GetTracerReportService)
 }
 
 
@@ -0,0 +1,121 @@
+// Package apiversion memasang route API per versi (/api/v1, /api/v2, ...) dan menandai route yang
+// deprecated lewat header Deprecation (RFC 9745), Sunset (RFC 8594), dan Link.
+//
+// Setiap versi punya fungsi pendaftar route sendiri. Versi baru cukup mendaftarkan handler yang
+// berubah lalu memanggil pendaftar versi sebelumnya untuk sisanya; Fiber memakai route yang
+// didaftarkan lebih dulu, jadi handler baru menang:
+//
+//	func registerV2(api fiber.Router) {
+//		api.Get("/alumni", service.GetAlumniV2)
+//		registerV1(api)
+//	}
+package apiversion
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Root -> prefix semua versi API
+const Root = "/api"
+
+// V1 -> versi API pertama
+const V1 = "v1"
+
+// Path -> path lengkap route pada versi tertentu, mis. Path(V1, "/jobs/1") = "/api/v1/jobs/1"
+func Path(version, path string) string {
+	return Root + "/" + version + path
+}
+
+// Deprecation -> informasi deprecation satu group route
+type Deprecation struct {
+	Since  time.Time // sejak kapan deprecated, dikirim di header Deprecation
+	Sunset time.Time // kapan route dihapus, kosong -> header Sunset tidak dikirim
+	Link   string    // dokumen migrasi, dikirim sebagai Link rel="deprecation"
+}
+
+// Deprecated -> middleware yang menambahkan header deprecation ke setiap response group/route
+func Deprecated(d Deprecation) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		setHeaders(c, d)
+		return c.Next()
+	}
+}
+
+func setHeaders(c *fiber.Ctx, d Deprecation) {
+	// RFC 9745: structured field date, detik sejak epoch diawali "@"
+	c.Set("Deprecation", "@"+strconv.FormatInt(d.Since.Unix(), 10))
+	if !d.Sunset.IsZero() {
+		c.Set("Sunset", d.Sunset.UTC().Format(http.TimeFormat))
+	}
+	if d.Link != "" {
+		c.Append(fiber.HeaderLink, `<`+d.Link+`>; rel="deprecation"`)
+	}
+}
+
+// Version -> satu versi API beserta fungsi pendaftar route-nya
+type Version struct {
+	Name        string             // mis. V1, dipasang di /api/<Name>
+	Register    func(fiber.Router) // mendaftarkan route relatif terhadap prefix versi
+	Deprecation *Deprecation       // nil -> versi masih aktif
+}
+
+// Alias -> route tanpa versi di /api yang melayani route salah satu versi
+type Alias struct {
+	Version     string       // versi yang dilayani, kosong -> alias tidak dipasang
+	Deprecation *Deprecation // nil -> alias tidak ditandai deprecated
+}
+
+// Mount memasang setiap versi di /api/<versi>, lalu alias di /api. Alias dipasang paling akhir
+// karena middleware group /api juga cocok dengan path /api/<versi>.
+func Mount(app fiber.Router, alias Alias, versions ...Version) {
+	var aliased *Version
+	prefixes := make([]string, len(versions))
+	for i := range versions {
+		v := &versions[i]
+		prefixes[i] = Path(v.Name, "")
+		v.Register(app.Group(prefixes[i], deprecated(v.Deprecation, nil)...))
+		if v.Name == alias.Version {
+			aliased = v
+		}
+	}
+	if aliased == nil {
+		return
+	}
+
+	// Request /api/<versi> yang tidak cocok dengan route versinya tidak diberi header alias
+	successor := func(c *fiber.Ctx) string {
+		for _, prefix := range prefixes {
+			if c.Path() == prefix || strings.HasPrefix(c.Path(), prefix+"/") {
+				return ""
+			}
+		}
+		return Path(aliased.Name, strings.TrimPrefix(c.Path(), Root))
+	}
+	router := app.Group(Root, deprecated(alias.Deprecation, successor)...)
+	aliased.Register(router)
+}
+
+// deprecated -> middleware header deprecation untuk group, kosong jika d nil. successor (opsional)
+// mengembalikan path pengganti untuk Link rel="successor-version"; "" berarti header dilewati.
+func deprecated(d *Deprecation, successor func(*fiber.Ctx) string) []fiber.Handler {
+	if d == nil {
+		return nil
+	}
+	dep := *d
+	return []fiber.Handler{func(c *fiber.Ctx) error {
+		if successor == nil {
+			setHeaders(c, dep)
+			return c.Next()
+		}
+		if path := successor(c); path != "" {
+			setHeaders(c, dep)
+			c.Append(fiber.HeaderLink, `<`+path+`>; rel="successor-version"`)
+		}
+		return c.Next()
+	}}
+}
@@ -0,0 +1,301 @@
+// Package apperror berisi error domain bertipe (NotFound, Forbidden, Validation, Conflict, ...)
+// yang dikembalikan repository & service, lalu diubah satu kali di ErrorHandler Fiber menjadi
+// response RFC 7807 (application/problem+json) dengan kode error yang stabil untuk client.
+//
+// Detail error ditulis sebagai kunci katalog i18n dan baru diterjemahkan ke bahasa request
+// saat response ditulis.
+package apperror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"alumniproject/utils/i18n"
+)
+
+// ContentType -> media type response error (RFC 7807)
+const ContentType = "application/problem+json"
+
+// typeBase -> prefix URI "type" pada problem, diikuti kode error
+const typeBase = "urn:alumniproject:problem:"
+
+// Kode error umum per jenis. Kode yang lebih spesifik dipasang dengan WithCode.
+const (
+	CodeValidation           = "validation_error"
+	CodeUnauthorized         = "unauthorized"
+	CodeForbidden            = "forbidden"
+	CodeNotFound             = "not_found"
+	CodeConflict             = "conflict"
+	CodeGone                 = "gone"
+	CodePreconditionFailed   = "precondition_failed"
+	CodeUnsupportedMediaType = "unsupported_media_type"
+	CodeUnavailable          = "service_unavailable"
+	CodeTimeout              = "request_timeout"
+	CodeInternal             = "internal_error"
+
+	CodeVersionConflict = "version_conflict"
+	CodeInvalidID       = "invalid_id"
+	CodeInvalidFilter   = "invalid_filter"
+	CodeInvalidCursor   = "invalid_cursor"
+	CodeRouteNotFound   = "route_not_found"
+	CodeJobNotFinished  = "job_not_finished"
+)
+
+// Error -> error domain dengan status HTTP dan kode stabil
+type Error struct {
+	Status int
+	Code   string
+	Detail string                  // kunci katalog i18n (atau teks apa adanya)
+	Args   []interface{}           // argumen placeholder pada Detail
+	Fields map[string]i18n.Message // pesan per field, untuk error validasi
+	Err    error                   // penyebab asli, hanya dicatat di log
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil && e.Detail == "" {
+		return e.Err.Error()
+	}
+	return e.Localize(i18n.Default)
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// WithCode -> salinan error dengan kode yang lebih spesifik
+func (e *Error) WithCode(code string) *Error {
+	cp := *e
+	cp.Code = code
+	return &cp
+}
+
+// WithArgs -> salinan error dengan argumen untuk placeholder pada Detail
+func (e *Error) WithArgs(args ...interface{}) *Error {
+	cp := *e
+	cp.Args = args
+	return &cp
+}
+
+// WithFields -> salinan error dengan pesan per field
+func (e *Error) WithFields(fields map[string]i18n.Message) *Error {
+	cp := *e
+	cp.Fields = fields
+	return &cp
+}
+
+// Localize -> detail error dalam bahasa lang. Tanpa detail, pesan per field digabung
+// (urut nama field); jika tidak ada juga, dipakai pesan umum untuk kode error.
+func (e *Error) Localize(lang string) string {
+	if e.Detail != "" {
+		return i18n.T(lang, e.Detail, e.Args...)
+	}
+	if len(e.Fields) == 0 {
+		return i18n.T(lang, e.Code)
+	}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name + " " + e.Fields[name].In(lang)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func newError(status int, code, detail string) *Error {
+	return &Error{Status: status, Code: code, Detail: detail}
+}
+
+// Validation -> 400, input tidak valid
+func Validation(detail string) *Error {
+	return newError(http.StatusBadRequest, CodeValidation, detail)
+}
+
+// Unauthorized -> 401, belum login / token tidak valid
+func Unauthorized(detail string) *Error {
+	return newError(http.StatusUnauthorized, CodeUnauthorized, detail)
+}
+
+// Forbidden -> 403, tidak punya akses
+func Forbidden(detail string) *Error {
+	return newError(http.StatusForbidden, CodeForbidden, detail)
+}
+
+// NotFound -> 404, data tidak ditemukan
+func NotFound(detail string) *Error {
+	return newError(http.StatusNotFound, CodeNotFound, detail)
+}
+
+// Conflict -> 409, bentrok dengan keadaan data saat ini
+func Conflict(detail string) *Error {
+	return newError(http.StatusConflict, CodeConflict, detail)
+}
+
+// Gone -> 410, data pernah ada tapi sudah tidak tersedia
+func Gone(detail string) *Error {
+	return newError(http.StatusGone, CodeGone, detail)
+}
+
+// PreconditionFailed -> 412, If-Match tidak cocok dengan versi data
+func PreconditionFailed(detail string) *Error {
+	return newError(http.StatusPreconditionFailed, CodePreconditionFailed, detail)
+}
+
+// UnsupportedMediaType -> 415, Content-Type request tidak didukung
+func UnsupportedMediaType(detail string) *Error {
+	return newError(http.StatusUnsupportedMediaType, CodeUnsupportedMediaType, detail)
+}
+
+// Unavailable -> 503, fitur/layanan sedang tidak aktif
+func Unavailable(detail string) *Error {
+	return newError(http.StatusServiceUnavailable, CodeUnavailable, detail)
+}
+
+// Timeout -> 504, request melewati batas waktunya (deadline request / query)
+func Timeout(detail string) *Error {
+	return newError(http.StatusGatewayTimeout, CodeTimeout, detail)
+}
+
+// Invalid -> 400 dari error hasil parsing input. Error domain dikembalikan apa adanya,
+// selain itu teks error dipakai sebagai detail.
+func Invalid(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Validation(err.Error())
+}
+
+// Internal -> 500. detail dikirim ke client, err hanya dicatat di log.
+func Internal(detail string, err error) *Error {
+	e := newError(http.StatusInternalServerError, CodeInternal, detail)
+	e.Err = err
+	return e
+}
+
+// Localize -> pesan err dalam bahasa lang, untuk hasil per item (mis. operasi bulk).
+// Teks error biasa ikut diterjemahkan jika berupa kunci katalog.
+func Localize(err error, lang string) string {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr.Localize(lang)
+	}
+	return i18n.T(lang, err.Error())
+}
+
+// From mengubah error apa pun menjadi *Error: error domain apa adanya, *fiber.Error sesuai statusnya,
+// data tidak ditemukan dari driver database -> NotFound, deadline habis -> Timeout, selain itu Internal.
+func From(err error) *Error {
+	// Error server yang penyebabnya deadline habis (juga yang sudah dibungkus Internal) -> 504
+	if isTimeout(err) {
+		var appErr *Error
+		if !errors.As(err, &appErr) || appErr.Status >= http.StatusInternalServerError {
+			e := Timeout("")
+			e.Err = err
+			return e
+		}
+	}
+
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		e := newError(fiberErr.Code, codeForStatus(fiberErr.Code), fiberErr.Message)
+		if fiberErr.Code == http.StatusNotFound {
+			e.Code = CodeRouteNotFound
+		}
+		return e
+	}
+
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, mongo.ErrNoDocuments) {
+		return NotFound("data.not_found")
+	}
+	return Internal("", err)
+}
+
+// isTimeout -> err berasal dari deadline context yang habis (database/sql, lib/pq, atau driver MongoDB)
+func isTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || mongo.IsTimeout(err)
+}
+
+func codeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusPreconditionFailed:
+		return CodePreconditionFailed
+	case http.StatusUnsupportedMediaType:
+		return CodeUnsupportedMediaType
+	case http.StatusServiceUnavailable:
+		return CodeUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	case http.StatusInternalServerError:
+		return CodeInternal
+	default:
+		return "http_" + strconv.Itoa(status)
+	}
+}
+
+// Problem -> body response RFC 7807, ditambah "code" (kode stabil) dan "errors" (pesan per field)
+type Problem struct {
+	Type     string            `json:"type"`
+	Title    string            `json:"title"`
+	Status   int               `json:"status"`
+	Detail   string            `json:"detail,omitempty"`
+	Instance string            `json:"instance,omitempty"`
+	Code     string            `json:"code"`
+	Errors   map[string]string `json:"errors,omitempty"`
+}
+
+// Handler -> ErrorHandler Fiber: semua error dari handler & middleware ditulis sebagai problem+json
+// dalam bahasa request (lihat i18n.Lang)
+func Handler(c *fiber.Ctx, err error) error {
+	e := From(err)
+	if e.Status >= http.StatusInternalServerError {
+		slog.ErrorContext(c.UserContext(), "request gagal", "method", c.Method(), "path", c.OriginalURL(), "error", err)
+	}
+
+	lang := i18n.Lang(c)
+	var fields map[string]string
+	if len(e.Fields) > 0 {
+		fields = make(map[string]string, len(e.Fields))
+		for name, msg := range e.Fields {
+			fields[name] = msg.In(lang)
+		}
+	}
+
+	return c.Status(e.Status).JSON(Problem{
+		Type:     typeBase + e.Code,
+		Title:    http.StatusText(e.Status),
+		Status:   e.Status,
+		Detail:   e.Localize(lang),
+		Instance: c.Path(),
+		Code:     e.Code,
+		Errors:   fields,
+	}, ContentType)
+}
@@ -0,0 +1,76 @@
+// Package audit berisi helper bersama untuk mencatat perubahan data (audit log).
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Aksi yang dicatat di audit log
+const (
+	ActionCreate     = "create"
+	ActionUpdate     = "update"
+	ActionDelete     = "delete"
+	ActionRestore    = "restore"
+	ActionHardDelete = "hard_delete"
+	ActionRevert     = "revert"
+	ActionLogin      = "login"
+)
+
+// Entitas yang dicatat di audit log
+const (
+	EntityAlumni    = "alumni"
+	EntityPekerjaan = "pekerjaan"
+	EntityFile      = "file"
+	EntityUser      = "user"
+)
+
+// Change -> nilai sebelum dan sesudah untuk satu field
+type Change struct {
+	Old interface{} `json:"old" bson:"old"`
+	New interface{} `json:"new" bson:"new"`
+}
+
+// Diff membandingkan representasi JSON before dan after per field (top-level).
+// before = nil -> data baru, after = nil -> data dihapus.
+func Diff(before, after interface{}) map[string]Change {
+	oldFields := toFields(before)
+	newFields := toFields(after)
+
+	diff := make(map[string]Change)
+	for k, ov := range oldFields {
+		nv, ok := newFields[k]
+		if !ok || !reflect.DeepEqual(ov, nv) {
+			diff[k] = Change{Old: ov, New: nv}
+		}
+	}
+	for k, nv := range newFields {
+		if _, ok := oldFields[k]; !ok {
+			diff[k] = Change{Old: nil, New: nv}
+		}
+	}
+	return diff
+}
+
+func toFields(v interface{}) map[string]interface{} {
+	fields := map[string]interface{}{}
+	if v == nil {
+		return fields
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return fields
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		// bukan object (misal slice ID) -> simpan utuh sebagai satu field
+		var val interface{}
+		if json.Unmarshal(raw, &val) == nil {
+			return map[string]interface{}{"value": val}
+		}
+	}
+	return fields
+}
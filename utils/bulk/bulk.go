@@ -0,0 +1,134 @@
+// Package bulk berisi bagian operasi bulk yang sama untuk PostgreSQL dan MongoDB: membaca ?mode=,
+// memvalidasi tanggal pekerjaan, dan menjalankan validasi + simpan per item lalu merangkum hasilnya.
+// Penyimpanan, ID, dan audit tetap dikerjakan service masing-masing backend lewat callback.
+package bulk
+
+import (
+	"time"
+
+	"alumniproject/utils/apperror"
+)
+
+// MaxItems -> batas jumlah item per request bulk
+const MaxItems = 500
+
+// Mode bulk
+const (
+	ModeAllOrNothing = "all_or_nothing" // satu item gagal -> semua dibatalkan
+	ModeBestEffort   = "best_effort"    // item yang valid tetap disimpan
+)
+
+// ErrCancelled -> item tidak disimpan karena item lain gagal pada mode all-or-nothing
+var ErrCancelled = apperror.Conflict("bulk.cancelled")
+
+// ParseMode memeriksa nilai ?mode= (kosong -> all_or_nothing). atomic = true untuk all_or_nothing.
+func ParseMode(mode string) (atomic bool, err error) {
+	switch mode {
+	case "", ModeAllOrNothing:
+		return true, nil
+	case ModeBestEffort:
+		return false, nil
+	}
+	return false, apperror.Validation("bulk.mode_invalid")
+}
+
+// ParseTanggalPekerjaan mengubah tanggal mulai/selesai (YYYY-MM-DD) dan memastikan urutannya benar.
+// Dipakai juga oleh create/update pekerjaan satu per satu.
+func ParseTanggalPekerjaan(mulai, selesai string) (time.Time, *time.Time, error) {
+	tMulai, err := time.Parse("2006-01-02", mulai)
+	if err != nil {
+		return time.Time{}, nil, apperror.Validation("pekerjaan.start_date_invalid")
+	}
+
+	var tSelesai *time.Time
+	if selesai != "" {
+		t, err := time.Parse("2006-01-02", selesai)
+		if err != nil {
+			return time.Time{}, nil, apperror.Validation("pekerjaan.end_date_invalid")
+		}
+		if t.Before(tMulai) {
+			return time.Time{}, nil, apperror.Validation("pekerjaan.end_before_start")
+		}
+		tSelesai = &t
+	}
+	return tMulai, tSelesai, nil
+}
+
+// Item -> hasil satu item tanpa ID; ID diisi service lewat callback saved
+type Item struct {
+	Index   int
+	Success bool
+	Error   string // pesan yang sudah diterjemahkan ke bahasa request
+	Status  int    // status HTTP error item, mis. 412 jika version tidak cocok
+	Code    string // kode error stabil, mis. version_conflict
+}
+
+// Run memvalidasi n item lewat parse, menyimpan item yang valid sekaligus lewat save, lalu memanggil
+// saved(i, j, item) untuk setiap item ke-i yang tersimpan (j = posisinya di list yang dikirim ke save).
+//
+// Mode atomic: satu item tidak valid -> save tidak dipanggil dan item lain ditandai ErrCancelled.
+// save mengembalikan error per item (sepanjang list); error kedua berarti seluruh operasi gagal.
+func Run[T any](lang string, atomic bool, n int,
+	parse func(i int) (T, error),
+	save func(list []T) ([]error, error),
+	saved func(i, j int, item T),
+) ([]Item, error) {
+	items := make([]Item, n)
+	fail := func(i int, err error) {
+		e := apperror.From(err)
+		items[i].Error = apperror.Localize(err, lang)
+		items[i].Status = e.Status
+		items[i].Code = e.Code
+	}
+
+	var list []T
+	var index []int
+	invalid := 0
+	for i := 0; i < n; i++ {
+		items[i].Index = i
+		item, err := parse(i)
+		if err != nil {
+			fail(i, err)
+			invalid++
+			continue
+		}
+		list = append(list, item)
+		index = append(index, i)
+	}
+
+	if atomic && invalid > 0 {
+		for _, i := range index {
+			fail(i, ErrCancelled)
+		}
+		return items, nil
+	}
+	if len(list) == 0 {
+		return items, nil
+	}
+
+	errs, err := save(list)
+	if err != nil {
+		return nil, err
+	}
+	for j, i := range index {
+		if errs[j] != nil {
+			fail(i, errs[j])
+			continue
+		}
+		items[i].Success = true
+		saved(i, j, list[j])
+	}
+	return items, nil
+}
+
+// Count -> jumlah item yang berhasil dan gagal
+func Count(items []Item) (success, failed int) {
+	for _, it := range items {
+		if it.Success {
+			success++
+		} else {
+			failed++
+		}
+	}
+	return success, failed
+}
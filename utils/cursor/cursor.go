@@ -0,0 +1,111 @@
+// Package cursor membuat cursor opaque untuk keyset pagination (?cursor=...&limit=).
+//
+// Cursor menyimpan kolom & arah sort serta nilai kolom sort + ID baris batas, lalu di-encode
+// base64url sehingga client cukup mengirim ulang next_cursor / prev_cursor dari meta response.
+// Halaman berikutnya diambil dengan kondisi (kolom, id) > (nilai, id) (atau < untuk desc),
+// bukan OFFSET, sehingga tetap cepat dan konsisten walau ada data baru di tengah scroll.
+package cursor
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/utils/apperror"
+)
+
+// errInvalid -> cursor rusak atau bukan hasil Encode
+var errInvalid = apperror.Validation("invalid_cursor").WithCode(apperror.CodeInvalidCursor)
+
+// Cursor -> posisi batas halaman
+type Cursor struct {
+	SortBy string `json:"s"`
+	Order  string `json:"o"`
+	Value  string `json:"v"`           // nilai kolom sort pada baris batas
+	ID     string `json:"i"`           // ID baris batas, pemecah seri jika nilai sort sama
+	Before bool   `json:"b,omitempty"` // true -> ambil halaman sebelum baris batas (prev_cursor)
+}
+
+// Encode -> bentuk opaque untuk dikirim ke client
+func (c Cursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode membaca cursor dari query. raw kosong -> nil (halaman pertama).
+func Decode(raw string) (*Cursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errInvalid
+	}
+	var c Cursor
+	if err := json.Unmarshal(b, &c); err != nil || c.SortBy == "" || c.ID == "" {
+		return nil, errInvalid
+	}
+	if c.Order != "asc" && c.Order != "desc" {
+		return nil, errInvalid
+	}
+	return &c, nil
+}
+
+// Format mengubah nilai kolom sort / ID menjadi string untuk disimpan di cursor
+func Format(v interface{}) string {
+	switch t := v.(type) {
+	case time.Time:
+		return t.UTC().Format(time.RFC3339Nano)
+	case primitive.ObjectID:
+		return t.Hex()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// Descending -> arah scan di database: kebalikan dari order jika mengambil halaman sebelumnya
+func Descending(order string, cur *Cursor) bool {
+	desc := order == "desc"
+	if cur != nil && cur.Before {
+		return !desc
+	}
+	return desc
+}
+
+// Trim memotong hasil query (diambil limit+1 baris) menjadi limit baris dan membalik urutan
+// untuk halaman sebelumnya. hasMore -> masih ada baris ke arah scan.
+func Trim[T any](rows []T, limit int, cur *Cursor) ([]T, bool) {
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+	if cur != nil && cur.Before {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+	return rows, hasMore
+}
+
+// Links menghitung next_cursor & prev_cursor dari hasil Trim. key -> nilai kolom sort dan ID satu baris.
+func Links[T any](rows []T, hasMore bool, cur *Cursor, sortBy, order string, key func(T) (value, id interface{})) (next, prev string) {
+	if len(rows) == 0 {
+		return "", ""
+	}
+	at := func(row T, before bool) string {
+		v, id := key(row)
+		return Cursor{SortBy: sortBy, Order: order, Value: Format(v), ID: Format(id), Before: before}.Encode()
+	}
+
+	before := cur != nil && cur.Before
+	if hasMore || before {
+		next = at(rows[len(rows)-1], false)
+	}
+	if cur != nil && (hasMore || !before) {
+		prev = at(rows[0], true)
+	}
+	return next, prev
+}
@@ -0,0 +1,145 @@
+package cursor
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"alumniproject/utils/apperror"
+)
+
+func TestDecodeRoundTrip(t *testing.T) {
+	c := Cursor{SortBy: "created_at", Order: "desc", Value: "2026-10-19T00:00:00Z", ID: "42", Before: true}
+	got, err := Decode(c.Encode())
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if *got != c {
+		t.Errorf("Decode(Encode()) = %+v, want %+v", *got, c)
+	}
+
+	if got, err := Decode(""); got != nil || err != nil {
+		t.Errorf("Decode(\"\") = %v, %v, want nil, nil", got, err)
+	}
+}
+
+func TestDecodeRejectsInvalid(t *testing.T) {
+	valid := Cursor{SortBy: "nama", Order: "asc", Value: "Budi", ID: "1"}.Encode()
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{"bukan base64", "!!!"},
+		{"JSON terpotong", valid[:len(valid)/2]},
+		{"diubah satu karakter", valid[:len(valid)-2] + "!" + valid[len(valid)-1:]},
+		{"bukan JSON", raw("nama,asc,1")},
+		{"tanpa sort_by", raw(`{"o":"asc","v":"x","i":"1"}`)},
+		{"tanpa id", raw(`{"s":"nama","o":"asc","v":"x"}`)},
+		{"order tidak dikenal", raw(`{"s":"nama","o":"up","v":"x","i":"1"}`)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := Decode(tt.raw)
+			if err == nil {
+				t.Fatalf("Decode(%q) = %+v, want error", tt.raw, c)
+			}
+			if e := apperror.From(err); e.Code != apperror.CodeInvalidCursor {
+				t.Errorf("code = %q, want %q", e.Code, apperror.CodeInvalidCursor)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	oid := primitive.NewObjectID()
+	wib := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{"waktu diubah ke UTC", time.Date(2026, time.October, 19, 7, 0, 0, 500, wib), "2026-10-19T00:00:00.0000005Z"},
+		{"ObjectID", oid, oid.Hex()},
+		{"int", 42, "42"},
+		{"string", "Budi", "Budi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.v); got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescending(t *testing.T) {
+	tests := []struct {
+		order string
+		cur   *Cursor
+		want  bool
+	}{
+		{"desc", nil, true},
+		{"asc", nil, false},
+		{"desc", &Cursor{}, true},
+		{"desc", &Cursor{Before: true}, false},
+		{"asc", &Cursor{Before: true}, true},
+	}
+	for _, tt := range tests {
+		if got := Descending(tt.order, tt.cur); got != tt.want {
+			t.Errorf("Descending(%q, %+v) = %v, want %v", tt.order, tt.cur, got, tt.want)
+		}
+	}
+}
+
+// TestTrimLinks memakai baris berupa ID int dengan nilai sort ID*10, urutan asc, limit 3.
+// Query mengambil limit+1 baris ke arah scan; halaman sebelumnya di-scan terbalik.
+func TestTrimLinks(t *testing.T) {
+	key := func(id int) (interface{}, interface{}) { return id * 10, id }
+	at := func(id int, before bool) string {
+		return Cursor{SortBy: "angkatan", Order: "asc", Value: Format(id * 10), ID: Format(id), Before: before}.Encode()
+	}
+
+	tests := []struct {
+		name     string
+		cur      *Cursor
+		fetched  []int
+		rows     []int
+		next     string
+		prev     string
+		wantMore bool
+	}{
+		{"halaman pertama", nil, []int{1, 2, 3, 4}, []int{1, 2, 3}, at(3, false), "", true},
+		{"satu-satunya halaman", nil, []int{1, 2}, []int{1, 2}, "", "", false},
+		{"maju di tengah", &Cursor{ID: "3"}, []int{4, 5, 6, 7}, []int{4, 5, 6}, at(6, false), at(4, true), true},
+		{"maju ke halaman terakhir", &Cursor{ID: "6"}, []int{7, 8}, []int{7, 8}, "", at(7, true), false},
+		{"mundur di tengah", &Cursor{ID: "7", Before: true}, []int{6, 5, 4, 3}, []int{4, 5, 6}, at(6, false), at(4, true), true},
+		{"mundur ke halaman pertama", &Cursor{ID: "4", Before: true}, []int{3, 2, 1}, []int{1, 2, 3}, at(3, false), "", false},
+		{"halaman kosong", &Cursor{ID: "8"}, nil, nil, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, hasMore := Trim(tt.fetched, 3, tt.cur)
+			if !reflect.DeepEqual(rows, tt.rows) || hasMore != tt.wantMore {
+				t.Fatalf("Trim = %v, %v, want %v, %v", rows, hasMore, tt.rows, tt.wantMore)
+			}
+			next, prev := Links(rows, hasMore, tt.cur, "angkatan", "asc", key)
+			if next != tt.next {
+				t.Errorf("next = %s, want %s", decoded(next), decoded(tt.next))
+			}
+			if prev != tt.prev {
+				t.Errorf("prev = %s, want %s", decoded(prev), decoded(tt.prev))
+			}
+		})
+	}
+}
+
+// decoded -> isi cursor untuk pesan test yang bisa dibaca
+func decoded(raw string) string {
+	b, _ := base64.RawURLEncoding.DecodeString(raw)
+	return string(b)
+}
@@ -0,0 +1,37 @@
+// Package deadline memberi setiap request batas waktu lewat c.UserContext(). Service & repository
+// meneruskan context tersebut ke query database, jadi query yang melewati batas (atau request yang
+// dihentikan paksa saat shutdown) ikut dibatalkan di database.
+package deadline
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// New -> middleware yang memasang deadline timeout pada c.UserContext(). Context juga dibatalkan
+// saat base dibatalkan, mis. ketika batas waktu shutdown habis dan request yang tersisa harus berhenti.
+//
+// fasthttp tidak memberi tahu saat client memutus koneksi, jadi pembatalan hanya datang dari deadline
+// dan base. timeout <= 0 berarti tanpa deadline.
+func New(base context.Context, timeout time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var (
+			ctx    context.Context
+			cancel context.CancelFunc
+		)
+		if timeout > 0 {
+			ctx, cancel = context.WithTimeout(c.UserContext(), timeout)
+		} else {
+			ctx, cancel = context.WithCancel(c.UserContext())
+		}
+		defer cancel()
+
+		stop := context.AfterFunc(base, cancel)
+		defer stop()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
@@ -0,0 +1,39 @@
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"alumniproject/utils/apperror"
+)
+
+// Format mengubah nomor versi menjadi nilai header ETag, contoh: "3"
+func Format(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseIfMatch membaca header If-Match dan mengembalikan versi yang diharapkan.
+// Header kosong atau "*" -> 0 (tanpa syarat versi). Prefix weak (W/) diabaikan.
+// Jika header berisi beberapa ETag, hanya satu yang boleh dipakai.
+func ParseIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	if strings.Contains(header, ",") {
+		return 0, apperror.Validation("request.if_match_multiple")
+	}
+
+	value := strings.TrimPrefix(header, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		unquoted = value
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, apperror.Validation("request.if_match_invalid")
+	}
+	return version, nil
+}
@@ -0,0 +1,165 @@
+// Package export menulis data tabular (alumni, pekerjaan) ke CSV, XLSX, atau NDJSON
+// baris per baris, sehingga data bisa di-stream langsung dari cursor database.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format export yang didukung
+const (
+	FormatCSV    = "csv"
+	FormatXLSX   = "xlsx"
+	FormatNDJSON = "ndjson"
+)
+
+// Writer menulis baris data setelah header (ditulis oleh NewWriter).
+// Close wajib dipanggil untuk menyelesaikan file.
+type Writer interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
+
+// NewWriter membuat writer sesuai format. columns dipakai sebagai header (CSV/XLSX) atau key (NDJSON).
+func NewWriter(format string, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(columns); err != nil {
+			return nil, err
+		}
+		return &csvWriter{w: cw}, nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	case FormatNDJSON:
+		return &ndjsonWriter{enc: json.NewEncoder(w), columns: columns}, nil
+	default:
+		return nil, fmt.Errorf("format export harus csv, xlsx, atau ndjson")
+	}
+}
+
+// Valid -> format dikenali
+func Valid(format string) bool {
+	return format == FormatCSV || format == FormatXLSX || format == FormatNDJSON
+}
+
+// ContentType -> header Content-Type untuk format export
+func ContentType(format string) string {
+	switch format {
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// FileName -> nama file unduhan, mis. alumni-20250101.csv
+func FileName(name, format string, now time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", name, now.Format("20060102"), format)
+}
+
+// cellValue menyeragamkan nilai untuk CSV/XLSX: waktu jadi RFC3339, pointer nil jadi kosong
+func cellValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case time.Time:
+		return t.Format(time.RFC3339)
+	case *time.Time:
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	default:
+		return v
+	}
+}
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c *csvWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = fmt.Sprint(cellValue(v))
+	}
+	return c.w.Write(record)
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	enc     *json.Encoder
+	columns []string
+}
+
+func (n *ndjsonWriter) WriteRow(values []interface{}) error {
+	obj := make(map[string]interface{}, len(values))
+	for i, v := range values {
+		if i < len(n.columns) {
+			obj[n.columns[i]] = v
+		}
+	}
+	return n.enc.Encode(obj)
+}
+
+func (n *ndjsonWriter) Close() error { return nil }
+
+// xlsxWriter memakai StreamWriter excelize, baris yang sudah ditulis tidak disimpan di memori
+type xlsxWriter struct {
+	out  io.Writer
+	file *excelize.File
+	sw   *excelize.StreamWriter
+	row  int
+}
+
+func newXLSXWriter(w io.Writer, columns []string) (*xlsxWriter, error) {
+	f := excelize.NewFile()
+	sw, err := f.NewStreamWriter("Sheet1")
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	x := &xlsxWriter{out: w, file: f, sw: sw}
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col
+	}
+	if err := x.WriteRow(header); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return x, nil
+}
+
+func (x *xlsxWriter) WriteRow(values []interface{}) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, v := range values {
+		row[i] = cellValue(v)
+	}
+	return x.sw.SetRow(cell, row)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+	if err := x.sw.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
@@ -0,0 +1,250 @@
+// Package filter membaca parameter ?filter= pada endpoint list, mis.
+//
+//	?filter=angkatan:gte:2018,jurusan:in:TI|SI,tahun_lulus:eq:2022
+//
+// Setiap kondisi berformat field:operator:nilai dan digabung dengan AND. Field divalidasi
+// terhadap whitelist per entity (Schema), lalu dikompilasi menjadi SQL berparameter atau
+// dokumen filter MongoDB. Nama kolom hanya berasal dari Schema, nilai selalu dikirim sebagai parameter.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"alumniproject/utils/apperror"
+)
+
+// Operator yang didukung
+const (
+	OpEq   = "eq"
+	OpNe   = "ne"
+	OpGt   = "gt"
+	OpGte  = "gte"
+	OpLt   = "lt"
+	OpLte  = "lte"
+	OpIn   = "in"   // nilai dipisah "|"
+	OpLike = "like" // mengandung teks, tidak peka huruf besar/kecil
+)
+
+// MaxConditions -> batas jumlah kondisi dalam satu ?filter=
+const MaxConditions = 20
+
+// Kind -> tipe nilai field
+type Kind int
+
+const (
+	String Kind = iota
+	Int
+	Time // YYYY-MM-DD atau RFC3339
+)
+
+// Field -> field yang boleh difilter
+type Field struct {
+	Kind   Kind
+	Column string // kolom SQL, mis. "a.angkatan"
+	Key    string // field MongoDB
+}
+
+// Schema -> whitelist field per entity: nama di query -> Field
+type Schema map[string]Field
+
+// Condition -> satu kondisi yang sudah divalidasi
+type Condition struct {
+	Name   string
+	Field  Field
+	Op     string
+	Values []interface{}
+}
+
+// Filter -> daftar kondisi, digabung dengan AND
+type Filter []Condition
+
+var opsByKind = map[Kind][]string{
+	String: {OpEq, OpNe, OpIn, OpLike},
+	Int:    {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn},
+	Time:   {OpEq, OpNe, OpGt, OpGte, OpLt, OpLte},
+}
+
+// Parse membaca ?filter= dan memvalidasi field, operator, dan nilai terhadap schema.
+// raw kosong -> Filter kosong.
+func Parse(raw string, schema Schema) (Filter, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > MaxConditions {
+		return nil, invalid("filter.max_conditions", MaxConditions)
+	}
+
+	f := make(Filter, 0, len(parts))
+	for _, part := range parts {
+		pieces := strings.SplitN(strings.TrimSpace(part), ":", 3)
+		if len(pieces) != 3 {
+			return nil, invalid("filter.format", part)
+		}
+		name, op, value := pieces[0], strings.ToLower(pieces[1]), pieces[2]
+
+		field, ok := schema[name]
+		if !ok {
+			return nil, invalid("filter.field", name, strings.Join(schema.Names(), ", "))
+		}
+		if !allowed(field.Kind, op) {
+			return nil, invalid("filter.operator", op, name, strings.Join(opsByKind[field.Kind], ", "))
+		}
+
+		raws := []string{value}
+		if op == OpIn {
+			raws = strings.Split(value, "|")
+		}
+		cond := Condition{Name: name, Field: field, Op: op}
+		for _, v := range raws {
+			parsed, ok := parseValue(field.Kind, v)
+			if !ok {
+				if field.Kind == String {
+					return nil, invalid("filter.value_empty", name)
+				}
+				return nil, invalid(valueKeys[field.Kind], v, name)
+			}
+			cond.Values = append(cond.Values, parsed)
+		}
+		f = append(f, cond)
+	}
+	return f, nil
+}
+
+// Names -> nama field di schema, terurut
+func (s Schema) Names() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func allowed(kind Kind, op string) bool {
+	for _, o := range opsByKind[kind] {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// invalid -> error validasi ?filter= dengan pesan dari katalog i18n
+func invalid(key string, args ...interface{}) error {
+	return apperror.Validation(key).WithArgs(args...).WithCode(apperror.CodeInvalidFilter)
+}
+
+// valueKeys -> pesan jika nilai tidak sesuai tipe field
+var valueKeys = map[Kind]string{Int: "filter.value_int", Time: "filter.value_time"}
+
+func parseValue(kind Kind, v string) (interface{}, bool) {
+	switch kind {
+	case Int:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	case Time:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+		t, err := time.Parse("2006-01-02", v)
+		return t, err == nil
+	default:
+		return v, v != ""
+	}
+}
+
+var sqlOps = map[string]string{OpEq: "=", OpNe: "<>", OpGt: ">", OpGte: ">=", OpLt: "<", OpLte: "<="}
+
+// SQL mengompilasi filter menjadi kondisi WHERE (tanpa kata WHERE) dengan placeholder mulai dari $argStart.
+// Filter kosong -> "TRUE".
+func (f Filter) SQL(argStart int) (string, []interface{}) {
+	if len(f) == 0 {
+		return "TRUE", nil
+	}
+
+	var clauses []string
+	var args []interface{}
+	next := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(argStart+len(args)-1)
+	}
+
+	for _, c := range f {
+		col := c.Field.Column
+		switch c.Op {
+		case OpIn:
+			ph := make([]string, len(c.Values))
+			for i, v := range c.Values {
+				ph[i] = next(v)
+			}
+			clauses = append(clauses, fmt.Sprintf("%s IN (%s)", col, strings.Join(ph, ", ")))
+		case OpLike:
+			clauses = append(clauses, fmt.Sprintf("%s ILIKE %s", col, next("%"+escapeLike(c.Values[0].(string))+"%")))
+		default:
+			clauses = append(clauses, fmt.Sprintf("%s %s %s", col, sqlOps[c.Op], next(c.Values[0])))
+		}
+	}
+	return strings.Join(clauses, " AND "), args
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+var mongoOps = map[string]string{OpNe: "$ne", OpGt: "$gt", OpGte: "$gte", OpLt: "$lt", OpLte: "$lte"}
+
+// Mongo mengompilasi filter menjadi daftar kondisi MongoDB (digabung dengan $and oleh Apply)
+func (f Filter) Mongo() []bson.M {
+	conds := make([]bson.M, 0, len(f))
+	for _, c := range f {
+		key := c.Field.Key
+		switch c.Op {
+		case OpEq:
+			conds = append(conds, bson.M{key: c.Values[0]})
+		case OpIn:
+			conds = append(conds, bson.M{key: bson.M{"$in": c.Values}})
+		case OpLike:
+			conds = append(conds, bson.M{key: bson.M{"$regex": regexp.QuoteMeta(c.Values[0].(string)), "$options": "i"}})
+		default:
+			conds = append(conds, bson.M{key: bson.M{mongoOps[c.Op]: c.Values[0]}})
+		}
+	}
+	return conds
+}
+
+// Apply menambahkan kondisi filter ke dokumen filter MongoDB yang sudah ada lewat $and
+func (f Filter) Apply(doc bson.M) bson.M {
+	if len(f) == 0 {
+		return doc
+	}
+	and, _ := doc["$and"].([]bson.M)
+	doc["$and"] = append(and, f.Mongo()...)
+	return doc
+}
+
+// String -> bentuk kanonik filter untuk ditampilkan di meta response
+func (f Filter) String() string {
+	parts := make([]string, len(f))
+	for i, c := range f {
+		values := make([]string, len(c.Values))
+		for j, v := range c.Values {
+			if t, ok := v.(time.Time); ok {
+				values[j] = t.Format(time.RFC3339)
+			} else {
+				values[j] = fmt.Sprint(v)
+			}
+		}
+		parts[i] = c.Name + ":" + c.Op + ":" + strings.Join(values, "|")
+	}
+	return strings.Join(parts, ",")
+}
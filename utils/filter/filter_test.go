@@ -0,0 +1,140 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"alumniproject/utils/apperror"
+)
+
+var testSchema = Schema{
+	"nama":     {Kind: String, Column: "a.nama", Key: "nama"},
+	"angkatan": {Kind: Int, Column: "a.angkatan", Key: "angkatan"},
+	"lulus":    {Kind: Time, Column: "a.tanggal_lulus", Key: "tanggal_lulus"},
+}
+
+func TestParseRejectsInvalidFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		key  string
+	}{
+		{"field di luar whitelist", "password:eq:x", "filter.field"},
+		{"kolom SQL langsung", "a.angkatan:eq:2018", "filter.field"},
+		{"format tanpa nilai", "angkatan:eq", "filter.format"},
+		{"operator tidak cocok tipe", "nama:gte:a", "filter.operator"},
+		{"like pada int", "angkatan:like:20", "filter.operator"},
+		{"nilai int tidak valid", "angkatan:eq:dua", "filter.value_int"},
+		{"salah satu nilai in tidak valid", "angkatan:in:2018|x", "filter.value_int"},
+		{"nilai tanggal tidak valid", "lulus:gte:2022-13-01", "filter.value_time"},
+		{"nilai string kosong", "nama:eq:", "filter.value_empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.raw, testSchema)
+			if err == nil {
+				t.Fatalf("Parse(%q) tidak mengembalikan error", tt.raw)
+			}
+			e := apperror.From(err)
+			if e.Detail != tt.key || e.Code != apperror.CodeInvalidFilter {
+				t.Errorf("Parse(%q) error key = %q code = %q, want %q %q", tt.raw, e.Detail, e.Code, tt.key, apperror.CodeInvalidFilter)
+			}
+		})
+	}
+}
+
+func TestParseMaxConditions(t *testing.T) {
+	raw := "angkatan:gte:2000"
+	for i := 0; i < MaxConditions; i++ {
+		raw += ",angkatan:gte:2000"
+	}
+	if _, err := Parse(raw, testSchema); err == nil {
+		t.Errorf("Parse dengan %d kondisi tidak ditolak", MaxConditions+1)
+	}
+}
+
+func TestSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		argStart int
+		where    string
+		args     []interface{}
+	}{
+		{"kosong", "", 1, "TRUE", nil},
+		{"eq", "angkatan:eq:2018", 1, "a.angkatan = $1", []interface{}{2018}},
+		{
+			"in dipisah pipa", "nama:in:TI|SI|MI", 1,
+			"a.nama IN ($1, $2, $3)", []interface{}{"TI", "SI", "MI"},
+		},
+		{
+			"like di-escape", `nama:like:50%_a\b`, 1,
+			"a.nama ILIKE $1", []interface{}{`%50\%\_a\\b%`},
+		},
+		{
+			"placeholder mulai dari offset", "angkatan:gte:2018,nama:in:TI|SI,nama:ne:X", 4,
+			"a.angkatan >= $4 AND a.nama IN ($5, $6) AND a.nama <> $7", []interface{}{2018, "TI", "SI", "X"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse(tt.raw, testSchema)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.raw, err)
+			}
+			where, args := f.SQL(tt.argStart)
+			if where != tt.where {
+				t.Errorf("where = %q, want %q", where, tt.where)
+			}
+			if !reflect.DeepEqual(args, tt.args) {
+				t.Errorf("args = %#v, want %#v", args, tt.args)
+			}
+		})
+	}
+}
+
+func TestMongo(t *testing.T) {
+	lulus := time.Date(2022, time.August, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		raw  string
+		want []bson.M
+	}{
+		{"eq", "angkatan:eq:2018", []bson.M{{"angkatan": 2018}}},
+		{"ne", "nama:ne:Budi", []bson.M{{"nama": bson.M{"$ne": "Budi"}}}},
+		{"in", "angkatan:in:2018|2019", []bson.M{{"angkatan": bson.M{"$in": []interface{}{2018, 2019}}}}},
+		{"like di-quote", "nama:like:a.b*", []bson.M{{"nama": bson.M{"$regex": `a\.b\*`, "$options": "i"}}}},
+		{"tanggal", "lulus:gte:2022-08-01", []bson.M{{"tanggal_lulus": bson.M{"$gte": lulus}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := Parse(tt.raw, testSchema)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.raw, err)
+			}
+			if got := f.Mongo(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Mongo() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	f, err := Parse("angkatan:gte:2018", testSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := bson.M{"deleted_at": nil, "$and": []bson.M{{"created_by": 1}}}
+	want := bson.M{"deleted_at": nil, "$and": []bson.M{{"created_by": 1}, {"angkatan": bson.M{"$gte": 2018}}}}
+	if got := f.Apply(doc); !reflect.DeepEqual(got, want) {
+		t.Errorf("Apply() = %#v, want %#v", got, want)
+	}
+
+	empty := bson.M{"deleted_at": nil}
+	if got := Filter(nil).Apply(empty); !reflect.DeepEqual(got, bson.M{"deleted_at": nil}) {
+		t.Errorf("Apply filter kosong mengubah dokumen: %#v", got)
+	}
+}
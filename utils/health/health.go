@@ -0,0 +1,53 @@
+// Package health menyediakan endpoint probe Kubernetes: /healthz (liveness) hanya memastikan
+// proses masih melayani request, /readyz (readiness) juga memastikan database bisa dijangkau.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Path endpoint probe
+const (
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+)
+
+// checkTimeout -> batas waktu setiap pengecekan readiness
+const checkTimeout = 2 * time.Second
+
+// Check -> satu dependensi yang harus siap sebelum menerima traffic, mis. database
+type Check struct {
+	Name string
+	Ping func(context.Context) error
+}
+
+// Register memasang /healthz dan /readyz. Keduanya tidak butuh autentikasi.
+func Register(app fiber.Router, checks ...Check) {
+	app.Get(LivenessPath, func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "ok"})
+	})
+
+	app.Get(ReadinessPath, func(c *fiber.Ctx) error {
+		status, results := "ready", make(map[string]string, len(checks))
+		for _, chk := range checks {
+			ctx, cancel := context.WithTimeout(c.UserContext(), checkTimeout)
+			err := chk.Ping(ctx)
+			cancel()
+			if err != nil {
+				status = "unavailable"
+				results[chk.Name] = err.Error()
+				continue
+			}
+			results[chk.Name] = "ok"
+		}
+
+		code := fiber.StatusOK
+		if status != "ready" {
+			code = fiber.StatusServiceUnavailable
+		}
+		return c.Status(code).JSON(fiber.Map{"status": status, "checks": results})
+	})
+}
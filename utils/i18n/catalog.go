@@ -0,0 +1,197 @@
+package i18n
+
+// catalog -> kunci pesan -> teks per bahasa. Kunci tanpa titik sama dengan kode error apperror
+// dan dipakai sebagai pesan umum jika error tidak membawa detail.
+var catalog = map[string]map[string]string{
+	// Pesan umum per kode error
+	"validation_error":       {ID: "Data tidak valid", EN: "Invalid data"},
+	"unauthorized":           {ID: "Autentikasi diperlukan", EN: "Authentication required"},
+	"forbidden":              {ID: "Akses ditolak", EN: "Access denied"},
+	"not_found":              {ID: "Data tidak ditemukan", EN: "Data not found"},
+	"conflict":               {ID: "Permintaan bentrok dengan keadaan data saat ini", EN: "Request conflicts with the current state of the data"},
+	"gone":                   {ID: "Data sudah tidak tersedia", EN: "Data is no longer available"},
+	"precondition_failed":    {ID: "Versi data tidak cocok", EN: "Data version does not match"},
+	"unsupported_media_type": {ID: "Content-Type tidak didukung", EN: "Unsupported Content-Type"},
+	"service_unavailable":    {ID: "Layanan sedang tidak tersedia", EN: "Service unavailable"},
+	"internal_error":         {ID: "Terjadi kesalahan pada server", EN: "Internal server error"},
+	"request_timeout":        {ID: "Permintaan melebihi batas waktu", EN: "Request timed out"},
+	"version_conflict":       {ID: "Data sudah diubah oleh user lain, silakan ambil ulang data terbaru", EN: "Data was modified by another user, please fetch the latest version"},
+	"invalid_id":             {ID: "ID tidak valid", EN: "Invalid ID"},
+	"invalid_cursor":         {ID: "Cursor tidak valid", EN: "Invalid cursor"},
+
+	"welcome": {ID: "Selamat datang di API Alumni dan Pekerjaan", EN: "Welcome to the Alumni and Employment API"},
+
+	// Autentikasi (middleware & login)
+	"auth.token_required":      {ID: "Token akses diperlukan", EN: "Access token is required"},
+	"auth.token_format":        {ID: "Format token tidak valid", EN: "Invalid token format"},
+	"auth.token_invalid":       {ID: "Token tidak valid atau sudah expired", EN: "Token is invalid or has expired"},
+	"auth.user_id_invalid":     {ID: "User ID tidak valid", EN: "Invalid user ID"},
+	"auth.user_id_missing":     {ID: "User ID tidak ditemukan", EN: "User ID not found"},
+	"auth.role_missing":        {ID: "Role tidak ditemukan", EN: "Role not found"},
+	"auth.admin_only":          {ID: "Akses ditolak. Hanya admin yang diizinkan", EN: "Access denied. Admins only"},
+	"auth.invalid_credentials": {ID: "Username atau password salah", EN: "Invalid username or password"},
+	"auth.token_failed":        {ID: "Gagal generate token", EN: "Failed to generate token"},
+	"auth.login_success":       {ID: "Login berhasil", EN: "Login successful"},
+
+	// Request umum
+	"request.invalid_body":      {ID: "Input tidak valid", EN: "Invalid input"},
+	"request.invalid_id_value":  {ID: "ID tidak valid: %s", EN: "Invalid ID: %s"},
+	"request.invalid_params":    {ID: "params tidak valid", EN: "Invalid params"},
+	"request.content_type":      {ID: "Content-Type harus %s", EN: "Content-Type must be %s"},
+	"request.datetime_format":   {ID: "%s harus berformat YYYY-MM-DD atau RFC3339", EN: "%s must be in YYYY-MM-DD or RFC3339 format"},
+	"request.if_match_multiple": {ID: "If-Match hanya boleh berisi satu ETag", EN: "If-Match must contain a single ETag"},
+	"request.if_match_invalid":  {ID: "If-Match tidak valid", EN: "Invalid If-Match"},
+	"patch.invalid_json":        {ID: "Patch bukan JSON yang valid", EN: "Patch is not valid JSON"},
+	"patch.not_object":          {ID: "Patch harus berupa objek JSON", EN: "Patch must be a JSON object"},
+	"patch.invalid":             {ID: "Patch tidak valid: %s", EN: "Invalid patch: %s"},
+
+	// Validasi field (utils/validate)
+	"validation.required": {ID: "wajib diisi", EN: "is required"},
+	"validation.email":    {ID: "format email tidak valid", EN: "must be a valid email address"},
+	"validation.nim":      {ID: "harus 5-20 karakter huruf, angka, atau titik", EN: "must be 5-20 letters, digits, or dots"},
+	"validation.year":     {ID: "harus antara %d dan %d", EN: "must be between %d and %d"},
+	"validation.date":     {ID: "format tanggal harus YYYY-MM-DD", EN: "must be a date in YYYY-MM-DD format"},
+	"validation.min":      {ID: "minimal %d", EN: "must be at least %d"},
+	"validation.oneof":    {ID: "harus salah satu dari: %s", EN: "must be one of: %s"},
+	"validation.gtefield": {ID: "tidak boleh sebelum %s", EN: "must not be before %s"},
+
+	// Filter (?filter=)
+	"filter.max_conditions": {ID: "Filter maksimal %d kondisi", EN: "Filter allows at most %d conditions"},
+	"filter.format":         {ID: "Filter %q harus berformat field:operator:nilai", EN: "Filter %q must have the form field:operator:value"},
+	"filter.field":          {ID: "Field %q tidak bisa difilter (pilihan: %s)", EN: "Field %q cannot be filtered (options: %s)"},
+	"filter.operator":       {ID: "Operator %q tidak berlaku untuk field %q (pilihan: %s)", EN: "Operator %q does not apply to field %q (options: %s)"},
+	"filter.value_int":      {ID: "Nilai %q untuk field %q harus berupa angka", EN: "Value %q for field %q must be a number"},
+	"filter.value_time":     {ID: "Nilai %q untuk field %q harus berformat YYYY-MM-DD atau RFC3339", EN: "Value %q for field %q must be in YYYY-MM-DD or RFC3339 format"},
+	"filter.value_empty":    {ID: "Nilai untuk field %q tidak boleh kosong", EN: "Value for field %q must not be empty"},
+
+	// Data umum
+	"data.not_found":        {ID: "Data tidak ditemukan", EN: "Data not found"},
+	"data.fetch_failed":     {ID: "Gagal mengambil data", EN: "Failed to fetch data"},
+	"data.save_failed":      {ID: "Gagal menyimpan data", EN: "Failed to save data"},
+	"data.update_failed":    {ID: "Gagal memperbarui data", EN: "Failed to update data"},
+	"data.forbidden_access": {ID: "Tidak boleh mengakses data milik orang lain", EN: "You may not access data owned by another user"},
+	"data.forbidden_delete": {ID: "Tidak boleh menghapus data milik orang lain", EN: "You may not delete data owned by another user"},
+	"data.restored":         {ID: "Data berhasil direstore", EN: "Data restored successfully"},
+	"data.hard_deleted":     {ID: "Data dengan ID %v dihapus permanen", EN: "Data with ID %v permanently deleted"},
+
+	// Alumni
+	"alumni.not_found":               {ID: "Data alumni tidak ditemukan", EN: "Alumni not found"},
+	"alumni.not_found_or_not_owned":  {ID: "Data alumni tidak ditemukan atau bukan milik user ini", EN: "Alumni not found or not owned by this user"},
+	"alumni.not_in_trash":            {ID: "Data alumni tidak ada di trash atau bukan milik user ini", EN: "Alumni is not in the trash or not owned by this user"},
+	"alumni.id_invalid":              {ID: "Alumni ID tidak valid", EN: "Invalid alumni ID"},
+	"alumni.forbidden_update":        {ID: "Tidak boleh ubah data ini", EN: "You may not modify this data"},
+	"alumni.forbidden_delete":        {ID: "Tidak boleh hapus data ini", EN: "You may not delete this data"},
+	"alumni.forbidden_history":       {ID: "Tidak boleh melihat riwayat data ini", EN: "You may not view the history of this data"},
+	"alumni.fetch_failed":            {ID: "Gagal ambil data alumni", EN: "Failed to fetch alumni"},
+	"alumni.count_failed":            {ID: "Gagal hitung data alumni", EN: "Failed to count alumni"},
+	"alumni.search_failed":           {ID: "Gagal mencari data alumni", EN: "Failed to search alumni"},
+	"alumni.update_failed":           {ID: "Gagal memperbarui data alumni", EN: "Failed to update alumni"},
+	"alumni.restore_failed":          {ID: "Gagal mengembalikan data alumni", EN: "Failed to restore alumni"},
+	"alumni.deleted_restore_first":   {ID: "Data alumni sudah dihapus, restore terlebih dahulu", EN: "Alumni has been deleted, restore it first"},
+	"alumni.same_version":            {ID: "Data alumni sudah berada di versi tersebut", EN: "Alumni is already at that version"},
+	"alumni.version_not_found":       {ID: "Versi alumni tidak ditemukan", EN: "Alumni version not found"},
+	"alumni.not_yet_exist":           {ID: "Data alumni belum ada pada waktu tersebut", EN: "Alumni did not exist at that time"},
+	"alumni.history_unavailable":     {ID: "Riwayat alumni pada waktu tersebut tidak tersedia", EN: "Alumni history at that time is not available"},
+	"alumni.empty":                   {ID: "Belum ada data alumni", EN: "No alumni yet"},
+	"alumni.created":                 {ID: "Data alumni berhasil ditambahkan", EN: "Alumni created successfully"},
+	"alumni.updated":                 {ID: "Data alumni berhasil diperbarui", EN: "Alumni updated successfully"},
+	"alumni.deleted":                 {ID: "Data alumni berhasil dihapus", EN: "Alumni deleted successfully"},
+	"alumni.restored":                {ID: "Data alumni berhasil dikembalikan", EN: "Alumni restored successfully"},
+	"alumni.reverted":                {ID: "Data alumni berhasil dikembalikan ke versi %d", EN: "Alumni reverted to version %d"},
+	"alumni.with_pekerjaan_fetched":  {ID: "Data alumni beserta pekerjaan berhasil diambil", EN: "Alumni and their employment fetched successfully"},
+	"alumni.by_status_failed":        {ID: "Gagal mengambil data alumni berdasarkan status pekerjaan", EN: "Failed to fetch alumni by employment status"},
+	"alumni.by_status_empty":         {ID: "Tidak ada data alumni dengan status pekerjaan %s", EN: "No alumni with employment status %s"},
+	"alumni.by_status_fetched":       {ID: "Data alumni dengan status pekerjaan %s berhasil diambil", EN: "Alumni with employment status %s fetched successfully"},
+	"alumni.long_employment_failed":  {ID: "Gagal mengambil data alumni dengan pekerjaan lebih dari 1 tahun", EN: "Failed to fetch alumni employed for more than 1 year"},
+	"alumni.long_employment_empty":   {ID: "Tidak ada data alumni dengan pekerjaan aktif lebih dari 1 tahun", EN: "No alumni with an active job for more than 1 year"},
+	"alumni.long_employment_fetched": {ID: "Data alumni dengan pekerjaan aktif lebih dari 1 tahun berhasil diambil", EN: "Alumni with an active job for more than 1 year fetched successfully"},
+
+	// Pekerjaan
+	"pekerjaan.not_found":              {ID: "Pekerjaan tidak ditemukan", EN: "Employment record not found"},
+	"pekerjaan.not_found_or_not_owned": {ID: "Data pekerjaan tidak ditemukan atau bukan milik user ini", EN: "Employment record not found or not owned by this user"},
+	"pekerjaan.forbidden_update":       {ID: "Kamu tidak punya akses untuk update data ini", EN: "You may not update this data"},
+	"pekerjaan.forbidden_delete":       {ID: "Kamu tidak punya akses untuk menghapus data ini", EN: "You may not delete this data"},
+	"pekerjaan.delete_failed":          {ID: "Gagal menghapus pekerjaan", EN: "Failed to delete employment record"},
+	"pekerjaan.fetch_failed":           {ID: "Gagal mengambil data pekerjaan", EN: "Failed to fetch employment records"},
+	"pekerjaan.search_failed":          {ID: "Gagal mencari data pekerjaan", EN: "Failed to search employment records"},
+	"pekerjaan.create_failed":          {ID: "Gagal membuat pekerjaan", EN: "Failed to create employment record"},
+	"pekerjaan.fetched":                {ID: "Data pekerjaan berhasil diambil", EN: "Employment records fetched successfully"},
+	"pekerjaan.created":                {ID: "Pekerjaan berhasil ditambahkan", EN: "Employment record created successfully"},
+	"pekerjaan.updated":                {ID: "Data pekerjaan berhasil diperbarui", EN: "Employment record updated successfully"},
+	"pekerjaan.deleted":                {ID: "Riwayat pekerjaan berhasil dihapus (soft delete)", EN: "Employment record moved to trash"},
+	"pekerjaan.start_date_invalid":     {ID: "Tanggal mulai tidak valid", EN: "Invalid start date"},
+	"pekerjaan.end_date_invalid":       {ID: "Tanggal selesai tidak valid", EN: "Invalid end date"},
+	"pekerjaan.end_before_start":       {ID: "Tanggal selesai tidak boleh sebelum tanggal mulai", EN: "End date must not be before start date"},
+
+	// Bulk pekerjaan
+	"bulk.array_required": {ID: "Input harus berupa array pekerjaan", EN: "Input must be an array of employment records"},
+	"bulk.item_count":     {ID: "Jumlah item harus antara 1 dan %d", EN: "Number of items must be between 1 and %d"},
+	"bulk.mode_invalid":   {ID: "Mode harus all_or_nothing atau best_effort", EN: "Mode must be all_or_nothing or best_effort"},
+	"bulk.save_failed":    {ID: "Gagal menyimpan data bulk", EN: "Failed to save bulk data"},
+	"bulk.cancelled":      {ID: "Dibatalkan karena ada item lain yang gagal", EN: "Cancelled because another item failed"},
+
+	// Trash
+	"trash.purge_admin_only":   {ID: "Hanya admin yang dapat purge trash semua user; gunakan /pekerjaan/trash/purge untuk data milik sendiri", EN: "Only admins can purge every user's trash; use /pekerjaan/trash/purge for your own data"},
+	"trash.fetch_failed":       {ID: "Gagal mengambil data trash", EN: "Failed to fetch trash"},
+	"trash.process_failed":     {ID: "Gagal memproses data trash", EN: "Failed to process trash"},
+	"trash.retention_negative": {ID: "retention_days tidak boleh negatif", EN: "retention_days must not be negative"},
+	"trash.max_ids":            {ID: "Maksimal %d ID per request", EN: "At most %d IDs per request"},
+	"trash.criteria_required":  {ID: "Isi ids, alumni_id, deleted_by, deleted_before, atau all=true", EN: "Provide ids, alumni_id, deleted_by, deleted_before, or all=true"},
+
+	// Job & export
+	"job.queued":            {ID: "Job diantrikan", EN: "Job queued"},
+	"job.not_found":         {ID: "Job tidak ditemukan", EN: "Job not found"},
+	"job.fetch_failed":      {ID: "Gagal mengambil job", EN: "Failed to fetch job"},
+	"job.create_failed":     {ID: "Gagal membuat job", EN: "Failed to create job"},
+	"job.queue_inactive":    {ID: "Antrian job belum aktif", EN: "Job queue is not running"},
+	"job.result_gone":       {ID: "File hasil job sudah tidak tersedia", EN: "Job result file is no longer available"},
+	"job.not_finished":      {ID: "Job belum selesai atau tidak memiliki hasil (status: %s)", EN: "Job is not finished or has no result (status: %s)"},
+	"job.type_invalid":      {ID: "type harus export_alumni, export_pekerjaan, purge_trash, atau tracer_report", EN: "type must be export_alumni, export_pekerjaan, purge_trash, or tracer_report"},
+	"export.format_invalid": {ID: "format harus csv, xlsx, atau ndjson", EN: "format must be csv, xlsx, or ndjson"},
+
+	// Import alumni
+	"import.file_required":   {ID: "File CSV / XLSX wajib diupload", EN: "A CSV / XLSX file is required"},
+	"import.open_failed":     {ID: "Gagal membuka file", EN: "Failed to open file"},
+	"import.mode_invalid":    {ID: "mode harus insert atau upsert", EN: "mode must be insert or upsert"},
+	"import.mapping_invalid": {ID: `mapping harus berupa JSON {"header": "field"}`, EN: `mapping must be JSON {"header": "field"}`},
+
+	// Laporan tracer study
+	"report.admin_only":       {ID: "Hanya admin yang dapat membuat laporan tracer study", EN: "Only admins can generate tracer study reports"},
+	"report.fetch_failed":     {ID: "Gagal mengambil data laporan", EN: "Failed to fetch report data"},
+	"report.pdf_failed":       {ID: "Gagal membuat laporan PDF", EN: "Failed to generate PDF report"},
+	"report.angkatan_invalid": {ID: "angkatan harus berupa tahun", EN: "angkatan must be a year"},
+
+	// Pencarian
+	"search.query_too_short": {ID: "q minimal %d karakter", EN: "q must be at least %d characters"},
+	"search.type_invalid":    {ID: "type harus alumni atau pekerjaan", EN: "type must be alumni or pekerjaan"},
+	"search.unavailable":     {ID: "Pencarian belum tersedia, skema pencarian database belum terpasang", EN: "Search is unavailable, the database search schema is not installed"},
+
+	// Audit log & user
+	"audit.fetch_failed":  {ID: "Gagal ambil audit log", EN: "Failed to fetch audit log"},
+	"audit.count_failed":  {ID: "Gagal hitung audit log", EN: "Failed to count audit log"},
+	"audit.actor_invalid": {ID: "actor_id tidak valid", EN: "Invalid actor_id"},
+	"user.fetch_failed":   {ID: "Gagal ambil data user", EN: "Failed to fetch users"},
+	"user.count_failed":   {ID: "Gagal hitung data user", EN: "Failed to count users"},
+
+	// Upload file (foto & sertifikat)
+	"file.required":            {ID: "File wajib diupload", EN: "No file uploaded"},
+	"file.too_large":           {ID: "Ukuran file maksimal %s", EN: "Max file size %s"},
+	"file.image_only":          {ID: "Hanya file jpeg/jpg/png yang diizinkan", EN: "Only jpeg/jpg/png allowed"},
+	"file.pdf_only":            {ID: "Hanya file PDF yang diizinkan", EN: "Only PDF allowed"},
+	"file.save_failed":         {ID: "Gagal menyimpan file", EN: "Failed to save file"},
+	"file.metadata_failed":     {ID: "Gagal menyimpan metadata file", EN: "Failed to save metadata"},
+	"foto.not_found":           {ID: "Foto tidak ditemukan", EN: "Photo not found"},
+	"foto.list_failed":         {ID: "Gagal mengambil daftar foto", EN: "Failed to get photos"},
+	"foto.delete_failed":       {ID: "Gagal menghapus foto", EN: "Failed to delete photo"},
+	"foto.uploaded":            {ID: "Foto berhasil diupload", EN: "Photo uploaded successfully"},
+	"foto.listed":              {ID: "Daftar foto berhasil diambil", EN: "Photos retrieved successfully"},
+	"foto.fetched":             {ID: "Foto berhasil diambil", EN: "Photo retrieved successfully"},
+	"foto.deleted":             {ID: "Foto berhasil dihapus", EN: "Photo deleted successfully"},
+	"sertifikat.not_found":     {ID: "Sertifikat tidak ditemukan", EN: "Certificate not found"},
+	"sertifikat.list_failed":   {ID: "Gagal mengambil daftar sertifikat", EN: "Failed to get certificates"},
+	"sertifikat.delete_failed": {ID: "Gagal menghapus sertifikat", EN: "Failed to delete certificate"},
+	"sertifikat.uploaded":      {ID: "Sertifikat berhasil diupload", EN: "Certificate uploaded successfully"},
+	"sertifikat.listed":        {ID: "Daftar sertifikat berhasil diambil", EN: "Certificates retrieved successfully"},
+	"sertifikat.fetched":       {ID: "Sertifikat berhasil diambil", EN: "Certificate retrieved successfully"},
+	"sertifikat.deleted":       {ID: "Sertifikat berhasil dihapus", EN: "Certificate deleted successfully"},
+}
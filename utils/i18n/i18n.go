@@ -0,0 +1,88 @@
+// Package i18n memilih bahasa response (Indonesia / Inggris) dan menerjemahkan pesan API dari katalog.
+//
+// Pesan ditulis di kode sebagai kunci katalog (mis. "alumni.not_found"), lalu diterjemahkan sesuai
+// bahasa request: ?lang= lebih dulu, kemudian header Accept-Language, default Bahasa Indonesia.
+// Kunci yang tidak ada di katalog dikembalikan apa adanya, sehingga pesan dinamis tetap tampil.
+package i18n
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Bahasa yang didukung
+const (
+	ID = "id"
+	EN = "en"
+
+	Default = ID
+)
+
+// Lang -> bahasa response untuk request ini
+func Lang(c *fiber.Ctx) string {
+	if lang := normalize(c.Query("lang")); lang != "" {
+		return lang
+	}
+	if lang := c.AcceptsLanguages(ID, EN); lang != "" {
+		return lang
+	}
+	return Default
+}
+
+func normalize(lang string) string {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	for _, l := range []string{ID, EN} {
+		if lang == l || strings.HasPrefix(lang, l+"-") {
+			return l
+		}
+	}
+	return ""
+}
+
+// T menerjemahkan key ke bahasa lang. args diisi ke placeholder fmt pada pesan; argumen bertipe
+// Message ikut diterjemahkan.
+func T(lang, key string, args ...interface{}) string {
+	msg := key
+	if byLang, ok := catalog[key]; ok {
+		if m, ok := byLang[lang]; ok {
+			msg = m
+		} else {
+			msg = byLang[Default]
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+
+	localized := make([]interface{}, len(args))
+	for i, a := range args {
+		if m, ok := a.(Message); ok {
+			a = m.In(lang)
+		}
+		localized[i] = a
+	}
+	return fmt.Sprintf(msg, localized...)
+}
+
+// Msg -> T dengan bahasa request
+func Msg(c *fiber.Ctx, key string, args ...interface{}) string {
+	return T(Lang(c), key, args...)
+}
+
+// Message -> pesan yang baru diterjemahkan saat response ditulis
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+// New -> Message dengan argumen
+func New(key string, args ...interface{}) Message {
+	return Message{Key: key, Args: args}
+}
+
+// In -> teks pesan dalam bahasa lang
+func (m Message) In(lang string) string {
+	return T(lang, m.Key, m.Args...)
+}
@@ -0,0 +1,322 @@
+// Package importer membaca data alumni dari file CSV / XLSX (dari registrar),
+// memetakan kolom ke field alumni, dan memvalidasi setiap baris.
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/mail"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"alumniproject/utils/validate"
+)
+
+// Field alumni yang bisa diisi dari file import
+const (
+	FieldNIM        = "nim"
+	FieldNama       = "nama"
+	FieldJurusan    = "jurusan"
+	FieldAngkatan   = "angkatan"
+	FieldTahunLulus = "tahun_lulus"
+	FieldEmail      = "email"
+	FieldNoTelepon  = "no_telepon"
+	FieldAlamat     = "alamat"
+)
+
+// MinYear -> batas bawah angkatan & tahun lulus, sama dengan validasi request API
+const MinYear = validate.MinYear
+
+var requiredFields = []string{FieldNIM, FieldNama, FieldJurusan, FieldEmail}
+
+// DefaultMapping -> header kolom (sudah dinormalisasi) ke field alumni.
+// Bisa ditimpa per request lewat mapping kustom.
+var DefaultMapping = map[string]string{
+	"nim":           FieldNIM,
+	"nomor_induk":   FieldNIM,
+	"nama":          FieldNama,
+	"nama_lengkap":  FieldNama,
+	"jurusan":       FieldJurusan,
+	"program_studi": FieldJurusan,
+	"prodi":         FieldJurusan,
+	"angkatan":      FieldAngkatan,
+	"tahun_masuk":   FieldAngkatan,
+	"tahun_lulus":   FieldTahunLulus,
+	"email":         FieldEmail,
+	"e_mail":        FieldEmail,
+	"no_telepon":    FieldNoTelepon,
+	"no_hp":         FieldNoTelepon,
+	"telepon":       FieldNoTelepon,
+	"alamat":        FieldAlamat,
+}
+
+// Row -> satu baris data alumni dari file. Line = nomor baris di spreadsheet (header = 1).
+type Row struct {
+	Line       int
+	NIM        string
+	Nama       string
+	Jurusan    string
+	Angkatan   int
+	TahunLulus int
+	Email      string
+	NoTelepon  string
+	Alamat     string
+}
+
+// RowError -> kesalahan pada satu baris (Line 0 = kesalahan file secara umum)
+type RowError struct {
+	Line    int    `json:"row"`
+	NIM     string `json:"nim,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Normalize menyeragamkan teks header: huruf kecil, spasi & tanda hubung jadi underscore
+func Normalize(header string) string {
+	h := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+	return strings.NewReplacer(" ", "_", "-", "_", ".", "").Replace(h)
+}
+
+// ParseMapping membaca mapping kustom berformat {"Header di file": "field"} dan
+// menggabungkannya dengan DefaultMapping
+func ParseMapping(custom map[string]string) (map[string]string, error) {
+	known := map[string]bool{
+		FieldNIM: true, FieldNama: true, FieldJurusan: true, FieldAngkatan: true,
+		FieldTahunLulus: true, FieldEmail: true, FieldNoTelepon: true, FieldAlamat: true,
+	}
+
+	mapping := make(map[string]string, len(DefaultMapping)+len(custom))
+	for k, v := range DefaultMapping {
+		mapping[k] = v
+	}
+	for header, field := range custom {
+		if !known[field] {
+			return nil, fmt.Errorf("field %q pada mapping tidak dikenal", field)
+		}
+		mapping[Normalize(header)] = field
+	}
+	return mapping, nil
+}
+
+// Parse membaca file CSV / XLSX (dilihat dari ekstensi nama file) menjadi baris alumni.
+// Nilai yang tidak bisa dibaca (mis. angkatan bukan angka) dikembalikan sebagai RowError.
+func Parse(filename string, r io.Reader, mapping map[string]string) ([]Row, []RowError, error) {
+	var records [][]string
+	var err error
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		records, err = readCSV(r)
+	case ".xlsx":
+		records, err = readXLSX(r)
+	default:
+		return nil, nil, fmt.Errorf("format file harus .csv atau .xlsx")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, fmt.Errorf("file kosong")
+	}
+
+	// Posisi kolom untuk setiap field
+	columns := make(map[string]int)
+	for i, h := range records[0] {
+		if field, ok := mapping[Normalize(h)]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	var missing []string
+	for _, f := range requiredFields {
+		if _, ok := columns[f]; !ok {
+			missing = append(missing, f)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, nil, fmt.Errorf("kolom wajib tidak ditemukan: %s", strings.Join(missing, ", "))
+	}
+
+	var rows []Row
+	var errs []RowError
+	for i, rec := range records[1:] {
+		line := i + 2
+		get := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(rec) {
+				return ""
+			}
+			return strings.TrimSpace(rec[idx])
+		}
+
+		if isBlank(rec) {
+			continue
+		}
+
+		row := Row{
+			Line:      line,
+			NIM:       get(FieldNIM),
+			Nama:      get(FieldNama),
+			Jurusan:   get(FieldJurusan),
+			Email:     get(FieldEmail),
+			NoTelepon: get(FieldNoTelepon),
+			Alamat:    get(FieldAlamat),
+		}
+
+		ok := true
+		for _, f := range []struct {
+			name string
+			dst  *int
+		}{{FieldAngkatan, &row.Angkatan}, {FieldTahunLulus, &row.TahunLulus}} {
+			v := get(f.name)
+			if v == "" {
+				continue
+			}
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				errs = append(errs, RowError{Line: line, NIM: row.NIM, Field: f.name, Message: "harus berupa angka tahun"})
+				ok = false
+				continue
+			}
+			*f.dst = n
+		}
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	return rows, errs, nil
+}
+
+// Validate memeriksa field wajib, format email, rentang tahun, dan NIM ganda di dalam file
+func Validate(rows []Row) (valid []Row, errs []RowError) {
+	maxYear := time.Now().Year()
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		fail := func(field, msg string) {
+			errs = append(errs, RowError{Line: row.Line, NIM: row.NIM, Field: field, Message: msg})
+		}
+		before := len(errs)
+
+		if row.NIM == "" {
+			fail(FieldNIM, "wajib diisi")
+		}
+		if row.Nama == "" {
+			fail(FieldNama, "wajib diisi")
+		}
+		if row.Jurusan == "" {
+			fail(FieldJurusan, "wajib diisi")
+		}
+		if row.Email == "" {
+			fail(FieldEmail, "wajib diisi")
+		} else if addr, err := mail.ParseAddress(row.Email); err != nil || addr.Address != row.Email {
+			fail(FieldEmail, "format email tidak valid")
+		}
+		if row.Angkatan != 0 && (row.Angkatan < MinYear || row.Angkatan > maxYear) {
+			fail(FieldAngkatan, fmt.Sprintf("harus antara %d dan %d", MinYear, maxYear))
+		}
+		if row.TahunLulus != 0 && (row.TahunLulus < MinYear || row.TahunLulus > maxYear) {
+			fail(FieldTahunLulus, fmt.Sprintf("harus antara %d dan %d", MinYear, maxYear))
+		}
+		if row.Angkatan != 0 && row.TahunLulus != 0 && row.TahunLulus < row.Angkatan {
+			fail(FieldTahunLulus, "tidak boleh sebelum angkatan")
+		}
+		if row.NIM != "" {
+			if first, dup := seen[row.NIM]; dup {
+				fail(FieldNIM, fmt.Sprintf("NIM ganda, sudah ada di baris %d", first))
+			} else {
+				seen[row.NIM] = row.Line
+			}
+		}
+
+		if len(errs) == before {
+			valid = append(valid, row)
+		}
+	}
+	return valid, errs
+}
+
+// WriteErrorsCSV menulis laporan kesalahan per baris dalam format CSV
+func WriteErrorsCSV(w io.Writer, errs []RowError) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"row", "nim", "field", "message"}); err != nil {
+		return err
+	}
+	for _, e := range errs {
+		if err := cw.Write([]string{strconv.Itoa(e.Line), e.NIM, e.Field, e.Message}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = -1
+	// Excel dengan locale Indonesia menyimpan CSV dengan pemisah titik koma
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		cr.Comma = ';'
+	}
+
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca CSV: %v", err)
+	}
+	return records, nil
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca XLSX: %v", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("file XLSX tidak memiliki sheet")
+	}
+	rows, err := f.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("gagal membaca XLSX: %v", err)
+	}
+	return rows, nil
+}
+
+func isBlank(rec []string) bool {
+	for _, v := range rec {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// SortErrors mengurutkan kesalahan berdasarkan nomor baris
+func SortErrors(errs []RowError) {
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+}
+
+// CountLines -> jumlah baris berbeda yang memiliki kesalahan
+func CountLines(errs []RowError) int {
+	lines := make(map[int]bool, len(errs))
+	for _, e := range errs {
+		lines[e.Line] = true
+	}
+	return len(lines)
+}
@@ -0,0 +1,247 @@
+// Package handler berisi bagian job latar belakang yang sama untuk PostgreSQL dan MongoDB: menjalankan
+// antrian, endpoint /jobs, dan job purge_trash. Penyimpanan job (jobs.Store), isi job export/import/report,
+// dan validasi params export tetap dikerjakan service masing-masing backend.
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alumniproject/utils/apiversion"
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/i18n"
+	"alumniproject/utils/jobs"
+	"alumniproject/utils/report"
+)
+
+// ExportPayload mengubah params job export (export_alumni / export_pekerjaan) dari body POST /jobs
+// menjadi payload job. role diambil dari token, bukan dari params. Params tidak valid -> error validasi.
+type ExportPayload func(jobType, role string, params json.RawMessage) (interface{}, error)
+
+// ErrQueueInactive -> antrian job belum berjalan
+var ErrQueueInactive = apperror.Unavailable("job.queue_inactive")
+
+// PurgeTrashParams -> payload job purge_trash
+type PurgeTrashParams struct {
+	RetentionDays int  `json:"retention_days"`
+	DryRun        bool `json:"dry_run"`
+}
+
+// Jobs -> antrian job satu backend beserta handler HTTP /jobs
+type Jobs struct {
+	store         jobs.Store
+	exportPayload ExportPayload
+	queue         *jobs.Queue
+}
+
+// New -> Jobs yang menyimpan job di store. Antrian baru berjalan setelah Start.
+func New(store jobs.Store, exportPayload ExportPayload) *Jobs {
+	return &Jobs{store: store, exportPayload: exportPayload}
+}
+
+// Start membuat antrian, mendaftarkan handler per tipe job, lalu menjalankan worker sampai ctx dibatalkan.
+// Job yang masih running saat server mati diantrikan ulang di sini.
+func (j *Jobs) Start(ctx context.Context, cfg jobs.Config, handlers map[string]jobs.Handler) {
+	j.queue = jobs.NewQueue(j.store, cfg)
+	for jobType, h := range handlers {
+		j.queue.Register(jobType, h)
+	}
+	j.queue.Start(ctx)
+}
+
+// Wait menunggu worker berhenti setelah ctx Start dibatalkan, paling lama sampai ctx habis
+func (j *Jobs) Wait(ctx context.Context) error {
+	if j.queue == nil {
+		return nil
+	}
+	return j.queue.Wait(ctx)
+}
+
+// SaveUpload menyimpan file input job lewat jobs.Queue.SaveUpload. Antrian belum berjalan -> ErrQueueInactive.
+func (j *Jobs) SaveUpload(name string, r io.Reader) (string, error) {
+	if j.queue == nil {
+		return "", ErrQueueInactive
+	}
+	return j.queue.SaveUpload(name, r)
+}
+
+// Enqueue menyimpan job baru dan membalas 202 dengan lokasi endpoint status job
+func (j *Jobs) Enqueue(c *fiber.Ctx, jobType string, params interface{}) error {
+	if j.queue == nil {
+		return ErrQueueInactive
+	}
+
+	job, err := j.queue.Enqueue(c.UserContext(), jobType, params, c.Locals("user_id").(int))
+	if err != nil {
+		slog.ErrorContext(c.UserContext(), "gagal membuat job", "job_type", jobType, "error", err)
+		return apperror.Internal("job.create_failed", err)
+	}
+
+	c.Location(apiversion.Path(apiversion.V1, "/jobs/"+job.ID))
+	return c.Status(202).JSON(fiber.Map{
+		"success": true,
+		"message": i18n.Msg(c, "job.queued"),
+		"data":    job,
+	})
+}
+
+// PurgeTrashJob -> handler job purge_trash, hasilnya laporan purge dalam JSON.
+// purge adalah PurgeTrash milik backend.
+func PurgeTrashJob[R any](purge func(ctx context.Context, retentionDays int, dryRun bool) (R, error)) jobs.Handler {
+	return func(ctx context.Context, run *jobs.Run) error {
+		var params PurgeTrashParams
+		if err := run.Decode(&params); err != nil {
+			return err
+		}
+		if params.RetentionDays < 0 {
+			return jobs.Permanent(fmt.Errorf("retention_days tidak boleh negatif"))
+		}
+
+		run.Progress(0, "Menghapus data trash")
+		report, err := purge(ctx, params.RetentionDays, params.DryRun)
+		if err != nil {
+			return err
+		}
+
+		file, err := run.CreateResult("purge-trash.json", fiber.MIMEApplicationJSON)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		return json.NewEncoder(file).Encode(report)
+	}
+}
+
+// CreateJob -> POST /jobs {"type": "...", "params": {...}}
+// @Summary Buat job latar belakang
+// @Description Mengantrikan job. Tipe: export_alumni, export_pekerjaan (params: format, search, filter, sort_by, order), purge_trash (khusus admin, params: retention_days, dry_run; menghapus trash semua user, non-admin memakai POST /pekerjaan/trash/purge), tracer_report (khusus admin, params: angkatan, jurusan). Job import_alumni dibuat lewat POST /alumni/import?async=true. Status dipantau lewat /api/v1/jobs/{id}.
+// @Tags Jobs
+// @Accept json
+// @Produce json
+// @Param body body object{type=string,params=object} true "Tipe job dan parameternya, mis. type export_alumni dengan params format xlsx"
+// @Success 202 {object} object{success=bool,message=string,data=jobs.Job}
+// @Failure 400 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /jobs [post]
+func (j *Jobs) CreateJob(c *fiber.Ctx) error {
+	var req struct {
+		Type   string          `json:"type"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.Validation("request.invalid_body")
+	}
+	role := c.Locals("role").(string)
+
+	switch req.Type {
+	case jobs.TypeExportAlumni, jobs.TypeExportPekerjaan:
+		params, err := j.exportPayload(req.Type, role, req.Params)
+		if err != nil {
+			return err
+		}
+		return j.Enqueue(c, req.Type, params)
+
+	case jobs.TypePurgeTrash:
+		if role != "admin" {
+			return apperror.Forbidden("trash.purge_admin_only")
+		}
+		var params PurgeTrashParams
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return apperror.Validation("request.invalid_params")
+			}
+		}
+		if params.RetentionDays < 0 {
+			return apperror.Validation("trash.retention_negative")
+		}
+		return j.Enqueue(c, req.Type, params)
+
+	case jobs.TypeTracerReport:
+		if role != "admin" {
+			return apperror.Forbidden("report.admin_only")
+		}
+		var params report.TracerFilter
+		if len(req.Params) > 0 {
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return apperror.Validation("request.invalid_params")
+			}
+		}
+		return j.Enqueue(c, req.Type, params)
+
+	default:
+		return apperror.Validation("job.type_invalid")
+	}
+}
+
+// find mengambil job milik user (admin boleh semua)
+func (j *Jobs) find(c *fiber.Ctx) (*jobs.Job, error) {
+	job, err := j.store.Get(c.UserContext(), c.Params("id"))
+	if err != nil {
+		return nil, apperror.Internal("job.fetch_failed", err)
+	}
+	if job == nil || (c.Locals("role").(string) != "admin" && job.CreatedBy != c.Locals("user_id").(int)) {
+		return nil, apperror.NotFound("job.not_found")
+	}
+	return job, nil
+}
+
+// GetJob -> GET /jobs/:id, status & progres job
+// @Summary Status job
+// @Description Mengambil status, progres, dan error job. result_url terisi jika job selesai dan punya file hasil. User hanya bisa melihat job miliknya.
+// @Tags Jobs
+// @Produce json
+// @Param id path string true "ID job"
+// @Success 200 {object} object{success=bool,data=jobs.Job,result_url=string}
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /jobs/{id} [get]
+func (j *Jobs) GetJob(c *fiber.Ctx) error {
+	job, err := j.find(c)
+	if job == nil {
+		return err
+	}
+
+	resp := fiber.Map{"success": true, "data": job}
+	if job.HasResult() {
+		resp["result_url"] = apiversion.Path(apiversion.V1, "/jobs/"+job.ID+"/result")
+	}
+	return c.JSON(resp)
+}
+
+// GetJobResult -> GET /jobs/:id/result, unduh file hasil job yang sudah selesai
+// @Summary Unduh hasil job
+// @Description Mengunduh file hasil job yang sudah selesai (mis. file export)
+// @Tags Jobs
+// @Produce octet-stream
+// @Param id path string true "ID job"
+// @Success 200 {file} file
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 410 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Security BearerAuth
+// @Router /jobs/{id}/result [get]
+func (j *Jobs) GetJobResult(c *fiber.Ctx) error {
+	job, err := j.find(c)
+	if job == nil {
+		return err
+	}
+	if !job.HasResult() {
+		return apperror.Conflict("job.not_finished").WithArgs(job.Status).WithCode(apperror.CodeJobNotFinished)
+	}
+
+	if err := c.Download(job.ResultPath, job.ResultName); err != nil {
+		return apperror.Gone("job.result_gone")
+	}
+	c.Set(fiber.HeaderContentType, job.ResultType)
+	return nil
+}
@@ -0,0 +1,438 @@
+// Package jobs menjalankan pekerjaan panjang (import, export, purge, laporan) di latar belakang.
+// Job disimpan di database lewat Store, diambil oleh worker di dalam proses server,
+// dan job yang sedang berjalan saat server mati akan diantrikan ulang ketika start.
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status job
+const (
+	StatusQueued    = "queued"
+	StatusRunning   = "running"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Tipe job yang dikenal aplikasi
+const (
+	TypeExportAlumni    = "export_alumni"
+	TypeExportPekerjaan = "export_pekerjaan"
+	TypeImportAlumni    = "import_alumni"
+	TypePurgeTrash      = "purge_trash"
+	TypeTracerReport    = "tracer_report"
+)
+
+// Job -> satu pekerjaan latar belakang beserta progres dan hasilnya
+type Job struct {
+	ID          string          `json:"id" bson:"_id"`
+	Type        string          `json:"type" bson:"type"`
+	Status      string          `json:"status" bson:"status"`
+	Payload     json.RawMessage `json:"payload,omitempty" bson:"payload"`
+	Attempts    int             `json:"attempts" bson:"attempts"`
+	MaxAttempts int             `json:"max_attempts" bson:"max_attempts"`
+	Progress    int             `json:"progress" bson:"progress"` // 0-100
+	Message     string          `json:"message,omitempty" bson:"message,omitempty"`
+	Error       string          `json:"error,omitempty" bson:"error,omitempty"`
+	ResultPath  string          `json:"-" bson:"result_path,omitempty"`
+	ResultName  string          `json:"result_name,omitempty" bson:"result_name,omitempty"`
+	ResultType  string          `json:"result_type,omitempty" bson:"result_type,omitempty"`
+	CreatedBy   int             `json:"created_by" bson:"created_by"`
+	RunAfter    time.Time       `json:"run_after" bson:"run_after"`
+	CreatedAt   time.Time       `json:"created_at" bson:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at" bson:"updated_at"`
+	StartedAt   *time.Time      `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+// HasResult -> job selesai dan punya file hasil
+func (j *Job) HasResult() bool {
+	return j.Status == StatusSucceeded && j.ResultPath != ""
+}
+
+// Store -> penyimpanan job (PostgreSQL atau MongoDB)
+type Store interface {
+	Create(ctx context.Context, job *Job) error
+	// Get mengembalikan nil, nil jika job tidak ada
+	Get(ctx context.Context, id string) (*Job, error)
+	// ClaimNext mengambil satu job queued yang sudah waktunya (run_after <= now) dengan tipe di types,
+	// mengubahnya menjadi running dan menaikkan attempts secara atomik. nil jika tidak ada.
+	ClaimNext(ctx context.Context, types []string, now time.Time) (*Job, error)
+	UpdateProgress(ctx context.Context, id string, progress int, message string) error
+	// Finish menyimpan status akhir percobaan: status, progress, message, error, hasil, run_after, finished_at
+	Finish(ctx context.Context, job *Job) error
+	// Recover mengembalikan job running (ditinggal worker yang mati) ke status queued
+	Recover(ctx context.Context) (int64, error)
+}
+
+// Handler menjalankan satu job. Error -> job dicoba ulang sampai MaxAttempts.
+type Handler func(ctx context.Context, run *Run) error
+
+// ErrPermanent -> bungkus error dengan ini agar job langsung gagal tanpa dicoba ulang
+var ErrPermanent = errors.New("job tidak bisa dicoba ulang")
+
+// Permanent menandai error sebagai kegagalan permanen (mis. payload tidak valid)
+func Permanent(err error) error {
+	return fmt.Errorf("%w: %v", ErrPermanent, err)
+}
+
+// Config -> pengaturan Queue
+type Config struct {
+	Workers      int
+	PollInterval time.Duration
+	MaxAttempts  int
+	ResultDir    string
+}
+
+// Queue -> antrian job dengan worker di dalam proses
+type Queue struct {
+	store    Store
+	cfg      Config
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	wake     chan struct{}
+	workers  sync.WaitGroup
+}
+
+// NewQueue membuat antrian baru. Handler didaftarkan dengan Register sebelum Start.
+func NewQueue(store Store, cfg Config) *Queue {
+	if cfg.MaxAttempts < 1 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 2 * time.Second
+	}
+	return &Queue{
+		store:    store,
+		cfg:      cfg,
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register mendaftarkan handler untuk satu tipe job
+func (q *Queue) Register(jobType string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[jobType] = h
+}
+
+// Registered -> tipe job sudah punya handler
+func (q *Queue) Registered(jobType string) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	_, ok := q.handlers[jobType]
+	return ok
+}
+
+// Store -> penyimpanan job yang dipakai antrian ini
+func (q *Queue) Store() Store {
+	return q.store
+}
+
+// Enqueue menyimpan job baru berstatus queued dan membangunkan worker
+func (q *Queue) Enqueue(ctx context.Context, jobType string, payload interface{}, createdBy int) (*Job, error) {
+	if !q.Registered(jobType) {
+		return nil, fmt.Errorf("tipe job %q tidak dikenal", jobType)
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("payload job tidak valid: %v", err)
+	}
+
+	now := time.Now()
+	job := &Job{
+		ID:          uuid.NewString(),
+		Type:        jobType,
+		Status:      StatusQueued,
+		Payload:     raw,
+		MaxAttempts: q.cfg.MaxAttempts,
+		CreatedBy:   createdBy,
+		RunAfter:    now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := q.store.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// SaveUpload menyimpan file input job (mis. file import) di subfolder uploads pada ResultDir dan
+// mengembalikan path-nya untuk dimasukkan ke payload sebagai field "file". Handler menghapusnya lewat
+// Run.RemoveUpload; jika job gagal permanen, queue ikut menghapusnya walaupun handler tidak sempat berjalan.
+func (q *Queue) SaveUpload(name string, r io.Reader) (string, error) {
+	dir := q.uploadDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, uuid.NewString()+filepath.Ext(name))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+func (q *Queue) uploadDir() string {
+	return filepath.Join(q.cfg.ResultDir, "uploads")
+}
+
+// Start mengantrikan ulang job yang tertinggal lalu menjalankan worker sampai ctx dibatalkan
+func (q *Queue) Start(ctx context.Context) {
+	if q.cfg.Workers <= 0 {
+		slog.InfoContext(ctx, "worker job nonaktif (JOB_WORKERS <= 0)")
+		return
+	}
+
+	if n, err := q.store.Recover(ctx); err != nil {
+		slog.ErrorContext(ctx, "gagal memulihkan job yang tertinggal", "error", err)
+	} else if n > 0 {
+		slog.InfoContext(ctx, "job yang terhenti saat restart diantrikan ulang", "count", n)
+	}
+
+	if err := os.MkdirAll(q.cfg.ResultDir, 0755); err != nil {
+		slog.WarnContext(ctx, "gagal membuat folder hasil job", "dir", q.cfg.ResultDir, "error", err)
+	}
+
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.workers.Add(1)
+		go func(n int) {
+			defer q.workers.Done()
+			q.worker(ctx, n)
+		}(i + 1)
+	}
+	slog.InfoContext(ctx, "worker job aktif", "workers", q.cfg.Workers)
+}
+
+// Wait menunggu semua worker berhenti setelah ctx Start dibatalkan. Job yang sedang berjalan ikut
+// dibatalkan dan diantrikan ulang saat start berikutnya. Mengembalikan ctx.Err() jika ctx habis duluan.
+func (q *Queue) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		q.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) types() []string {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	types := make([]string, 0, len(q.handlers))
+	for t := range q.handlers {
+		types = append(types, t)
+	}
+	return types
+}
+
+func (q *Queue) worker(ctx context.Context, n int) {
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Kerjakan semua job yang siap sebelum menunggu lagi
+		for ctx.Err() == nil {
+			job, err := q.store.ClaimNext(ctx, q.types(), time.Now())
+			if err != nil {
+				slog.ErrorContext(ctx, "worker gagal mengambil job", "worker", n, "error", err)
+				break
+			}
+			if job == nil {
+				break
+			}
+			q.execute(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+func (q *Queue) execute(ctx context.Context, job *Job) {
+	run := &Run{Job: job, queue: q}
+
+	q.mu.RLock()
+	h := q.handlers[job.Type]
+	q.mu.RUnlock()
+
+	var err error
+	if job.Attempts > job.MaxAttempts {
+		err = Permanent(fmt.Errorf("melebihi batas %d percobaan", job.MaxAttempts))
+	} else {
+		slog.InfoContext(ctx, "job mulai", "job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts, "max_attempts", job.MaxAttempts)
+		err = runSafe(ctx, h, run)
+	}
+
+	// Server berhenti di tengah job: biarkan running, akan diantrikan ulang saat start
+	if ctx.Err() != nil {
+		slog.WarnContext(context.WithoutCancel(ctx), "job terhenti karena server berhenti", "job_id", job.ID, "job_type", job.Type)
+		return
+	}
+
+	now := time.Now()
+	job.UpdatedAt = now
+	switch {
+	case err == nil:
+		job.Status = StatusSucceeded
+		job.Progress = 100
+		job.Error = ""
+		job.FinishedAt = &now
+		slog.InfoContext(ctx, "job selesai", "job_id", job.ID, "job_type", job.Type)
+	case errors.Is(err, ErrPermanent) || job.Attempts >= job.MaxAttempts:
+		run.removeResult()
+		run.removePayloadUpload()
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		job.FinishedAt = &now
+		slog.ErrorContext(ctx, "job gagal", "job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts, "error", err)
+	default:
+		// Coba ulang dengan jeda yang makin panjang: 10s, 40s, 90s, ...
+		run.removeResult()
+		job.Status = StatusQueued
+		job.Error = err.Error()
+		job.RunAfter = now.Add(time.Duration(job.Attempts*job.Attempts) * 10 * time.Second)
+		slog.WarnContext(ctx, "job gagal, akan dicoba ulang", "job_id", job.ID, "job_type", job.Type, "attempt", job.Attempts, "run_after", job.RunAfter, "error", err)
+	}
+
+	// Status akhir tetap disimpan walaupun ctx worker dibatalkan setelah ini
+	saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := q.store.Finish(saveCtx, job); err != nil {
+		slog.ErrorContext(saveCtx, "gagal menyimpan status job", "job_id", job.ID, "job_type", job.Type, "status", job.Status, "error", err)
+	}
+}
+
+func runSafe(ctx context.Context, h Handler, run *Run) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, run)
+}
+
+// Run -> konteks satu eksekusi job untuk handler
+type Run struct {
+	Job   *Job
+	queue *Queue
+}
+
+// Decode membaca payload job ke dst. Payload tidak valid -> kegagalan permanen.
+func (r *Run) Decode(dst interface{}) error {
+	if len(r.Job.Payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(r.Job.Payload, dst); err != nil {
+		return Permanent(fmt.Errorf("payload tidak valid: %v", err))
+	}
+	return nil
+}
+
+// Progress menyimpan progres (0-100) dan pesan singkat. Kegagalan menyimpan hanya di-log.
+func (r *Run) Progress(percent int, message string) {
+	if percent < 0 {
+		percent = 0
+	}
+	if percent > 99 {
+		percent = 99 // 100 hanya untuk job yang sudah selesai
+	}
+	r.Job.Progress = percent
+	r.Job.Message = message
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := r.queue.store.UpdateProgress(ctx, r.Job.ID, percent, message); err != nil {
+		slog.WarnContext(ctx, "gagal menyimpan progres job", "job_id", r.Job.ID, "job_type", r.Job.Type, "error", err)
+	}
+}
+
+// CreateResult membuat file hasil job. name = nama file saat diunduh.
+func (r *Run) CreateResult(name, contentType string) (*os.File, error) {
+	r.removeResult()
+
+	path := filepath.Join(r.queue.cfg.ResultDir, r.Job.ID+filepath.Ext(name))
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r.Job.ResultPath = path
+	r.Job.ResultName = name
+	r.Job.ResultType = contentType
+	return f, nil
+}
+
+// RemoveUpload menghapus file input dari SaveUpload jika job tidak akan dicoba ulang setelah handler
+// mengembalikan err (berhasil, gagal permanen, atau percobaan terakhir). Dipanggil lewat defer di handler.
+func (r *Run) RemoveUpload(path string, err error) {
+	if err != nil && !errors.Is(err, ErrPermanent) && r.Job.Attempts < r.Job.MaxAttempts {
+		return
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		slog.WarnContext(context.Background(), "gagal menghapus file upload job", "job_id", r.Job.ID, "job_type", r.Job.Type, "path", path, "error", err)
+	}
+}
+
+// removePayloadUpload menghapus file SaveUpload yang dirujuk field "file" pada payload. Dipakai saat job
+// gagal permanen, termasuk ketika handler tidak dijalankan lagi karena batas percobaan sudah terlewati.
+// Path di luar folder uploads tidak disentuh.
+func (r *Run) removePayloadUpload() {
+	var payload struct {
+		File string `json:"file"`
+	}
+	if len(r.Job.Payload) == 0 || json.Unmarshal(r.Job.Payload, &payload) != nil || payload.File == "" {
+		return
+	}
+	if filepath.Dir(filepath.Clean(payload.File)) != filepath.Clean(r.queue.uploadDir()) {
+		return
+	}
+	r.RemoveUpload(payload.File, nil)
+}
+
+// removeResult menghapus file hasil dari percobaan yang gagal
+func (r *Run) removeResult() {
+	if r.Job.ResultPath == "" {
+		return
+	}
+	os.Remove(r.Job.ResultPath)
+	r.Job.ResultPath = ""
+	r.Job.ResultName = ""
+	r.Job.ResultType = ""
+}
@@ -0,0 +1,108 @@
+// Package logger menyiapkan logger slog berformat JSON yang sadar request.
+//
+// Request ID disimpan di context (lihat RequestID), dan handler dari New menambahkan atribut
+// request_id ke setiap log yang ditulis dengan varian *Context, mis. slog.InfoContext(ctx, ...).
+// Dengan meneruskan c.UserContext() ke service & repository, semua log satu request bisa
+// dikaitkan dengan access log-nya. Nilai sensitif (password, token, ...) disamarkan sebelum ditulis.
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Redacted -> pengganti nilai atribut sensitif
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys -> atribut yang nilainya tidak boleh masuk log (dicocokkan tanpa beda huruf besar/kecil,
+// cukup mengandung salah satu kata ini, mis. "new_password" atau "refresh_token")
+var sensitiveKeys = []string{"password", "passwd", "token", "secret", "authorization", "cookie", "api_key", "apikey"}
+
+type requestIDKey struct{}
+
+// WithRequestID -> context turunan yang membawa request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFrom -> request ID di context, "" jika tidak ada (mis. job background)
+func RequestIDFrom(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// ParseLevel membaca level log (debug, info, warn, error; boleh "info+2" dst.), default info
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if strings.TrimSpace(s) == "" {
+		return slog.LevelInfo, nil
+	}
+	err := level.UnmarshalText([]byte(strings.TrimSpace(s)))
+	return level, err
+}
+
+// New membuat handler JSON yang menulis ke w mulai dari level tertentu
+func New(w io.Writer, level slog.Leveler) slog.Handler {
+	return contextHandler{slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redact,
+	})}
+}
+
+// contextHandler menambahkan request_id (dan trace_id/span_id bila ada span aktif) dari context
+// ke setiap record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestIDFrom(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()), slog.String("span_id", sc.SpanID().String()))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+// redact menyamarkan atribut sensitif berdasarkan nama, juga nilai string berbentuk "Bearer ..."
+func redact(_ []string, a slog.Attr) slog.Attr {
+	if a.Value.Kind() == slog.KindGroup {
+		return a
+	}
+	if isSensitive(a.Key) {
+		return slog.String(a.Key, Redacted)
+	}
+	if a.Value.Kind() == slog.KindString {
+		v := a.Value.String()
+		if len(v) > 7 && strings.EqualFold(v[:7], "bearer ") {
+			return slog.String(a.Key, Redacted)
+		}
+	}
+	return a
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
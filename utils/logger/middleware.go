@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID -> header request ID, diterima dari client/proxy dan selalu dikirim balik
+const HeaderRequestID = "X-Request-ID"
+
+// LocalsRequestID -> kunci c.Locals berisi request ID
+const LocalsRequestID = "request_id"
+
+// maxRequestIDLen -> request ID dari client yang lebih panjang diganti ID baru
+const maxRequestIDLen = 128
+
+// RequestID -> middleware yang memakai X-Request-ID dari client bila valid atau membuat UUID baru,
+// lalu menyimpannya di header response, c.Locals("request_id"), dan c.UserContext().
+func RequestID() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// Header menunjuk buffer request yang dipakai ulang Fiber, sedangkan ID bisa dipakai setelah
+		// handler selesai (mis. stream export), jadi disalin
+		id := strings.Clone(c.Get(HeaderRequestID))
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+		c.Set(HeaderRequestID, id)
+		c.Locals(LocalsRequestID, id)
+		c.SetUserContext(WithRequestID(c.UserContext(), id))
+		return c.Next()
+	}
+}
+
+// validRequestID -> hanya karakter ASCII tampak agar aman ditulis ke log & header
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// AccessLog -> middleware yang menulis satu log JSON per request: method, route, path, status,
+// latency, user_id, dan request_id. Status 5xx dicatat sebagai error, 4xx sebagai warn.
+// Dipasang setelah RequestID. Error dari handler langsung diteruskan ke ErrorHandler app agar
+// status yang dicatat sama dengan yang dikirim ke client.
+func AccessLog() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if herr := c.App().ErrorHandler(c, err); herr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		latency := time.Since(start)
+
+		status := c.Response().StatusCode()
+		level := slog.LevelInfo
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= fiber.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Method()),
+			slog.String("route", c.Route().Path),
+			slog.String("path", c.Path()),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(latency.Microseconds())/1000),
+			slog.String("ip", c.IP()),
+			slog.Int("bytes_out", len(c.Response().Body())),
+		}
+		if userID := c.Locals("user_id"); userID != nil {
+			attrs = append(attrs, slog.Any("user_id", userID))
+		}
+		slog.LogAttrs(c.UserContext(), level, "http_request", attrs...)
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"alumniproject/utils/apperror"
+)
+
+// ContentType -> media type resmi untuk JSON Merge Patch (RFC 7396)
+const ContentType = "application/merge-patch+json"
+
+// Apply menerapkan patch ke dokumen JSON sesuai RFC 7396:
+// field bernilai null dihapus, objek di-merge secara rekursif, selain itu nilai diganti.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var p interface{}
+	if err := json.Unmarshal(patch, &p); err != nil {
+		return nil, fmt.Errorf("patch bukan JSON yang valid: %v", err)
+	}
+
+	var target interface{}
+	if len(bytes.TrimSpace(doc)) > 0 {
+		if err := json.Unmarshal(doc, &target); err != nil {
+			return nil, fmt.Errorf("dokumen bukan JSON yang valid: %v", err)
+		}
+	}
+
+	return json.Marshal(merge(target, p))
+}
+
+func merge(target, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]interface{})
+	if !ok {
+		targetObj = map[string]interface{}{}
+	}
+
+	for key, value := range patchObj {
+		if value == nil {
+			delete(targetObj, key)
+			continue
+		}
+		targetObj[key] = merge(targetObj[key], value)
+	}
+	return targetObj
+}
+
+// ApplyTo menerapkan patch ke current (struct request) lalu hasil merge di-decode ke out.
+// Field yang tidak dikenal di patch ditolak supaya typo tidak diam-diam diabaikan.
+func ApplyTo(current interface{}, patch []byte, out interface{}) error {
+	var probe interface{}
+	if err := json.Unmarshal(patch, &probe); err != nil {
+		return apperror.Validation("patch.invalid_json")
+	}
+	if _, ok := probe.(map[string]interface{}); !ok {
+		return apperror.Validation("patch.not_object")
+	}
+
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	merged, err := Apply(doc, patch)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(out); err != nil {
+		return apperror.Validation("patch.invalid").WithArgs(err.Error())
+	}
+	return nil
+}
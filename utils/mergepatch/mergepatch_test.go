@@ -0,0 +1,138 @@
+package mergepatch_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/mergepatch"
+)
+
+// TestApply memakai contoh dari RFC 7396 Appendix A
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"ganti nilai", `{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"tambah field", `{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{"null menghapus field", `{"a":"b"}`, `{"a":null}`, `{}`},
+		{"null hanya field yang disebut", `{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{"array diganti utuh", `{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{"nilai diganti array", `{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{"objek bersarang di-merge", `{"a":{"b":"c","d":"e"}}`, `{"a":{"b":"d","d":null}}`, `{"a":{"b":"d"}}`},
+		{"array objek tidak di-merge", `{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{"patch bukan objek mengganti dokumen", `{"a":"b"}`, `["c"]`, `["c"]`},
+		{"patch null mengganti dokumen", `{"a":"foo"}`, `null`, `null`},
+		{"patch string mengganti dokumen", `{"a":"foo"}`, `"bar"`, `"bar"`},
+		{"null di dokumen tidak disentuh", `{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{"dokumen bukan objek", `[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{"objek baru bersarang", `{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		{"dokumen kosong", ``, `{"a":1}`, `{"a":1}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mergepatch.Apply([]byte(tt.doc), []byte(tt.patch))
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if !jsonEqual(t, got, []byte(tt.want)) {
+				t.Errorf("Apply(%s, %s) = %s, want %s", tt.doc, tt.patch, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInvalidJSON(t *testing.T) {
+	if _, err := mergepatch.Apply([]byte(`{}`), []byte(`{"a":`)); err == nil {
+		t.Error("patch rusak tidak ditolak")
+	}
+	if _, err := mergepatch.Apply([]byte(`{"a":`), []byte(`{}`)); err == nil {
+		t.Error("dokumen rusak tidak ditolak")
+	}
+}
+
+type alamat struct {
+	Kota    string `json:"kota"`
+	KodePos string `json:"kode_pos,omitempty"`
+}
+
+type profil struct {
+	Nama   string  `json:"nama"`
+	Email  string  `json:"email,omitempty"`
+	Alamat *alamat `json:"alamat,omitempty"`
+}
+
+func TestApplyTo(t *testing.T) {
+	current := profil{Nama: "Budi", Email: "budi@example.com", Alamat: &alamat{Kota: "Bandung", KodePos: "40111"}}
+
+	tests := []struct {
+		name  string
+		patch string
+		want  profil
+	}{
+		{"patch kosong", `{}`, current},
+		{"ganti satu field", `{"nama":"Budi S"}`, profil{Nama: "Budi S", Email: "budi@example.com", Alamat: &alamat{Kota: "Bandung", KodePos: "40111"}}},
+		{"null mengosongkan field", `{"email":null}`, profil{Nama: "Budi", Alamat: &alamat{Kota: "Bandung", KodePos: "40111"}}},
+		{"objek bersarang di-merge", `{"alamat":{"kota":"Jakarta"}}`, profil{Nama: "Budi", Email: "budi@example.com", Alamat: &alamat{Kota: "Jakarta", KodePos: "40111"}}},
+		{"null pada objek bersarang", `{"alamat":null}`, profil{Nama: "Budi", Email: "budi@example.com"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out profil
+			if err := mergepatch.ApplyTo(current, []byte(tt.patch), &out); err != nil {
+				t.Fatalf("ApplyTo: %v", err)
+			}
+			if !reflect.DeepEqual(out, tt.want) {
+				t.Errorf("ApplyTo(%s) = %+v, want %+v", tt.patch, out, tt.want)
+			}
+		})
+	}
+	if current.Alamat.Kota != "Bandung" {
+		t.Errorf("ApplyTo mengubah current: %+v", current.Alamat)
+	}
+}
+
+func TestApplyToRejects(t *testing.T) {
+	current := profil{Nama: "Budi", Alamat: &alamat{Kota: "Bandung"}}
+	tests := []struct {
+		name  string
+		patch string
+		key   string
+	}{
+		{"bukan JSON", `{"nama":`, "patch.invalid_json"},
+		{"array", `[{"nama":"x"}]`, "patch.not_object"},
+		{"null", `null`, "patch.not_object"},
+		{"string", `"Budi"`, "patch.not_object"},
+		{"field tidak dikenal", `{"nmaa":"Budi"}`, "patch.invalid"},
+		{"field bersarang tidak dikenal", `{"alamat":{"kode":"1"}}`, "patch.invalid"},
+		{"tipe tidak cocok", `{"nama":1}`, "patch.invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out profil
+			err := mergepatch.ApplyTo(current, []byte(tt.patch), &out)
+			if err == nil {
+				t.Fatalf("ApplyTo(%s) tidak ditolak", tt.patch)
+			}
+			if e := apperror.From(err); e.Detail != tt.key {
+				t.Errorf("ApplyTo(%s) error = %q, want %q", tt.patch, e.Detail, tt.key)
+			}
+		})
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var va, vb interface{}
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("hasil bukan JSON: %s", a)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("want bukan JSON: %s", b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,125 @@
+// Package metrics mengumpulkan metrik Prometheus aplikasi: jumlah & latency request per route,
+// request yang sedang berjalan, durasi query database, dan byte upload. Metrik dibuka di /metrics
+// lewat Handler, siap di-scrape Prometheus dan ditampilkan di Grafana.
+package metrics
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path -> endpoint scrape Prometheus
+const Path = "/metrics"
+
+// routeUnmatched -> label route untuk request yang tidak cocok dengan route mana pun,
+// agar path acak (mis. scan 404) tidak menambah label baru
+const routeUnmatched = "unmatched"
+
+// Registry -> registry khusus aplikasi (bukan prometheus.DefaultRegisterer), berisi juga
+// metrik runtime Go dan proses
+var Registry = prometheus.NewRegistry()
+
+var (
+	requestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Jumlah request HTTP per method, route, dan status.",
+	}, []string{"method", "route", "status"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency request HTTP per method dan route.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	requestsInFlight = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "http_requests_in_flight",
+		Help: "Jumlah request HTTP yang sedang diproses.",
+	})
+
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Durasi query database per backend, operasi, dan hasil (ok/error).",
+		Buckets: []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"backend", "operation", "outcome"})
+
+	uploadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "upload_bytes_total",
+		Help: "Total byte file yang di-upload per jenis upload.",
+	}, []string{"kind"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		requestsTotal, requestDuration, requestsInFlight, queryDuration, uploadBytes,
+	)
+}
+
+// Middleware mencatat metrik request HTTP. Label route memakai pola route Fiber (mis.
+// /api/v1/alumni/:id), bukan path mentah. Dipasang setelah middleware yang menulis response
+// error (AccessLog), supaya status yang dicatat sama dengan yang dikirim ke client.
+func Middleware() fiber.Handler {
+	// Route middleware (Use) juga tercatat di c.Route() saat tidak ada route yang cocok, jadi route
+	// handler dikumpulkan sekali saat request pertama, setelah semua route selesai didaftarkan
+	var (
+		once   sync.Once
+		routes map[string]bool
+	)
+	return func(c *fiber.Ctx) error {
+		once.Do(func() {
+			routes = make(map[string]bool)
+			for _, r := range c.App().GetRoutes(true) {
+				routes[r.Method+" "+r.Path] = true
+			}
+		})
+
+		if c.Path() == Path {
+			return c.Next()
+		}
+
+		requestsInFlight.Inc()
+		defer requestsInFlight.Dec()
+
+		// c.Method() menunjuk buffer request yang dipakai ulang Fiber, jadi disalin untuk label
+		method := strings.Clone(c.Method())
+		start := time.Now()
+		err := c.Next()
+
+		route := c.Route().Path
+		if !routes[c.Route().Method+" "+route] {
+			route = routeUnmatched
+		}
+		requestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+		requestsTotal.WithLabelValues(method, route, strconv.Itoa(c.Response().StatusCode())).Inc()
+		return err
+	}
+}
+
+// Handler -> handler /metrics dalam format exposition Prometheus
+func Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+}
+
+// ObserveQuery mencatat durasi satu query database. backend: "postgresql" atau "mongodb";
+// operation: jenis perintah (SELECT, INSERT, find, aggregate, ...).
+func ObserveQuery(backend, operation string, d time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	queryDuration.WithLabelValues(backend, operation, outcome).Observe(d.Seconds())
+}
+
+// AddUploadBytes menambah total byte upload untuk jenis upload tertentu (foto, sertifikat, import)
+func AddUploadBytes(kind string, n int64) {
+	uploadBytes.WithLabelValues(kind).Add(float64(n))
+}
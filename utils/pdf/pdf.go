@@ -0,0 +1,228 @@
+// Package pdf membuat dokumen PDF sederhana (teks, garis, kotak) tanpa dependensi luar.
+// Font yang dipakai adalah Helvetica standar PDF sehingga tidak perlu menyematkan file font.
+// Koordinat dalam point (1/72 inch) dengan titik (0,0) di kiri atas halaman.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ukuran halaman A4 portrait dalam point
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document -> dokumen PDF yang dibangun di memori lalu ditulis dengan Write
+type Document struct {
+	Width, Height float64
+
+	pages   []*bytes.Buffer
+	current *bytes.Buffer
+	bold    bool
+	size    float64
+}
+
+// New membuat dokumen A4 kosong. Panggil AddPage sebelum menggambar.
+func New() *Document {
+	return &Document{Width: A4Width, Height: A4Height, size: 10}
+}
+
+// AddPage menambah halaman baru dan menjadikannya halaman aktif
+func (d *Document) AddPage() {
+	d.current = &bytes.Buffer{}
+	d.pages = append(d.pages, d.current)
+}
+
+// PageCount -> jumlah halaman
+func (d *Document) PageCount() int {
+	return len(d.pages)
+}
+
+// SetPage memilih halaman aktif (mulai dari 1), mis. untuk menulis nomor halaman setelah semua halaman dibuat
+func (d *Document) SetPage(n int) {
+	if n >= 1 && n <= len(d.pages) {
+		d.current = d.pages[n-1]
+	}
+}
+
+// SetFont memilih Helvetica (bold = Helvetica-Bold) dengan ukuran dalam point
+func (d *Document) SetFont(bold bool, size float64) {
+	d.bold = bold
+	d.size = size
+}
+
+// FontSize -> ukuran font aktif
+func (d *Document) FontSize() float64 {
+	return d.size
+}
+
+// TextWidth -> lebar teks dengan font aktif
+func (d *Document) TextWidth(s string) float64 {
+	widths := helveticaWidths
+	if d.bold {
+		widths = helveticaBoldWidths
+	}
+	total := 0
+	for _, b := range encode(s) {
+		if b >= 32 && int(b-32) < len(widths) {
+			total += widths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	return float64(total) * d.size / 1000
+}
+
+// Truncate memotong teks dengan "..." agar lebarnya tidak melebihi maxWidth
+func (d *Document) Truncate(s string, maxWidth float64) string {
+	if d.TextWidth(s) <= maxWidth {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && d.TextWidth(string(r)+"...") > maxWidth {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
+
+// Text menulis teks dengan baseline di y
+func (d *Document) Text(x, y float64, s string) {
+	font := "F1"
+	if d.bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.current, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, d.size, x, d.Height-y, escape(encode(s)))
+}
+
+// SetFillColor mengatur warna isi (teks & kotak), komponen 0-255
+func (d *Document) SetFillColor(r, g, b int) {
+	fmt.Fprintf(d.current, "%.3f %.3f %.3f rg\n", float64(r)/255, float64(g)/255, float64(b)/255)
+}
+
+// SetStrokeColor mengatur warna garis, komponen 0-255
+func (d *Document) SetStrokeColor(r, g, b int) {
+	fmt.Fprintf(d.current, "%.3f %.3f %.3f RG\n", float64(r)/255, float64(g)/255, float64(b)/255)
+}
+
+// SetLineWidth mengatur tebal garis
+func (d *Document) SetLineWidth(w float64) {
+	fmt.Fprintf(d.current, "%.2f w\n", w)
+}
+
+// Rect menggambar kotak dengan sudut kiri atas (x, y). fill -> diisi warna, jika tidak hanya garis tepi.
+func (d *Document) Rect(x, y, w, h float64, fill bool) {
+	op := "S"
+	if fill {
+		op = "f"
+	}
+	fmt.Fprintf(d.current, "%.2f %.2f %.2f %.2f re %s\n", x, d.Height-y-h, w, h, op)
+}
+
+// Line menggambar garis lurus
+func (d *Document) Line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(d.current, "%.2f %.2f m %.2f %.2f l S\n", x1, d.Height-y1, x2, d.Height-y2)
+}
+
+// Write menulis dokumen PDF lengkap ke w
+func (d *Document) Write(w io.Writer) error {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	var buf bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1 catalog, 2 pages, 3-4 font, lalu pasangan page + content untuk setiap halaman
+	const firstPage = 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+i*2)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, page := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			d.Width, d.Height, firstPage+i*2+1))
+
+		var z bytes.Buffer
+		zw := zlib.NewWriter(&z)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", z.Len(), z.Bytes()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// encode mengubah teks ke WinAnsi (Latin-1); karakter di luar itu diganti "?"
+func encode(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r >= 32 && r < 127, r >= 160 && r <= 255:
+			out = append(out, byte(r))
+		case r == '\t':
+			out = append(out, ' ')
+		default:
+			out = append(out, '?')
+		}
+	}
+	return out
+}
+
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		if c == '\\' || c == '(' || c == ')' {
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// Lebar karakter 32-126 (per 1000 unit) dari metrik AFM Helvetica & Helvetica-Bold
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+var helveticaBoldWidths = []int{
+	278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+	975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+	333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+	611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+}
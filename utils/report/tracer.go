@@ -0,0 +1,336 @@
+// Package report menyusun laporan tracer study alumni (keterserapan kerja per angkatan,
+// bidang industri, perusahaan teratas, rentang gaji) menjadi PDF berhalaman.
+package report
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"alumniproject/utils/pdf"
+)
+
+// TopEmployers -> jumlah perusahaan teratas yang ditampilkan di laporan
+const TopEmployers = 10
+
+// TracerFilter -> filter laporan, nilai kosong berarti semua
+type TracerFilter struct {
+	Angkatan int    `json:"angkatan,omitempty"`
+	Jurusan  string `json:"jurusan,omitempty"`
+}
+
+// CohortStat -> keterserapan kerja satu angkatan
+type CohortStat struct {
+	Angkatan int `json:"angkatan"`
+	Total    int `json:"total"`
+	Employed int `json:"employed"` // alumni dengan minimal satu pekerjaan aktif
+}
+
+// Rate -> persentase alumni yang bekerja
+func (c CohortStat) Rate() float64 {
+	if c.Total == 0 {
+		return 0
+	}
+	return float64(c.Employed) * 100 / float64(c.Total)
+}
+
+// CountStat -> jumlah per kategori (bidang industri, perusahaan, rentang gaji)
+type CountStat struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// TracerStats -> data laporan dari repository. Breakdown industri, perusahaan, dan gaji
+// dihitung dari pekerjaan berstatus aktif.
+type TracerStats struct {
+	Cohorts     []CohortStat `json:"cohorts"`
+	Industries  []CountStat  `json:"industries"`
+	Employers   []CountStat  `json:"employers"`
+	SalaryBands []CountStat  `json:"salary_bands"`
+}
+
+// Totals -> jumlah alumni dan yang bekerja dari semua angkatan
+func (s *TracerStats) Totals() (total, employed int) {
+	for _, c := range s.Cohorts {
+		total += c.Total
+		employed += c.Employed
+	}
+	return total, employed
+}
+
+const (
+	margin     = 50.0
+	rowHeight  = 18.0
+	barHeight  = 12.0
+	footerLine = 30.0
+)
+
+// layout -> posisi tulis saat ini dan pindah halaman otomatis
+type layout struct {
+	doc *pdf.Document
+	y   float64
+}
+
+func (l *layout) width() float64 { return l.doc.Width - 2*margin }
+
+// ensure pindah ke halaman baru jika sisa ruang kurang dari h
+func (l *layout) ensure(h float64) bool {
+	if l.y+h <= l.doc.Height-margin-footerLine {
+		return false
+	}
+	l.doc.AddPage()
+	l.y = margin
+	return true
+}
+
+func (l *layout) heading(title string) {
+	l.ensure(60)
+	l.y += 14
+	l.doc.SetFillColor(20, 60, 110)
+	l.doc.SetFont(true, 13)
+	l.doc.Text(margin, l.y, title)
+	l.y += 6
+	l.doc.SetStrokeColor(20, 60, 110)
+	l.doc.SetLineWidth(1)
+	l.doc.Line(margin, l.y, margin+l.width(), l.y)
+	l.y += 14
+	l.doc.SetFillColor(0, 0, 0)
+}
+
+func (l *layout) paragraph(text string) {
+	l.ensure(rowHeight)
+	l.doc.SetFont(false, 10)
+	l.doc.SetFillColor(0, 0, 0)
+	l.doc.Text(margin, l.y+10, text)
+	l.y += rowHeight
+}
+
+// column -> kolom tabel; align "right" untuk angka
+type column struct {
+	title string
+	width float64 // proporsi dari lebar halaman
+	right bool
+}
+
+// table menggambar tabel; header diulang jika tabel berlanjut ke halaman berikutnya
+func (l *layout) table(cols []column, rows [][]string) {
+	drawHeader := func() {
+		x := margin
+		l.doc.SetFillColor(225, 232, 242)
+		l.doc.Rect(margin, l.y, l.width(), rowHeight, true)
+		l.doc.SetFillColor(0, 0, 0)
+		l.doc.SetFont(true, 9)
+		for _, c := range cols {
+			l.cell(x, c, c.title)
+			x += c.width * l.width()
+		}
+		l.y += rowHeight
+	}
+
+	l.ensure(rowHeight * 2)
+	drawHeader()
+	l.doc.SetStrokeColor(200, 200, 200)
+	l.doc.SetLineWidth(0.5)
+	for _, row := range rows {
+		if l.ensure(rowHeight) {
+			drawHeader()
+			l.doc.SetStrokeColor(200, 200, 200)
+			l.doc.SetLineWidth(0.5)
+		}
+		l.doc.SetFont(false, 9)
+		x := margin
+		for i, c := range cols {
+			l.cell(x, c, row[i])
+			x += c.width * l.width()
+		}
+		l.y += rowHeight
+		l.doc.Line(margin, l.y, margin+l.width(), l.y)
+	}
+	l.y += 10
+}
+
+func (l *layout) cell(x float64, c column, text string) {
+	w := c.width*l.width() - 8
+	text = l.doc.Truncate(text, w)
+	if c.right {
+		x += w - l.doc.TextWidth(text)
+	}
+	l.doc.Text(x+4, l.y+12, text)
+}
+
+// bars menggambar grafik batang horizontal; value dinormalisasi ke max (atau ke 100 jika percent)
+func (l *layout) bars(labels []string, values []float64, percent bool) {
+	max := 0.0
+	for _, v := range values {
+		if v > max {
+			max = v
+		}
+	}
+	if percent {
+		max = 100
+	}
+	if max == 0 {
+		max = 1
+	}
+
+	labelWidth := l.width() * 0.3
+	valueWidth := 50.0
+	barMax := l.width() - labelWidth - valueWidth
+	for i, label := range labels {
+		l.ensure(barHeight + 6)
+		l.doc.SetFont(false, 8)
+		l.doc.SetFillColor(60, 60, 60)
+		l.doc.Text(margin, l.y+9, l.doc.Truncate(label, labelWidth-6))
+
+		l.doc.SetFillColor(235, 235, 235)
+		l.doc.Rect(margin+labelWidth, l.y, barMax, barHeight, true)
+		l.doc.SetFillColor(46, 112, 190)
+		l.doc.Rect(margin+labelWidth, l.y, barMax*values[i]/max, barHeight, true)
+
+		value := strconv.Itoa(int(values[i]))
+		if percent {
+			value = fmt.Sprintf("%.1f%%", values[i])
+		}
+		l.doc.SetFillColor(0, 0, 0)
+		l.doc.Text(margin+labelWidth+barMax+6, l.y+9, value)
+		l.y += barHeight + 6
+	}
+	l.y += 10
+}
+
+// countSection menulis tabel + grafik untuk breakdown per kategori
+func (l *layout) countSection(title, labelTitle string, items []CountStat) {
+	l.heading(title)
+	if len(items) == 0 {
+		l.paragraph("Tidak ada data.")
+		return
+	}
+
+	total := 0
+	for _, it := range items {
+		total += it.Count
+	}
+	rows := make([][]string, len(items))
+	labels := make([]string, len(items))
+	values := make([]float64, len(items))
+	for i, it := range items {
+		label := it.Label
+		if label == "" {
+			label = "(tidak diisi)"
+		}
+		rows[i] = []string{label, strconv.Itoa(it.Count), fmt.Sprintf("%.1f%%", float64(it.Count)*100/float64(total))}
+		labels[i] = label
+		values[i] = float64(it.Count)
+	}
+	l.table([]column{
+		{title: labelTitle, width: 0.6},
+		{title: "Jumlah", width: 0.2, right: true},
+		{title: "Persentase", width: 0.2, right: true},
+	}, rows)
+	l.bars(labels, values, false)
+}
+
+// WriteTracerPDF menulis laporan tracer study dalam format PDF
+func WriteTracerPDF(w io.Writer, f TracerFilter, s *TracerStats, now time.Time) error {
+	doc := pdf.New()
+	doc.AddPage()
+	l := &layout{doc: doc, y: margin}
+
+	// Judul & ringkasan
+	doc.SetFont(true, 18)
+	doc.Text(margin, l.y+18, "Laporan Tracer Study Alumni")
+	l.y += 30
+	angkatan, jurusan := "Semua", "Semua"
+	if f.Angkatan != 0 {
+		angkatan = strconv.Itoa(f.Angkatan)
+	}
+	if f.Jurusan != "" {
+		jurusan = f.Jurusan
+	}
+	l.paragraph(fmt.Sprintf("Angkatan: %s    Jurusan: %s", angkatan, jurusan))
+	l.paragraph("Dibuat: " + now.Format("02-01-2006 15:04"))
+
+	total, employed := s.Totals()
+	rate := CohortStat{Total: total, Employed: employed}.Rate()
+	l.heading("Ringkasan")
+	l.paragraph(fmt.Sprintf("Jumlah alumni: %d", total))
+	l.paragraph(fmt.Sprintf("Alumni bekerja (pekerjaan aktif): %d", employed))
+	l.paragraph(fmt.Sprintf("Tingkat keterserapan: %.1f%%", rate))
+
+	// Keterserapan per angkatan
+	l.heading("Tingkat Keterserapan per Angkatan")
+	if len(s.Cohorts) == 0 {
+		l.paragraph("Tidak ada data.")
+	} else {
+		rows := make([][]string, len(s.Cohorts))
+		labels := make([]string, len(s.Cohorts))
+		values := make([]float64, len(s.Cohorts))
+		for i, c := range s.Cohorts {
+			rows[i] = []string{strconv.Itoa(c.Angkatan), strconv.Itoa(c.Total), strconv.Itoa(c.Employed), fmt.Sprintf("%.1f%%", c.Rate())}
+			labels[i] = "Angkatan " + strconv.Itoa(c.Angkatan)
+			values[i] = c.Rate()
+		}
+		l.table([]column{
+			{title: "Angkatan", width: 0.25},
+			{title: "Jumlah Alumni", width: 0.25, right: true},
+			{title: "Bekerja", width: 0.25, right: true},
+			{title: "Keterserapan", width: 0.25, right: true},
+		}, rows)
+		l.bars(labels, values, true)
+	}
+
+	l.countSection("Bidang Industri", "Bidang Industri", s.Industries)
+	l.countSection(fmt.Sprintf("%d Perusahaan Teratas", TopEmployers), "Perusahaan", s.Employers)
+	l.countSection("Rentang Gaji", "Rentang Gaji", s.SalaryBands)
+
+	// Footer setiap halaman setelah jumlah halaman diketahui
+	pages := doc.PageCount()
+	for i := 1; i <= pages; i++ {
+		doc.SetPage(i)
+		doc.SetStrokeColor(200, 200, 200)
+		doc.SetLineWidth(0.5)
+		doc.Line(margin, doc.Height-margin, doc.Width-margin, doc.Height-margin)
+		doc.SetFont(false, 8)
+		doc.SetFillColor(110, 110, 110)
+		doc.Text(margin, doc.Height-margin+12, "Laporan Tracer Study Alumni")
+		page := fmt.Sprintf("Halaman %d dari %d", i, pages)
+		doc.Text(doc.Width-margin-doc.TextWidth(page), doc.Height-margin+12, page)
+	}
+
+	return doc.Write(w)
+}
+
+// FileName -> nama file unduhan laporan, mis. tracer-study-2020-informatika-20250101.pdf
+func FileName(f TracerFilter, now time.Time) string {
+	name := "tracer-study"
+	if f.Angkatan != 0 {
+		name += "-" + strconv.Itoa(f.Angkatan)
+	}
+	if f.Jurusan != "" {
+		name += "-" + slug(f.Jurusan)
+	}
+	return name + "-" + now.Format("20060102") + ".pdf"
+}
+
+func slug(s string) string {
+	out := make([]rune, 0, len(s))
+	dash := false
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			out = append(out, r)
+			dash = false
+		case r >= 'A' && r <= 'Z':
+			out = append(out, r+'a'-'A')
+			dash = false
+		case !dash && len(out) > 0:
+			out = append(out, '-')
+			dash = true
+		}
+	}
+	if dash {
+		out = out[:len(out)-1]
+	}
+	return string(out)
+}
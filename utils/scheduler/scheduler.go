@@ -0,0 +1,60 @@
+// Package scheduler menjalankan tugas latar belakang secara berkala di dalam server.
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// running -> goroutine scheduler yang masih aktif, ditunggu oleh Wait
+var running sync.WaitGroup
+
+// Every menjalankan fn sekali saat start lalu setiap interval, sampai ctx dibatalkan.
+// Panic di dalam fn dicatat ke log dan tidak menghentikan jadwal berikutnya.
+func Every(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context)) {
+	running.Add(1)
+	go func() {
+		defer running.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		slog.InfoContext(ctx, "scheduler aktif", "scheduler", name, "interval", interval)
+		for {
+			runSafe(ctx, name, fn)
+
+			select {
+			case <-ctx.Done():
+				slog.InfoContext(context.WithoutCancel(ctx), "scheduler berhenti", "scheduler", name)
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Wait menunggu semua scheduler berhenti (termasuk tugas yang sedang berjalan) setelah ctx Every
+// dibatalkan. Mengembalikan ctx.Err() jika ctx habis lebih dulu.
+func Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func runSafe(ctx context.Context, name string, fn func(ctx context.Context)) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, "scheduler panic", "scheduler", name, "panic", r)
+		}
+	}()
+	fn(ctx)
+}
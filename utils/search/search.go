@@ -0,0 +1,133 @@
+// Package search berisi aturan bersama untuk pencarian gabungan alumni & pekerjaan:
+// validasi query, batas hasil, dan penyorotan kata yang cocok pada cuplikan teks.
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"alumniproject/utils/apperror"
+)
+
+// Batas query & jumlah hasil per tipe entity
+const (
+	MinQueryLength = 2
+	DefaultLimit   = 10
+	MaxLimit       = 50
+)
+
+// Tipe entity hasil pencarian
+const (
+	TypeAlumni    = "alumni"
+	TypePekerjaan = "pekerjaan"
+)
+
+// Penanda kata yang cocok di cuplikan
+const (
+	StartSel = "<mark>"
+	StopSel  = "</mark>"
+)
+
+// snippetWords -> panjang cuplikan (jumlah kata) di sekitar kata pertama yang cocok
+const snippetWords = 24
+
+// Options -> parameter pencarian yang sudah divalidasi
+type Options struct {
+	Query string
+	Types []string
+	Limit int
+}
+
+// Parse memvalidasi q, type (alumni|pekerjaan, kosong = keduanya), dan limit
+func Parse(q, entityType string, limit int) (Options, error) {
+	opts := Options{Query: strings.TrimSpace(q), Limit: limit}
+	if len([]rune(opts.Query)) < MinQueryLength {
+		return opts, apperror.Validation("search.query_too_short").WithArgs(MinQueryLength)
+	}
+
+	switch entityType {
+	case "":
+		opts.Types = []string{TypeAlumni, TypePekerjaan}
+	case TypeAlumni, TypePekerjaan:
+		opts.Types = []string{entityType}
+	default:
+		return opts, apperror.Validation("search.type_invalid")
+	}
+
+	if opts.Limit <= 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit > MaxLimit {
+		opts.Limit = MaxLimit
+	}
+	return opts, nil
+}
+
+// Includes -> tipe entity termasuk dalam pencarian
+func (o Options) Includes(entityType string) bool {
+	for _, t := range o.Types {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// Terms memecah query menjadi kata (huruf kecil, tanpa tanda baca)
+func Terms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Highlight membuat cuplikan dari text: kata yang diawali salah satu term dibungkus StartSel/StopSel,
+// dan teks panjang dipotong di sekitar kata pertama yang cocok. Teks lain di-escape (aman untuk HTML).
+func Highlight(text string, terms []string) string {
+	words := strings.Fields(text)
+	first := -1
+	out := make([]string, len(words))
+	for i, w := range words {
+		out[i] = html.EscapeString(w)
+		if matches(w, terms) {
+			out[i] = StartSel + out[i] + StopSel
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+	if len(out) <= snippetWords {
+		return strings.Join(out, " ")
+	}
+
+	start := 0
+	if first > snippetWords/3 {
+		start = first - snippetWords/3
+	}
+	end := start + snippetWords
+	if end > len(out) {
+		end = len(out)
+		start = end - snippetWords
+	}
+
+	snippet := strings.Join(out[start:end], " ")
+	if start > 0 {
+		snippet = "... " + snippet
+	}
+	if end < len(out) {
+		snippet += " ..."
+	}
+	return snippet
+}
+
+func matches(word string, terms []string) bool {
+	w := strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+	for _, t := range terms {
+		if t != "" && strings.HasPrefix(w, t) {
+			return true
+		}
+	}
+	return false
+}
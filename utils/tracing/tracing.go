@@ -0,0 +1,167 @@
+// Package tracing menyiapkan tracing OpenTelemetry: satu span untuk setiap request Fiber, query SQL,
+// dan perintah MongoDB, sehingga request yang lambat bisa dilihat bagian mana yang makan waktu
+// (middleware, query, atau sisa waktu handler seperti encoding JSON).
+//
+// Span dikirim lewat OTLP (endpoint diatur variabel standar OTEL_EXPORTER_OTLP_ENDPOINT) atau ditulis
+// ke stdout untuk debugging lokal. Tanpa Setup, tracer global OpenTelemetry adalah no-op.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.39.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Exporter span yang didukung
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// tracerName -> nama instrumentation scope untuk semua span aplikasi
+const tracerName = "alumniproject"
+
+// Tracer -> tracer aplikasi dari provider global
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// Setup memasang tracer provider global dengan exporter tertentu dan sampling sebesar ratio
+// (0..1, mengikuti keputusan parent jika request membawa header traceparent).
+// Fungsi yang dikembalikan mengirim span yang tersisa lalu menutup exporter, dipanggil saat shutdown.
+func Setup(ctx context.Context, exporter, serviceName string, ratio float64) (func(context.Context) error, error) {
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exp, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	default:
+		return nil, fmt.Errorf("exporter tracing %q tidak dikenal", exporter)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		slog.Warn("tracing gagal", "error", err)
+	}))
+	return tp.Shutdown, nil
+}
+
+// Middleware membuat span server untuk setiap request, melanjutkan trace dari header traceparent bila ada.
+// Span disimpan di c.UserContext(), jadi span query database yang memakai context itu menjadi anaknya.
+// Dipasang sebelum AccessLog agar status yang dicatat sudah termasuk hasil ErrorHandler.
+func Middleware() fiber.Handler {
+	// Sama seperti metrik: route middleware (Use) juga muncul di c.Route(), jadi route handler yang asli
+	// dikumpulkan sekali setelah semua route didaftarkan
+	var (
+		once   sync.Once
+		routes map[string]bool
+	)
+	return func(c *fiber.Ctx) error {
+		once.Do(func() {
+			routes = make(map[string]bool)
+			for _, r := range c.App().GetRoutes(true) {
+				routes[r.Method+" "+r.Path] = true
+			}
+		})
+
+		ctx := otel.GetTextMapPropagator().Extract(c.UserContext(), headerCarrier{c})
+		// c.Method() & c.Path() menunjuk buffer yang dipakai ulang Fiber, sedangkan span diekspor belakangan
+		method := strings.Clone(c.Method())
+		ctx, span := Tracer().Start(ctx, method, trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(strings.Clone(c.Path())),
+				semconv.ClientAddress(c.IP()),
+			))
+		defer span.End()
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		// Route & user baru diketahui setelah routing dan middleware auth berjalan
+		if route := c.Route().Path; routes[c.Route().Method+" "+route] {
+			span.SetName(method + " " + route)
+			span.SetAttributes(semconv.HTTPRoute(route))
+		}
+		status := c.Response().StatusCode()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if userID := c.Locals("user_id"); userID != nil {
+			span.SetAttributes(semconv.EnduserID(fmt.Sprint(userID)))
+		}
+		if role, ok := c.Locals("role").(string); ok {
+			span.SetAttributes(attribute.String("enduser.role", role))
+		}
+		if err != nil {
+			span.RecordError(err)
+		}
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, "")
+		}
+		return err
+	}
+}
+
+// headerCarrier -> header request Fiber sebagai TextMapCarrier propagator
+type headerCarrier struct {
+	c *fiber.Ctx
+}
+
+func (h headerCarrier) Get(key string) string {
+	return strings.Clone(h.c.Get(key))
+}
+
+func (h headerCarrier) Set(key, value string) {
+	h.c.Request().Header.Set(key, value)
+}
+
+func (h headerCarrier) Keys() []string {
+	headers := h.c.GetReqHeaders()
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// EndSpan menutup span database: error dicatat dan status span ditandai gagal
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,175 @@
+// Package validate memvalidasi struct request berdasarkan tag `validate:"..."`, sehingga aturan
+// input cukup ditulis sekali di model dan dipakai oleh service PostgreSQL maupun MongoDB.
+//
+// Aturan dipisah koma dan dicek berurutan; hanya pesan pertama yang gagal per field yang dilaporkan.
+// Selain required, aturan dilewati jika nilainya kosong sehingga field opsional tetap boleh dikosongkan.
+//
+//	required        string tidak kosong / angka tidak nol
+//	email           alamat email tunggal yang valid
+//	nim             NIM 5-20 karakter huruf, angka, atau titik
+//	year            tahun antara MinYear dan tahun berjalan
+//	date            tanggal format YYYY-MM-DD
+//	min=N           angka minimal N
+//	oneof=a b c     salah satu nilai yang disebutkan
+//	gtefield=Field  tidak boleh lebih kecil dari field lain (angka atau tanggal)
+//
+// Kunci pesan error memakai nama field JSON agar sama dengan body yang dikirim client; pesannya
+// berupa kunci katalog i18n sehingga ikut diterjemahkan sesuai bahasa request.
+package validate
+
+import (
+	"net/mail"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"alumniproject/utils/apperror"
+	"alumniproject/utils/i18n"
+)
+
+// MinYear -> batas bawah angkatan & tahun lulus yang dianggap wajar
+const MinYear = 1950
+
+// DateLayout -> format tanggal pada body request
+const DateLayout = "2006-01-02"
+
+var nimPattern = regexp.MustCompile(`^[0-9A-Za-z.]{5,20}$`)
+
+// Body mem-parsing body request ke out lalu memvalidasinya. Semua field yang tidak valid
+// dikembalikan sekaligus sebagai satu error validasi.
+func Body(c *fiber.Ctx, out interface{}) error {
+	if err := c.BodyParser(out); err != nil {
+		return apperror.Validation("request.invalid_body")
+	}
+	return Check(out)
+}
+
+// Check memvalidasi v, nil jika semua field valid
+func Check(v interface{}) error {
+	fields := Struct(v)
+	if len(fields) == 0 {
+		return nil
+	}
+	// Tanpa detail, pesan per field digabung menjadi detail problem
+	return apperror.Validation("").WithFields(fields)
+}
+
+// Struct mengembalikan pesan error per field (nama JSON), kosong jika valid
+func Struct(v interface{}) map[string]i18n.Message {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	fields := make(map[string]i18n.Message)
+	if rv.Kind() == reflect.Struct {
+		checkStruct(rv, fields)
+	}
+	return fields
+}
+
+func checkStruct(rv reflect.Value, fields map[string]i18n.Message) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct {
+			checkStruct(rv.Field(i), fields)
+			continue
+		}
+		tag := sf.Tag.Get("validate")
+		if tag == "" || !sf.IsExported() {
+			continue
+		}
+		name := jsonName(sf)
+		if _, failed := fields[name]; failed {
+			continue
+		}
+		for _, rule := range strings.Split(tag, ",") {
+			if msg, ok := checkRule(rv, rv.Field(i), rule); !ok {
+				fields[name] = msg
+				break
+			}
+		}
+	}
+}
+
+// checkRule -> pesan error dan false jika field tidak memenuhi rule
+func checkRule(parent, field reflect.Value, rule string) (i18n.Message, bool) {
+	name, param, _ := strings.Cut(rule, "=")
+	if name == "required" {
+		if field.IsZero() {
+			return i18n.New("validation.required"), false
+		}
+		return i18n.Message{}, true
+	}
+	if field.IsZero() {
+		return i18n.Message{}, true
+	}
+
+	switch name {
+	case "email":
+		s := field.String()
+		if addr, err := mail.ParseAddress(s); err != nil || addr.Address != s {
+			return i18n.New("validation.email"), false
+		}
+	case "nim":
+		if !nimPattern.MatchString(field.String()) {
+			return i18n.New("validation.nim"), false
+		}
+	case "year":
+		maxYear := time.Now().Year()
+		if y := field.Int(); y < MinYear || y > int64(maxYear) {
+			return i18n.New("validation.year", MinYear, maxYear), false
+		}
+	case "date":
+		if _, err := time.Parse(DateLayout, field.String()); err != nil {
+			return i18n.New("validation.date"), false
+		}
+	case "min":
+		n, _ := strconv.ParseInt(param, 10, 64)
+		if field.Int() < n {
+			return i18n.New("validation.min", n), false
+		}
+	case "oneof":
+		allowed := strings.Fields(param)
+		for _, a := range allowed {
+			if field.String() == a {
+				return i18n.Message{}, true
+			}
+		}
+		return i18n.New("validation.oneof", strings.Join(allowed, ", ")), false
+	case "gtefield":
+		other, ok := parent.Type().FieldByName(param)
+		if !ok {
+			return i18n.Message{}, true
+		}
+		if before(field, parent.FieldByIndex(other.Index)) {
+			return i18n.New("validation.gtefield", jsonName(other)), false
+		}
+	}
+	return i18n.Message{}, true
+}
+
+// before -> true jika a lebih kecil dari b. Nilai kosong atau tanggal tidak valid tidak dibandingkan.
+func before(a, b reflect.Value) bool {
+	if b.IsZero() {
+		return false
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.String:
+		ta, errA := time.Parse(DateLayout, a.String())
+		tb, errB := time.Parse(DateLayout, b.String())
+		return errA == nil && errB == nil && ta.Before(tb)
+	}
+	return false
+}
+
+func jsonName(sf reflect.StructField) string {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return sf.Name
+	}
+	return name
+}
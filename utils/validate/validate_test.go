@@ -0,0 +1,160 @@
+package validate_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	models "alumniproject/app/models/postgresql"
+	"alumniproject/utils/validate"
+)
+
+func validAlumni() models.CreateAlumniRequest {
+	return models.CreateAlumniRequest{
+		NIM:        "21.11.0001",
+		Nama:       "Budi",
+		Jurusan:    "Informatika",
+		Angkatan:   2018,
+		TahunLulus: 2022,
+		Email:      "budi@example.com",
+	}
+}
+
+func validPekerjaan() models.UpdatePekerjaanRequest {
+	return models.UpdatePekerjaanRequest{
+		NamaPerusahaan:    "PT Maju",
+		PosisiJabatan:     "Backend Engineer",
+		BidangIndustri:    "Teknologi",
+		LokasiKerja:       "Jakarta",
+		TanggalMulaiKerja: "2022-08-01",
+	}
+}
+
+// keys -> kunci katalog pesan per field, agar test tidak bergantung pada terjemahan
+func keys(v interface{}) map[string]string {
+	out := map[string]string{}
+	for field, msg := range validate.Struct(v) {
+		out[field] = msg.Key
+	}
+	return out
+}
+
+func TestStructAlumni(t *testing.T) {
+	nextYear := time.Now().Year() + 1
+	tests := []struct {
+		name   string
+		modify func(r *models.CreateAlumniRequest)
+		want   map[string]string
+	}{
+		{"valid", func(r *models.CreateAlumniRequest) {}, map[string]string{}},
+		{
+			"required kosong",
+			func(r *models.CreateAlumniRequest) { r.NIM, r.Nama, r.Email = "", "", "" },
+			map[string]string{"nim": "validation.required", "nama": "validation.required", "email": "validation.required"},
+		},
+		{
+			"opsional kosong dilewati",
+			func(r *models.CreateAlumniRequest) { r.Angkatan, r.TahunLulus, r.NoTelepon = 0, 0, "" },
+			map[string]string{},
+		},
+		{"nim terlalu pendek", func(r *models.CreateAlumniRequest) { r.NIM = "2111" }, map[string]string{"nim": "validation.nim"}},
+		{"nim karakter tidak valid", func(r *models.CreateAlumniRequest) { r.NIM = "21-11-0001" }, map[string]string{"nim": "validation.nim"}},
+		{"email dengan nama", func(r *models.CreateAlumniRequest) { r.Email = "Budi <budi@example.com>" }, map[string]string{"email": "validation.email"}},
+		{"tahun di masa depan", func(r *models.CreateAlumniRequest) { r.Angkatan, r.TahunLulus = nextYear, 0 }, map[string]string{"angkatan": "validation.year"}},
+		{"lulus sebelum angkatan", func(r *models.CreateAlumniRequest) { r.TahunLulus = 2017 }, map[string]string{"tahun_lulus": "validation.gtefield"}},
+		{"lulus sama dengan angkatan", func(r *models.CreateAlumniRequest) { r.TahunLulus = 2018 }, map[string]string{}},
+		{"angkatan kosong tidak dibandingkan", func(r *models.CreateAlumniRequest) { r.Angkatan = 0 }, map[string]string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validAlumni()
+			tt.modify(&req)
+			if got := keys(&req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructPekerjaan(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *models.UpdatePekerjaanRequest)
+		want   map[string]string
+	}{
+		{"valid", func(r *models.UpdatePekerjaanRequest) {}, map[string]string{}},
+		{"tanggal bukan YYYY-MM-DD", func(r *models.UpdatePekerjaanRequest) { r.TanggalMulaiKerja = "01-08-2022" }, map[string]string{"tanggal_mulai_kerja": "validation.date"}},
+		{"selesai sebelum mulai", func(r *models.UpdatePekerjaanRequest) { r.TanggalSelesaiKerja = "2022-07-31" }, map[string]string{"tanggal_selesai_kerja": "validation.gtefield"}},
+		{"selesai sama dengan mulai", func(r *models.UpdatePekerjaanRequest) { r.TanggalSelesaiKerja = "2022-08-01" }, map[string]string{}},
+		{
+			"mulai tidak valid tidak dibandingkan",
+			func(r *models.UpdatePekerjaanRequest) {
+				r.TanggalMulaiKerja, r.TanggalSelesaiKerja = "kemarin", "2022-07-31"
+			},
+			map[string]string{"tanggal_mulai_kerja": "validation.date"},
+		},
+		{"oneof cocok", func(r *models.UpdatePekerjaanRequest) { r.StatusPekerjaan = "resigned" }, map[string]string{}},
+		{"oneof tidak cocok", func(r *models.UpdatePekerjaanRequest) { r.StatusPekerjaan = "cuti" }, map[string]string{"status_pekerjaan": "validation.oneof"}},
+		{"oneof peka huruf besar", func(r *models.UpdatePekerjaanRequest) { r.StatusPekerjaan = "Aktif" }, map[string]string{"status_pekerjaan": "validation.oneof"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validPekerjaan()
+			tt.modify(&req)
+			if got := keys(&req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestStructEmbedded memastikan field dari struct yang di-embed ikut divalidasi dengan nama JSON-nya
+func TestStructEmbedded(t *testing.T) {
+	item := models.BulkUpdatePekerjaanItem{UpdatePekerjaanRequest: validPekerjaan()}
+	item.TanggalSelesaiKerja = "2022-01-01"
+	item.NamaPerusahaan = ""
+
+	want := map[string]string{
+		"id":                    "validation.required",
+		"nama_perusahaan":       "validation.required",
+		"tanggal_selesai_kerja": "validation.gtefield",
+	}
+	if got := keys(&item); !reflect.DeepEqual(got, want) {
+		t.Errorf("Struct() = %v, want %v", got, want)
+	}
+
+	fields := validate.Struct(item)
+	if args := fields["tanggal_selesai_kerja"].Args; len(args) != 1 || args[0] != "tanggal_mulai_kerja" {
+		t.Errorf("gtefield args = %v, want nama JSON field pembanding", args)
+	}
+}
+
+func TestStructMin(t *testing.T) {
+	for _, v := range []int{-1, 0, 1} {
+		req := models.RevertAlumniRequest{Version: v}
+		want := map[string]string{}
+		switch {
+		case v == 0:
+			want["version"] = "validation.required"
+		case v < 1:
+			want["version"] = "validation.min"
+		}
+		t.Run(strconv.Itoa(v), func(t *testing.T) {
+			if got := keys(req); !reflect.DeepEqual(got, want) {
+				t.Errorf("Struct() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	req := validAlumni()
+	if err := validate.Check(&req); err != nil {
+		t.Errorf("Check(valid) = %v, want nil", err)
+	}
+	req.Nama = ""
+	if err := validate.Check(&req); err == nil {
+		t.Error("Check tanpa nama tidak mengembalikan error")
+	}
+}